@@ -0,0 +1,444 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+)
+
+func newApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Run a plan of replies, resolutions, and dismissals",
+		Long: `Run a batch of review actions from a JSON plan file.
+
+A plan is a list of actions, either as a JSON array or as {"actions": [...]}:
+
+  {"action": "reply", "thread_id": "PRRT_...", "body": "Fixed in abc123",
+   "idempotency_key": "fix-abc123"}
+  {"action": "resolve", "thread_id": "PRRT_..."}
+  {"action": "unresolve", "thread_id": "PRRT_..."}
+  {"action": "dismiss", "review_id": "PRR_...", "message": "superseded"}
+
+The whole plan is validated against a single fetch of the PR's threads and
+reviews before anything runs: threads must exist and allow the action,
+replies need a body, and dismissals need a message and a stale blocking
+review. If any action is invalid, nothing is executed. Resolving a thread
+that is already resolved (or unresolving an open one) is a no-op, as is a
+reply whose idempotency_key is already in the thread (see reply
+--idempotency-key).
+
+Actions on different threads and reviews run concurrently; actions on the
+same thread run in plan order, and stop there once one fails. With
+--fail-fast, no further action starts after the first failure.
+
+Exit codes: 0 = all success, 1 = partial failure, 2 = invalid plan or
+total failure.`,
+		Example: `  # Preview a plan
+  gh ghent apply --pr 42 --plan plan.json --dry-run
+
+  # Run it, stopping at the first failure
+  gh ghent apply --pr 42 --plan plan.json --fail-fast
+
+  # Read the plan from stdin
+  jq '[.threads[] | {action: "resolve", thread_id: .id}]' threads.json | gh ghent apply --pr 42 --plan -`,
+		RunE: runApply,
+	}
+
+	cmd.Flags().String("plan", "", "JSON plan file of actions to run (use '-' for stdin)")
+	cmd.Flags().Bool("dry-run", false, "validate the plan and show what would run without executing")
+	cmd.Flags().Bool("fail-fast", false, "start no further actions after the first failure")
+	cmd.Flags().Int("concurrency", 4, "maximum number of threads and reviews acted on at once")
+	_ = cmd.MarkFlagRequired("plan")
+
+	return cmd
+}
+
+// applyClient is the subset of the GitHub client the apply command needs.
+type applyClient interface {
+	domain.AllThreadFetcher
+	domain.CommentReplier
+	domain.ThreadResolver
+	domain.ReviewFetcher
+	domain.ReviewDismisser
+}
+
+// applyOptions holds the parsed apply command flags.
+type applyOptions struct {
+	dryRun      bool
+	failFast    bool
+	concurrency int
+}
+
+func runApply(cmd *cobra.Command, _ []string) error {
+	planPath, err := cmd.Flags().GetString("plan")
+	if err != nil {
+		return err
+	}
+	opts := applyOptions{}
+	if opts.dryRun, err = cmd.Flags().GetBool("dry-run"); err != nil {
+		return err
+	}
+	if opts.failFast, err = cmd.Flags().GetBool("fail-fast"); err != nil {
+		return err
+	}
+	if opts.concurrency, err = cmd.Flags().GetInt("concurrency"); err != nil {
+		return err
+	}
+	if opts.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	actions, err := readPlan(planPath)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	results, err := buildPlanResults(ctx, GitHubClient(), owner, repo, Flags.PR, actions, opts)
+	if err != nil {
+		return err
+	}
+
+	f, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	if err := f.FormatPlanResults(os.Stdout, results); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+
+	if exitCode := planExitCode(results); exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+// readPlan reads a plan file, or stdin for "-".
+func readPlan(path string) ([]domain.PlanAction, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // user-specified plan file
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read plan: %w", err)
+	}
+	return parsePlan(data)
+}
+
+// parsePlan decodes a plan given as a JSON array of actions or as an object
+// with an "actions" array. Unknown fields are rejected so that a misspelled
+// key fails loudly instead of being dropped.
+func parsePlan(data []byte) ([]domain.PlanAction, error) {
+	data = bytes.TrimSpace(data)
+	var actions []domain.PlanAction
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var err error
+	if bytes.HasPrefix(data, []byte("[")) {
+		err = dec.Decode(&actions)
+	} else {
+		var plan struct {
+			Actions []domain.PlanAction `json:"actions"`
+		}
+		err = dec.Decode(&plan)
+		actions = plan.Actions
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse plan: %w", err)
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("plan has no actions")
+	}
+	return actions, nil
+}
+
+func buildPlanResults(
+	ctx context.Context,
+	client applyClient,
+	owner, repo string,
+	pr int,
+	actions []domain.PlanAction,
+	opts applyOptions,
+) (*domain.PlanResults, error) {
+	var threads map[string]domain.ReviewThread
+	var reviews []domain.Review
+	if slices.ContainsFunc(actions, func(a domain.PlanAction) bool { return a.Action != domain.PlanDismiss }) {
+		fetched, err := client.FetchAllThreads(ctx, owner, repo, pr)
+		if err != nil {
+			return nil, fmt.Errorf("fetch threads: %w", err)
+		}
+		threads = make(map[string]domain.ReviewThread, len(fetched.Threads))
+		for _, t := range fetched.Threads {
+			threads[t.ID] = t
+		}
+	}
+	if slices.ContainsFunc(actions, func(a domain.PlanAction) bool { return a.Action == domain.PlanDismiss }) {
+		var err error
+		if reviews, err = client.FetchReviews(ctx, owner, repo, pr); err != nil {
+			return nil, fmt.Errorf("fetch reviews: %w", err)
+		}
+	}
+
+	results := &domain.PlanResults{
+		PRNumber: pr,
+		Results:  validatePlan(actions, threads, reviews),
+		DryRun:   opts.dryRun,
+	}
+	valid := !slices.ContainsFunc(results.Results, func(r domain.PlanActionResult) bool {
+		return r.Status == domain.PlanStatusInvalid
+	})
+
+	for i := range results.Results {
+		r := &results.Results[i]
+		switch {
+		case !valid && r.Status != domain.PlanStatusInvalid:
+			r.Status, r.Error = domain.PlanStatusSkipped, "the plan has invalid actions"
+		case valid && opts.dryRun && r.Status == "":
+			r.Status = domain.PlanStatusWouldRun
+		}
+	}
+	if valid && !opts.dryRun {
+		executePlan(ctx, client, owner, repo, pr, results.Results, threads, reviews, opts)
+	}
+
+	for _, r := range results.Results {
+		switch r.Status {
+		case domain.PlanStatusDone, domain.PlanStatusNoop, domain.PlanStatusWouldRun:
+			results.SuccessCount++
+		case domain.PlanStatusFailed, domain.PlanStatusInvalid:
+			results.FailureCount++
+		case domain.PlanStatusSkipped:
+			results.SkippedCount++
+		}
+	}
+	return results, nil
+}
+
+// validatePlan checks every action against the fetched threads and reviews.
+// Valid actions are left without a status, except resolutions that would
+// not change the thread, which become no-ops. Resolution state is tracked
+// through the plan, so "unresolve X, resolve X" is two real actions.
+func validatePlan(actions []domain.PlanAction, threads map[string]domain.ReviewThread, reviews []domain.Review) []domain.PlanActionResult {
+	results := make([]domain.PlanActionResult, len(actions))
+	resolved := make(map[string]bool)
+	for id, t := range threads {
+		resolved[id] = t.IsResolved
+	}
+
+	for i, a := range actions {
+		r := &results[i]
+		r.Index, r.PlanAction = i, a
+		invalid := func(format string, args ...any) {
+			r.Status, r.Error = domain.PlanStatusInvalid, fmt.Sprintf(format, args...)
+		}
+
+		if a.Action == domain.PlanDismiss {
+			switch {
+			case a.ReviewID == "":
+				invalid("dismiss requires review_id")
+			case strings.TrimSpace(a.Message) == "":
+				invalid("dismiss requires a message")
+			default:
+				if _, err := selectDismissReviews(reviews, a.ReviewID, "", false); err != nil {
+					invalid("%v", err)
+				}
+			}
+			continue
+		}
+
+		t, ok := threads[a.ThreadID]
+		switch {
+		case a.Action != domain.PlanReply && a.Action != domain.PlanResolve && a.Action != domain.PlanUnresolve:
+			invalid("unknown action %q (want reply, resolve, unresolve, or dismiss)", a.Action)
+		case a.ThreadID == "":
+			invalid("%s requires thread_id", a.Action)
+		case !ok:
+			invalid("thread %s not found", a.ThreadID)
+		case a.Action == domain.PlanReply:
+			var posted *domain.Comment
+			if a.IdempotencyKey != "" {
+				posted = findIdempotentReply(t, a.IdempotencyKey)
+			}
+			switch {
+			case strings.TrimSpace(a.Body) == "":
+				invalid("reply requires a body")
+			case a.IdempotencyKey != "" && !validIdempotencyKey(a.IdempotencyKey):
+				invalid("invalid idempotency_key %q", a.IdempotencyKey)
+			case posted != nil:
+				r.Status, r.URL = domain.PlanStatusNoop, posted.URL
+			case !t.ViewerCanReply:
+				invalid("viewer cannot reply to thread %s", a.ThreadID)
+			case len(t.Comments) == 0:
+				invalid("thread %s has no comments", a.ThreadID)
+			}
+		default:
+			unresolve := a.Action == domain.PlanUnresolve
+			switch {
+			case resolved[a.ThreadID] != unresolve:
+				r.Status = domain.PlanStatusNoop
+			// Permissions are only known for the thread as fetched.
+			case !unresolve && !t.IsResolved && !t.ViewerCanResolve:
+				invalid("permission denied: cannot resolve thread at %s:%d", t.Path, t.Line)
+			case unresolve && t.IsResolved && !t.ViewerCanUnresolve:
+				invalid("permission denied: cannot unresolve thread at %s:%d", t.Path, t.Line)
+			default:
+				resolved[a.ThreadID] = !unresolve
+			}
+		}
+	}
+	return results
+}
+
+// findIdempotentReply returns the comment in t carrying the idempotency
+// key, or nil.
+func findIdempotentReply(t domain.ReviewThread, key string) *domain.Comment {
+	for i := range t.Comments {
+		if ghub.IdempotencyKey(t.Comments[i].Body) == key {
+			return &t.Comments[i]
+		}
+	}
+	return nil
+}
+
+// validIdempotencyKey reports whether key can be embedded in a reply.
+func validIdempotencyKey(key string) bool {
+	_, err := ghub.WithIdempotencyKey("", key)
+	return err == nil
+}
+
+// executePlan runs the validated actions. Each thread or review is acted on
+// by one worker, in plan order, so a reply lands before its resolution;
+// at most opts.concurrency workers run at once.
+func executePlan(
+	ctx context.Context,
+	client applyClient,
+	owner, repo string,
+	pr int,
+	results []domain.PlanActionResult,
+	threads map[string]domain.ReviewThread,
+	reviews []domain.Review,
+	opts applyOptions,
+) {
+	var targets []string
+	byTarget := make(map[string][]int)
+	for i, r := range results {
+		key := "thread:" + r.ThreadID
+		if r.Action == domain.PlanDismiss {
+			key = "review:" + r.ReviewID
+		}
+		if _, ok := byTarget[key]; !ok {
+			targets = append(targets, key)
+		}
+		byTarget[key] = append(byTarget[key], i)
+	}
+
+	var stopped atomic.Bool
+	var g errgroup.Group
+	g.SetLimit(opts.concurrency)
+	for _, key := range targets {
+		g.Go(func() error {
+			failed := false
+			for _, i := range byTarget[key] {
+				r := &results[i]
+				if r.Status == domain.PlanStatusNoop {
+					continue
+				}
+				switch {
+				case failed:
+					r.Status, r.Error = domain.PlanStatusSkipped, "an earlier action on the same target failed"
+					continue
+				case stopped.Load():
+					r.Status, r.Error = domain.PlanStatusSkipped, "stopped after an earlier failure"
+					continue
+				}
+				if err := runPlanAction(ctx, client, owner, repo, pr, r, threads, reviews); err != nil {
+					r.Status, r.Error = domain.PlanStatusFailed, err.Error()
+					failed = true
+					if opts.failFast {
+						stopped.Store(true)
+					}
+					continue
+				}
+				r.Status = domain.PlanStatusDone
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+// runPlanAction executes one validated action, recording the reply URL.
+func runPlanAction(
+	ctx context.Context,
+	client applyClient,
+	owner, repo string,
+	pr int,
+	r *domain.PlanActionResult,
+	threads map[string]domain.ReviewThread,
+	reviews []domain.Review,
+) error {
+	switch r.Action {
+	case domain.PlanReply:
+		body := r.Body
+		if r.IdempotencyKey != "" {
+			var err error
+			if body, err = ghub.WithIdempotencyKey(body, r.IdempotencyKey); err != nil {
+				return err
+			}
+		}
+		// Like reply, target the thread's last comment.
+		comments := threads[r.ThreadID].Comments
+		reply, err := client.ReplyToComment(ctx, owner, repo, pr, r.ThreadID, comments[len(comments)-1].DatabaseID, body)
+		if err != nil {
+			return err
+		}
+		r.URL = reply.URL
+	case domain.PlanResolve, domain.PlanUnresolve:
+		if _, msg := doResolve(ctx, client, owner, repo, pr, r.ThreadID, r.Action == domain.PlanUnresolve); msg != "" {
+			return errors.New(msg)
+		}
+	case domain.PlanDismiss:
+		selected, err := selectDismissReviews(reviews, r.ReviewID, "", false)
+		if err != nil {
+			return err
+		}
+		if _, err := client.DismissReview(ctx, owner, repo, pr, selected[0], r.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planExitCode follows the mutation commands: 0 = all success, 1 = partial
+// failure, 2 = invalid plan or total failure.
+func planExitCode(results *domain.PlanResults) int {
+	if results.FailureCount > 0 && results.SuccessCount > 0 {
+		return 1
+	}
+	if results.FailureCount > 0 {
+		return 2
+	}
+	return 0
+}
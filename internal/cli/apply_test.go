@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+)
+
+// stubApplyClient records calls as "action:target". It is safe for the
+// concurrent calls executePlan makes.
+type stubApplyClient struct {
+	stubDismissClient
+	threads []domain.ReviewThread
+	errs    map[string]error // keyed like calls
+
+	mu        sync.Mutex
+	calls     []string
+	repliedTo []int64
+	bodies    []string
+	fetches   int
+}
+
+func (s *stubApplyClient) record(call string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call)
+	return s.errs[call]
+}
+
+func (s *stubApplyClient) FetchAllThreads(_ context.Context, _, _ string, pr int) (*domain.CommentsResult, error) {
+	s.fetches++
+	return &domain.CommentsResult{PRNumber: pr, Threads: s.threads}, nil
+}
+
+func (s *stubApplyClient) ReplyToComment(_ context.Context, _, _ string, _ int, threadID string, commentID int64, body string) (*domain.ReplyResult, error) {
+	if err := s.record("reply:" + threadID); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.repliedTo = append(s.repliedTo, commentID)
+	s.bodies = append(s.bodies, body)
+	s.mu.Unlock()
+	return &domain.ReplyResult{ThreadID: threadID, Body: body, URL: "https://example.com/" + threadID}, nil
+}
+
+func (s *stubApplyClient) ResolveThread(_ context.Context, _, _ string, _ int, threadID string) (*domain.ResolveResult, error) {
+	if err := s.record("resolve:" + threadID); err != nil {
+		return nil, err
+	}
+	return &domain.ResolveResult{ThreadID: threadID, IsResolved: true, Action: "resolved"}, nil
+}
+
+func (s *stubApplyClient) UnresolveThread(_ context.Context, _, _ string, _ int, threadID string) (*domain.ResolveResult, error) {
+	if err := s.record("unresolve:" + threadID); err != nil {
+		return nil, err
+	}
+	return &domain.ResolveResult{ThreadID: threadID, Action: "unresolved"}, nil
+}
+
+func (s *stubApplyClient) DismissReview(ctx context.Context, owner, repo string, pr int, review domain.Review, message string) (*domain.DismissResult, error) {
+	if err := s.record("dismiss:" + review.ID); err != nil {
+		return nil, err
+	}
+	return s.stubDismissClient.DismissReview(ctx, owner, repo, pr, review, message)
+}
+
+func applyFixture() *stubApplyClient {
+	comments := []domain.Comment{{ID: "C1", DatabaseID: 1}, {ID: "C2", DatabaseID: 2}}
+	return &stubApplyClient{
+		stubDismissClient: stubDismissClient{reviews: staleDismissFixture()},
+		threads: []domain.ReviewThread{
+			{ID: "T1", Path: "a.go", Line: 1, ViewerCanReply: true, ViewerCanResolve: true, Comments: comments},
+			{ID: "T2", Path: "b.go", Line: 2, ViewerCanReply: true, ViewerCanResolve: true, Comments: comments},
+			{ID: "T3", Path: "c.go", Line: 3, IsResolved: true, ViewerCanUnresolve: true, Comments: comments},
+			{ID: "T4", Path: "d.go", Line: 4, Comments: comments},
+		},
+	}
+}
+
+func planStatuses(results *domain.PlanResults) []string {
+	var got []string
+	for _, r := range results.Results {
+		got = append(got, r.Status)
+	}
+	return got
+}
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []domain.PlanAction
+		wantErr bool
+	}{
+		{
+			name: "array",
+			data: ` [{"action": "resolve", "thread_id": "T1"}]`,
+			want: []domain.PlanAction{{Action: "resolve", ThreadID: "T1"}},
+		},
+		{
+			name: "object",
+			data: `{"actions": [{"action": "dismiss", "review_id": "PRR_1", "message": "stale"}]}`,
+			want: []domain.PlanAction{{Action: "dismiss", ReviewID: "PRR_1", Message: "stale"}},
+		},
+		{name: "unknown field", data: `[{"action": "resolve", "thread": "T1"}]`, wantErr: true},
+		{name: "empty", data: `{"actions": []}`, wantErr: true},
+		{name: "not JSON", data: `resolve T1`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePlan([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parsePlan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildPlanResults(t *testing.T) {
+	plan := []domain.PlanAction{
+		{Action: domain.PlanReply, ThreadID: "T1", Body: "Fixed"},
+		{Action: domain.PlanResolve, ThreadID: "T1"},
+		{Action: domain.PlanResolve, ThreadID: "T3"},   // already resolved
+		{Action: domain.PlanUnresolve, ThreadID: "T3"}, // reopened
+		{Action: domain.PlanResolve, ThreadID: "T3"},   // and resolved again
+		{Action: domain.PlanDismiss, ReviewID: "101", Message: "superseded"},
+	}
+
+	tests := []struct {
+		name       string
+		opts       applyOptions
+		errs       map[string]error
+		wantStatus []string
+		wantCalls  []string
+		wantExit   int
+	}{
+		{
+			name: "dry run",
+			opts: applyOptions{dryRun: true, concurrency: 4},
+			wantStatus: []string{
+				domain.PlanStatusWouldRun, domain.PlanStatusWouldRun, domain.PlanStatusNoop,
+				domain.PlanStatusWouldRun, domain.PlanStatusWouldRun, domain.PlanStatusWouldRun,
+			},
+		},
+		{
+			name: "all succeed",
+			opts: applyOptions{concurrency: 4},
+			wantStatus: []string{
+				domain.PlanStatusDone, domain.PlanStatusDone, domain.PlanStatusNoop,
+				domain.PlanStatusDone, domain.PlanStatusDone, domain.PlanStatusDone,
+			},
+			wantCalls: []string{"dismiss:PRR_1", "reply:T1", "resolve:T1", "resolve:T3", "unresolve:T3"},
+		},
+		{
+			name: "failure stops its thread only",
+			opts: applyOptions{concurrency: 1},
+			errs: map[string]error{"reply:T1": errors.New("boom")},
+			wantStatus: []string{
+				domain.PlanStatusFailed, domain.PlanStatusSkipped, domain.PlanStatusNoop,
+				domain.PlanStatusDone, domain.PlanStatusDone, domain.PlanStatusDone,
+			},
+			wantCalls: []string{"dismiss:PRR_1", "reply:T1", "resolve:T3", "unresolve:T3"},
+			wantExit:  1,
+		},
+		{
+			name: "fail fast",
+			opts: applyOptions{concurrency: 1, failFast: true},
+			errs: map[string]error{"reply:T1": errors.New("boom")},
+			wantStatus: []string{
+				domain.PlanStatusFailed, domain.PlanStatusSkipped, domain.PlanStatusNoop,
+				domain.PlanStatusSkipped, domain.PlanStatusSkipped, domain.PlanStatusSkipped,
+			},
+			wantCalls: []string{"reply:T1"},
+			wantExit:  1, // the no-op counts as a success
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := applyFixture()
+			client.errs = tt.errs
+			got, err := buildPlanResults(context.Background(), client, "owner", "repo", 42, plan, tt.opts)
+			if err != nil {
+				t.Fatalf("buildPlanResults() error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantStatus, planStatuses(got)); diff != "" {
+				t.Errorf("statuses mismatch (-want +got):\n%s", diff)
+			}
+			if reply, resolve := slices.Index(client.calls, "reply:T1"), slices.Index(client.calls, "resolve:T1"); resolve >= 0 && resolve < reply {
+				t.Errorf("resolved T1 before replying: %v", client.calls)
+			}
+			slices.Sort(client.calls)
+			if diff := cmp.Diff(tt.wantCalls, client.calls); diff != "" {
+				t.Errorf("calls mismatch (-want +got):\n%s", diff)
+			}
+			if client.fetches != 1 {
+				t.Errorf("fetched threads %d times, want 1", client.fetches)
+			}
+			if code := planExitCode(got); code != tt.wantExit {
+				t.Errorf("planExitCode() = %d, want %d", code, tt.wantExit)
+			}
+		})
+	}
+}
+
+func TestBuildPlanResults_ReplyTargetsLastComment(t *testing.T) {
+	client := applyFixture()
+	got, err := buildPlanResults(context.Background(), client, "owner", "repo", 42,
+		[]domain.PlanAction{{Action: domain.PlanReply, ThreadID: "T2", Body: "Done"}}, applyOptions{concurrency: 1})
+	if err != nil {
+		t.Fatalf("buildPlanResults() error: %v", err)
+	}
+	if r := got.Results[0]; r.Status != domain.PlanStatusDone || r.URL != "https://example.com/T2" {
+		t.Errorf("result = %+v, want done with the reply URL", r)
+	}
+	if diff := cmp.Diff([]int64{2}, client.repliedTo); diff != "" {
+		t.Errorf("replied to comments mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidatePlan(t *testing.T) {
+	client := applyFixture()
+	threads := make(map[string]domain.ReviewThread)
+	for _, th := range client.threads {
+		threads[th.ID] = th
+	}
+
+	tests := []struct {
+		name      string
+		action    domain.PlanAction
+		wantError string
+	}{
+		{name: "valid reply", action: domain.PlanAction{Action: "reply", ThreadID: "T1", Body: "ok"}},
+		{name: "unknown action", action: domain.PlanAction{Action: "close", ThreadID: "T1"}, wantError: `unknown action "close" (want reply, resolve, unresolve, or dismiss)`},
+		{name: "missing thread", action: domain.PlanAction{Action: "resolve"}, wantError: "resolve requires thread_id"},
+		{name: "unknown thread", action: domain.PlanAction{Action: "resolve", ThreadID: "T9"}, wantError: "thread T9 not found"},
+		{name: "empty body", action: domain.PlanAction{Action: "reply", ThreadID: "T1", Body: " "}, wantError: "reply requires a body"},
+		{name: "cannot reply", action: domain.PlanAction{Action: "reply", ThreadID: "T4", Body: "ok"}, wantError: "viewer cannot reply to thread T4"},
+		{name: "invalid idempotency key", action: domain.PlanAction{Action: "reply", ThreadID: "T1", Body: "ok", IdempotencyKey: "a b"}, wantError: `invalid idempotency_key "a b"`},
+		{name: "cannot resolve", action: domain.PlanAction{Action: "resolve", ThreadID: "T4"}, wantError: "permission denied: cannot resolve thread at d.go:4"},
+		{name: "dismiss without message", action: domain.PlanAction{Action: "dismiss", ReviewID: "PRR_1"}, wantError: "dismiss requires a message"},
+		{
+			name:      "dismiss current review",
+			action:    domain.PlanAction{Action: "dismiss", ReviewID: "PRR_3", Message: "x"},
+			wantError: `review "PRR_3" is not a stale CHANGES_REQUESTED review on this PR`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validatePlan([]domain.PlanAction{tt.action}, threads, client.reviews)[0]
+			if got.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", got.Error, tt.wantError)
+			}
+			if invalid := got.Status == domain.PlanStatusInvalid; invalid != (tt.wantError != "") {
+				t.Errorf("Status = %q, want invalid only with an error", got.Status)
+			}
+		})
+	}
+}
+
+func TestBuildPlanResults_InvalidPlanRunsNothing(t *testing.T) {
+	client := applyFixture()
+	plan := []domain.PlanAction{
+		{Action: domain.PlanResolve, ThreadID: "T1"},
+		{Action: domain.PlanResolve, ThreadID: "T9"},
+	}
+	got, err := buildPlanResults(context.Background(), client, "owner", "repo", 42, plan, applyOptions{concurrency: 4})
+	if err != nil {
+		t.Fatalf("buildPlanResults() error: %v", err)
+	}
+	want := []string{domain.PlanStatusSkipped, domain.PlanStatusInvalid}
+	if diff := cmp.Diff(want, planStatuses(got)); diff != "" {
+		t.Errorf("statuses mismatch (-want +got):\n%s", diff)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("ran %v for an invalid plan", client.calls)
+	}
+	if code := planExitCode(got); code != 2 {
+		t.Errorf("planExitCode() = %d, want 2", code)
+	}
+}
+
+func TestBuildPlanResults_IdempotentReplies(t *testing.T) {
+	client := applyFixture()
+	client.threads[1].Comments = append(slices.Clone(client.threads[1].Comments), domain.Comment{
+		ID: "C3", DatabaseID: 3, Body: "Fixed\n\n<!-- ghent:idempotency-key=fix-1 -->", URL: "https://example.com/C3",
+	})
+	plan := []domain.PlanAction{
+		{Action: domain.PlanReply, ThreadID: "T1", Body: "Fixed", IdempotencyKey: "fix-1"},
+		{Action: domain.PlanReply, ThreadID: "T2", Body: "Fixed", IdempotencyKey: "fix-1"},
+	}
+	got, err := buildPlanResults(context.Background(), client, "owner", "repo", 42, plan, applyOptions{concurrency: 1})
+	if err != nil {
+		t.Fatalf("buildPlanResults() error: %v", err)
+	}
+	if diff := cmp.Diff([]string{domain.PlanStatusDone, domain.PlanStatusNoop}, planStatuses(got)); diff != "" {
+		t.Errorf("statuses mismatch (-want +got):\n%s", diff)
+	}
+	if got.Results[1].URL != "https://example.com/C3" {
+		t.Errorf("already posted URL = %q, want the existing comment", got.Results[1].URL)
+	}
+	if diff := cmp.Diff([]string{"reply:T1"}, client.calls); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+	if len(client.bodies) != 1 || ghub.IdempotencyKey(client.bodies[0]) != "fix-1" {
+		t.Errorf("posted bodies %q, want one carrying the key", client.bodies)
+	}
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	"github.com/indrasvat/gh-ghent/internal/github"
+)
+
+func newAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the local log of resolves, replies, and dismissals",
+		Long: `ghent appends every resolve, unresolve, reply, and dismiss it sends to
+GitHub to a local JSONL audit log, whether the call succeeded or not. Each
+entry records when it happened, the repo, PR, target thread or review ID,
+the action, a sha256 of the reply body or dismissal message, the outcome,
+and the command line that made the call, with --body, --message, --reply,
+and --subject values redacted.
+
+The log lives at $XDG_STATE_HOME/gh/ghent/audit.jsonl (default
+~/.local/state/gh/ghent/audit.jsonl). This command reads it offline; it
+never contacts GitHub.
+
+With --pr, entries are limited to that PR of the --repo (or current) repo.
+Without --pr, all repos are shown unless --repo is given.`,
+		Example: `  # Everything ghent did to PR 42 in the last day
+  gh ghent audit --pr 42 --since 1d
+
+  # All dismissals, in any repo
+  gh ghent audit --action dismiss
+
+  # Replies made in a time window
+  gh ghent audit --action reply --since 2026-03-01T09:00:00Z --until 2026-03-01T12:00:00Z
+
+  # Failed mutations only
+  gh ghent audit --pr 42 --jq '.entries[] | select(.outcome == "failure")'`,
+		Annotations: map[string]string{offlineAnnotation: "true"},
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			until, err := cmd.Flags().GetString("until")
+			if err != nil {
+				return err
+			}
+			action, err := cmd.Flags().GetString("action")
+			if err != nil {
+				return err
+			}
+
+			q, err := auditQuery(action, until)
+			if err != nil {
+				return err
+			}
+
+			path := github.DefaultAuditLogPath()
+			entries, err := github.ReadAuditLog(path, q)
+			if err != nil {
+				return err
+			}
+
+			f, err := newFormatter()
+			if err != nil {
+				return err
+			}
+			if err := f.FormatAudit(os.Stdout, &domain.AuditResult{Path: path, Entries: entries}); err != nil {
+				return fmt.Errorf("format output: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("until", "", "only entries before this time (ISO 8601 or relative: 1h, 30m, 2d)")
+	cmd.Flags().String("action", "", "only this action: resolve, unresolve, reply, or dismiss")
+
+	return cmd
+}
+
+// auditQuery builds the log filter from the global --pr, --repo, and --since
+// flags plus the audit command's own. A bare PR number is taken to be in
+// the --repo (or current) repo, since numbers are only unique per repo.
+func auditQuery(action, until string) (github.AuditQuery, error) {
+	q := github.AuditQuery{Action: action, Since: Flags.Since}
+
+	switch action {
+	case "", domain.AuditResolve, domain.AuditUnresolve, domain.AuditReply, domain.AuditDismiss:
+	default:
+		return q, fmt.Errorf("invalid --action %q: expected resolve, unresolve, reply, or dismiss", action)
+	}
+
+	if until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			dur, durErr := parseRelativeDuration(until)
+			if durErr != nil {
+				return q, fmt.Errorf("invalid --until value %q: expected ISO 8601 (e.g. 2026-02-22T00:00:00Z) or relative duration (e.g. 1h, 30m, 2d): %w", until, durErr)
+			}
+			t = time.Now().Add(-dur)
+		}
+		q.Until = t
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return q, fmt.Errorf("--since must be before --until")
+	}
+
+	ref, err := parsePRRef(Flags.PRRef)
+	if err != nil {
+		return q, err
+	}
+	if ref.Branch != "" {
+		return q, fmt.Errorf("audit needs a PR number, URL, or OWNER/REPO#N; branch %q cannot be resolved offline", ref.Branch)
+	}
+	q.PR = ref.Number
+
+	switch {
+	case ref.Owner != "":
+		if Flags.Repo != "" && !strings.EqualFold(Flags.Repo, ref.Owner+"/"+ref.Repo) {
+			return q, fmt.Errorf("--pr %q refers to %s/%s but --repo is %s", Flags.PRRef, ref.Owner, ref.Repo, Flags.Repo)
+		}
+		q.Repo = ref.Owner + "/" + ref.Repo
+	case Flags.Repo != "" || q.PR != 0:
+		owner, repo, err := resolveRepo(Flags.Repo)
+		if err != nil {
+			return q, err
+		}
+		q.Repo = owner + "/" + repo
+	}
+	return q, nil
+}
+
+// redactedFlags take free text (reply bodies, dismissal messages, commit
+// messages) that the audit log records only as a hash, if at all.
+var redactedFlags = map[string]bool{"--body": true, "--message": true, "--reply": true, "--subject": true}
+
+// commandLine renders args for the audit log, quoting any argument that
+// would not survive being split on spaces. Values of redactedFlags become
+// <redacted>; content read from stdin or files never appears in args.
+func commandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		name, _, inline := strings.Cut(a, "=")
+		switch {
+		case inline && redactedFlags[name]:
+			a = name + "=<redacted>"
+		case i > 0 && redactedFlags[args[i-1]]:
+			a = "<redacted>"
+		case a == "" || strings.ContainsAny(a, " \t\n\"'\\"):
+			a = strconv.Quote(a)
+		}
+		quoted[i] = a
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/github"
+)
+
+func TestAuditQuery(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		flags   GlobalFlags
+		action  string
+		until   string
+		want    github.AuditQuery
+		wantErr bool
+	}{
+		{
+			name: "no filters",
+			want: github.AuditQuery{},
+		},
+		{
+			name:  "PR with repo flag",
+			flags: GlobalFlags{Repo: "owner/repo", PRRef: "42"},
+			want:  github.AuditQuery{Repo: "owner/repo", PR: 42},
+		},
+		{
+			name:  "PR URL",
+			flags: GlobalFlags{PRRef: "https://github.com/owner/repo/pull/7"},
+			want:  github.AuditQuery{Repo: "owner/repo", PR: 7},
+		},
+		{
+			name:  "repo without PR",
+			flags: GlobalFlags{Repo: "owner/repo"},
+			want:  github.AuditQuery{Repo: "owner/repo"},
+		},
+		{
+			name:   "time range and action",
+			flags:  GlobalFlags{Since: since},
+			action: "reply",
+			until:  "2026-03-02T00:00:00Z",
+			want:   github.AuditQuery{Action: "reply", Since: since, Until: since.Add(24 * time.Hour)},
+		},
+		{
+			name:    "branch",
+			flags:   GlobalFlags{Repo: "owner/repo", PRRef: "feature/x"},
+			wantErr: true,
+		},
+		{
+			name:    "PR conflicts with repo",
+			flags:   GlobalFlags{Repo: "other/repo", PRRef: "owner/repo#3"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			action:  "merge",
+			wantErr: true,
+		},
+		{
+			name:    "invalid until",
+			until:   "tomorrow",
+			wantErr: true,
+		},
+		{
+			name:    "since after until",
+			flags:   GlobalFlags{Since: since},
+			until:   "2026-02-01T00:00:00Z",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := Flags
+			t.Cleanup(func() { Flags = saved })
+			Flags = tt.flags
+
+			got, err := auditQuery(tt.action, tt.until)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("auditQuery() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("auditQuery() error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("auditQuery() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "quotes arguments with spaces",
+			args: []string{"gh-ghent", "resolve", "--file", "internal/my dir/*.go", ""},
+			want: `gh-ghent resolve --file "internal/my dir/*.go" ""`,
+		},
+		{
+			name: "redacts reply body",
+			args: []string{"gh-ghent", "reply", "--thread", "PRRT_1", "--body", "Fixed in \"abc\"\nthanks"},
+			want: `gh-ghent reply --thread PRRT_1 --body <redacted>`,
+		},
+		{
+			name: "redacts inline values",
+			args: []string{"gh-ghent", "dismiss", "--message=stale review", "--pr", "42"},
+			want: `gh-ghent dismiss --message=<redacted> --pr 42`,
+		},
+		{
+			name: "redacts commit and suggestion text",
+			args: []string{"gh-ghent", "merge", "--subject", "Ship it", "--body", "", "suggestions", "--reply", "done"},
+			want: `gh-ghent merge --subject <redacted> --body <redacted> suggestions --reply <redacted>`,
+		},
+		{
+			name: "keeps file paths and stdin markers",
+			args: []string{"gh-ghent", "apply", "--plan", "-", "reply", "--body-file", "notes.md"},
+			want: `gh-ghent apply --plan - reply --body-file notes.md`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandLine(tt.args); got != tt.want {
+				t.Errorf("commandLine() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
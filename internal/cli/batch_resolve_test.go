@@ -150,3 +150,24 @@ func TestMatchesFilters_InvalidGlob(t *testing.T) {
 		t.Error("expected invalid glob to not match")
 	}
 }
+
+func TestIsAddressed(t *testing.T) {
+	changed, unchanged := true, false
+	tests := []struct {
+		name    string
+		changed *bool
+		want    bool
+	}{
+		{name: "not analyzed", changed: nil, want: false},
+		{name: "code unchanged", changed: &unchanged, want: false},
+		{name: "code changed", changed: &changed, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAddressed(domain.ReviewThread{CodeChangedSinceComment: tt.changed}); got != tt.want {
+				t.Errorf("isAddressed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/github"
+)
+
+// offlineAnnotation marks commands that never talk to GitHub, so the root
+// command skips creating an authenticated client for them.
+const offlineAnnotation = "ghent:offline"
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local API response cache",
+		Long: `ghent caches GitHub REST responses on disk to save rate limit.
+
+Responses are revalidated with ETags on every use (a 304 does not count
+against the rate limit); completed job logs and check annotations never
+change and are served straight from disk. Pass --no-cache to any command
+to bypass the cache entirely.`,
+		Annotations: map[string]string{offlineAnnotation: "true"},
+	}
+	cmd.AddCommand(newCachePruneCmd())
+	return cmd
+}
+
+func newCachePruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete cache entries that have not been used recently",
+		Example: `  # Drop entries unused for a week (the default)
+  gh ghent cache prune
+
+  # Drop entries unused for a day
+  gh ghent cache prune --older-than 1d
+
+  # Empty the cache
+  gh ghent cache prune --all`,
+		Annotations: map[string]string{offlineAnnotation: "true"},
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			all, err := cmd.Flags().GetBool("all")
+			if err != nil {
+				return err
+			}
+			olderThan, err := cmd.Flags().GetString("older-than")
+			if err != nil {
+				return err
+			}
+
+			var cutoff time.Time
+			if !all {
+				age, err := parseRelativeDuration(olderThan)
+				if err != nil {
+					return fmt.Errorf("invalid --older-than value %q: %w", olderThan, err)
+				}
+				cutoff = time.Now().Add(-age)
+			}
+
+			result, err := github.PruneCache(github.DefaultCacheDir(), cutoff)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Pruned %d cache entries (%s) from %s; %d kept.\n",
+				result.Removed, formatBytes(result.Bytes), result.Dir, result.Kept)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("older-than", "7d", "delete entries unused for this long (e.g. 12h, 7d, 2w)")
+	cmd.Flags().Bool("all", false, "delete every cache entry")
+	cmd.MarkFlagsMutuallyExclusive("older-than", "all")
+
+	return cmd
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,13 +1,14 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/indrasvat/gh-ghent/internal/domain"
-	"github.com/indrasvat/gh-ghent/internal/formatter"
 	ghub "github.com/indrasvat/gh-ghent/internal/github"
 	"github.com/indrasvat/gh-ghent/internal/tui"
 )
@@ -22,8 +23,17 @@ In TTY mode, launches an interactive TUI with check list, annotation
 details, and log viewer. In pipe mode, outputs structured data with
 check names, statuses, and annotations.
 
-Use --logs to include failing job log excerpts in pipe output.
-Use --watch to poll until all checks complete (fail-fast on failure).
+Use --logs to include failing job log excerpts and the failed tests
+recognized in them (go test, pytest, jest, cargo, JUnit) in pipe output.
+--format junit and --format quickfix always fetch logs.
+Use --flaky to mark checks that passed and failed on identical code (re-run
+attempts, commits with the same tree) or kept flipping between pass and fail
+over the last --flaky-commits commits, with the outcome history as evidence.
+Use --compare-base to classify each failing check (and, with --logs, each
+failed test) as new_failure or pre_existing against the base branch head,
+and passing checks the base fails as fixed_by_pr.
+Use --watch to poll until all checks complete (fail-fast on failure);
+--format quickfix, sarif, and junit write their report once watching ends.
 
 Exit codes: 0 = all pass, 1 = failure, 3 = pending.`,
 		Example: `  # Interactive TUI
@@ -38,22 +48,28 @@ Exit codes: 0 = all pass, 1 = failure, 3 = pending.`,
   # Wait for CI to finish (fail-fast)
   gh ghent checks --pr 42 --watch
 
+  # Flag flaky checks before "fixing" a failure
+  gh ghent checks --pr 42 --format json --flaky | jq '.checks[] | select(.flaky)'
+
+  # Did this PR break CI, or is the base branch already red?
+  gh ghent checks --pr 42 --format json --compare-base --logs
+
+  # JUnit report of failed tests for a CI dashboard
+  gh ghent checks --pr 42 --format junit > ghent-junit.xml
+
   # Check overall status
   gh ghent checks --pr 42 --format json | jq '.overall_status'`,
+		Annotations: map[string]string{locationsAnnotation: "true", junitAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if Flags.PR == 0 {
-				return fmt.Errorf("--pr flag is required")
-			}
-
-			owner, repo, err := resolveRepo(Flags.Repo)
+			ctx := cmd.Context()
+			owner, repo, err := resolvePRTarget(ctx)
 			if err != nil {
 				return err
 			}
 
-			ctx := cmd.Context()
 			client := GitHubClient()
 
-			f, err := formatter.New(Flags.Format)
+			f, err := newFormatter()
 			if err != nil {
 				return err
 			}
@@ -81,6 +97,11 @@ Exit codes: 0 = all pass, 1 = failure, 3 = pending.`,
 				if watchErr != nil {
 					return fmt.Errorf("watch checks: %w", watchErr)
 				}
+				if reportFormat(Flags.Format) {
+					if err := writeFinalChecks(ctx, client, os.Stdout, f, owner, repo, Flags.PR, Flags.Format); err != nil {
+						return err
+					}
+				}
 				switch finalStatus {
 				case domain.StatusFail:
 					os.Exit(1)
@@ -98,19 +119,22 @@ Exit codes: 0 = all pass, 1 = failure, 3 = pending.`,
 			// Apply --since filter (no-op if not set).
 			FilterChecksBySince(result, Flags.Since)
 
+			if flaky, _ := cmd.Flags().GetBool("flaky"); flaky {
+				commits, _ := cmd.Flags().GetInt("flaky-commits")
+				attachFlakiness(ctx, client, owner, repo, Flags.PR, commits, result)
+			}
+			compareBase, _ := cmd.Flags().GetBool("compare-base")
+			var base *domain.ChecksResult
+			if compareBase {
+				base = compareWithBase(ctx, client, owner, repo, Flags.PR, result)
+			}
+
 			// TTY → launch TUI; non-TTY / --no-tui → pipe mode.
 			if Flags.IsTTY {
 				// Pre-fetch logs for failed checks for the TUI log viewer.
-				for i := range result.Checks {
-					ch := &result.Checks[i]
-					if !domain.IsFailConclusion(ch.Conclusion) {
-						continue
-					}
-					logText, logErr := client.FetchJobLog(ctx, owner, repo, ch.ID)
-					if logErr != nil {
-						continue // graceful degradation
-					}
-					ch.LogExcerpt = ghub.ExtractErrorLines(logText)
+				attachLogExcerpts(ctx, client, owner, repo, result)
+				if base != nil {
+					compareFailedTests(ctx, client, owner, repo, result, base)
 				}
 				repoStr := owner + "/" + repo
 				return launchTUI(tui.ViewChecksList,
@@ -119,21 +143,17 @@ Exit codes: 0 = all pass, 1 = failure, 3 = pending.`,
 				)
 			}
 
-			// Fetch logs for failed checks when --logs is set.
-			// IsFailConclusion covers all failure-classified conclusions
-			// (failure, timed_out, cancelled, etc.), not just "failure".
+			// Fetch logs for failed checks when --logs is set; the JUnit
+			// report is built from the failed tests found in them, and
+			// quickfix lists the file:line references they contain.
 			withLogs, _ := cmd.Flags().GetBool("logs")
+			if Flags.Format == "junit" || Flags.Format == "quickfix" {
+				withLogs = true
+			}
 			if withLogs {
-				for i := range result.Checks {
-					ch := &result.Checks[i]
-					if !domain.IsFailConclusion(ch.Conclusion) {
-						continue
-					}
-					logText, logErr := client.FetchJobLog(ctx, owner, repo, ch.ID)
-					if logErr != nil {
-						continue // graceful degradation
-					}
-					ch.LogExcerpt = ghub.ExtractErrorLines(logText)
+				attachLogExcerpts(ctx, client, owner, repo, result)
+				if base != nil {
+					compareFailedTests(ctx, client, owner, repo, result, base)
 				}
 			}
 
@@ -153,8 +173,43 @@ Exit codes: 0 = all pass, 1 = failure, 3 = pending.`,
 		},
 	}
 
-	cmd.Flags().Bool("logs", false, "include failing job log excerpts in output")
+	cmd.Flags().Bool("logs", false, "include failing job log excerpts and failed tests in output")
 	cmd.Flags().Bool("watch", false, "poll until all checks complete, fail-fast on failure")
+	cmd.Flags().Bool("flaky", false, "mark flaky checks using the check history of recent commits")
+	cmd.Flags().Bool("compare-base", false, "classify failures as new or pre-existing against the base branch")
+	cmd.Flags().Int("flaky-commits", ghub.DefaultFlakyCommits, "how many recent commits --flaky looks back over")
 
 	return cmd
 }
+
+// reportFormat reports whether format renders one whole document and so
+// writes no watch progress (see FormatWatchStatus of quickfix, sarif, and
+// junit).
+func reportFormat(format string) bool {
+	return locationFormats[format] || format == "junit"
+}
+
+// checksReportClient is the subset of the GitHub client writeFinalChecks
+// needs.
+type checksReportClient interface {
+	domain.CheckFetcher
+	jobLogFetcher
+}
+
+// writeFinalChecks formats the checks as they stand once --watch ends, so
+// the report formats, silent while watching, still produce their document.
+// junit and quickfix are built from the failing jobs' logs.
+func writeFinalChecks(ctx context.Context, client checksReportClient, w io.Writer, f domain.Formatter, owner, repo string, pr int, format string) error {
+	result, err := client.FetchChecks(ctx, owner, repo, pr)
+	if err != nil {
+		return fmt.Errorf("fetch checks: %w", err)
+	}
+	FilterChecksBySince(result, Flags.Since)
+	if format == "junit" || format == "quickfix" {
+		attachLogExcerpts(ctx, client, owner, repo, result)
+	}
+	if err := f.FormatChecks(w, result); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	"github.com/indrasvat/gh-ghent/internal/formatter"
+)
+
+func TestWriteFinalChecks(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "quickfix", want: "b_test.go:9:"},
+		{format: "sarif", want: `"version": "2.1.0"`},
+		{format: "junit", want: `<testcase name="TestB"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if !reportFormat(tt.format) {
+				t.Fatalf("reportFormat(%q) = false, want true", tt.format)
+			}
+			client := &stubLogsClient{
+				checks: []domain.CheckRun{
+					{ID: 2, Name: "test", Status: "completed", Conclusion: "failure"},
+				},
+				log: "2025-01-15T10:30:07.0Z --- FAIL: TestB (0.00s)\n" +
+					"2025-01-15T10:30:07.1Z     b_test.go:9: boom\n",
+			}
+			f, err := formatter.New(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := writeFinalChecks(context.Background(), client, &buf, f, "o", "r", 1, tt.format); err != nil {
+				t.Fatalf("writeFinalChecks: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -1,14 +1,15 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/indrasvat/gh-ghent/internal/domain"
-	"github.com/indrasvat/gh-ghent/internal/formatter"
 	"github.com/indrasvat/gh-ghent/internal/tui"
 )
 
@@ -16,12 +17,17 @@ func newCommentsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "comments",
 		Short: "Show unresolved review threads",
-		Long: `Show unresolved review threads for a pull request.
+		Long: `Show unresolved review threads for a pull request, followed by the PR
+conversation: top-level comments and review summary bodies.
 
 In TTY mode, launches an interactive TUI with thread navigation,
 diff hunks, and expandable comment chains. In pipe mode, outputs
 structured data with thread IDs, file paths, and comment bodies.
 
+With --code-changes, each thread's commented lines are compared with
+the file at the PR head: code_changed_since_comment tells whether a later
+push touched them, and changed_by lists the commits that did.
+
 Exit codes: 0 = no unresolved threads, 1 = has unresolved threads.`,
 		Example: `  # Interactive TUI
   gh ghent comments --pr 42
@@ -35,27 +41,27 @@ Exit codes: 0 = no unresolved threads, 1 = has unresolved threads.`,
   # Only human review threads
   gh ghent comments --pr 42 --humans-only --format json
 
+  # Which threads were likely addressed by a later push
+  gh ghent comments --pr 42 --code-changes --format json | jq '.threads[] | select(.code_changed_since_comment)'
+
   # Count unresolved threads
   gh ghent comments --pr 42 --format json | jq '.unresolved_count'
 
   # Markdown summary
   gh ghent comments -R owner/repo --pr 42 --format md`,
+		Annotations: map[string]string{locationsAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if Flags.PR == 0 {
-				return fmt.Errorf("--pr flag is required")
-			}
-
-			owner, repo, err := resolveRepo(Flags.Repo)
+			ctx := cmd.Context()
+			owner, repo, err := resolvePRTarget(ctx)
 			if err != nil {
 				return err
 			}
 
-			ctx := cmd.Context()
 			client := GitHubClient()
 
-			result, err := client.FetchThreads(ctx, owner, repo, Flags.PR)
+			result, err := fetchComments(ctx, client, owner, repo, Flags.PR)
 			if err != nil {
-				return fmt.Errorf("fetch threads: %w", err)
+				return err
 			}
 
 			// Apply --since filter (no-op if not set).
@@ -75,6 +81,12 @@ Exit codes: 0 = no unresolved threads, 1 = has unresolved threads.`,
 				FilterThreadsByUnanswered(result)
 			}
 
+			if codeChanges, _ := cmd.Flags().GetBool("code-changes"); codeChanges {
+				if err := client.MarkCodeChanges(ctx, owner, repo, Flags.PR, result); err != nil {
+					return fmt.Errorf("compare threads with the PR head: %w", err)
+				}
+			}
+
 			// TTY → launch TUI; non-TTY / --no-tui → pipe mode.
 			if Flags.IsTTY {
 				repoStr := owner + "/" + repo
@@ -84,7 +96,7 @@ Exit codes: 0 = no unresolved threads, 1 = has unresolved threads.`,
 				)
 			}
 
-			f, err := formatter.New(Flags.Format)
+			f, err := newFormatter()
 			if err != nil {
 				return err
 			}
@@ -113,13 +125,57 @@ Exit codes: 0 = no unresolved threads, 1 = has unresolved threads.`,
 	}
 
 	cmd.Flags().String("group-by", "", "group threads by: file, author, status")
-	cmd.Flags().BoolP("bots-only", "b", false, "show only bot-originated threads")
-	cmd.Flags().BoolP("humans-only", "H", false, "show only human-originated threads")
-	cmd.Flags().BoolP("unanswered", "a", false, "show only threads with no replies")
+	cmd.Flags().BoolP("bots-only", "b", false, "show only bot-originated threads and conversation comments")
+	cmd.Flags().BoolP("humans-only", "H", false, "show only human-originated threads and conversation comments")
+	cmd.Flags().BoolP("unanswered", "a", false, "show only threads and conversation comments with no replies")
+	cmd.Flags().Bool("code-changes", false, "compare each thread's commented code with the PR head")
 
 	return cmd
 }
 
+type commentsClient interface {
+	domain.ThreadFetcher
+	domain.ConversationFetcher
+}
+
+// fetchComments fetches unresolved review threads and the PR conversation
+// (top-level comments and review bodies) in parallel.
+func fetchComments(ctx context.Context, client commentsClient, owner, repo string, pr int) (*domain.CommentsResult, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var result *domain.CommentsResult
+	var conversation []domain.ConversationComment
+
+	g.Go(func() error {
+		var fetchErr error
+		result, fetchErr = client.FetchThreads(gctx, owner, repo, pr)
+		if fetchErr != nil {
+			return fmt.Errorf("fetch threads: %w", fetchErr)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		var fetchErr error
+		conversation, fetchErr = client.FetchConversation(gctx, owner, repo, pr)
+		if fetchErr != nil {
+			return fmt.Errorf("fetch conversation: %w", fetchErr)
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if conversation == nil {
+		conversation = []domain.ConversationComment{}
+	}
+	result.Conversation = conversation
+	countConversation(result)
+	return result, nil
+}
+
 // groupThreads groups threads from a CommentsResult by the given dimension.
 func groupThreads(result *domain.CommentsResult, groupBy string) (*domain.GroupedCommentsResult, error) {
 	grouped := &domain.GroupedCommentsResult{
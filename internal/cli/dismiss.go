@@ -8,7 +8,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/indrasvat/gh-ghent/internal/domain"
-	"github.com/indrasvat/gh-ghent/internal/formatter"
 )
 
 func newDismissCmd() *cobra.Command {
@@ -54,10 +53,6 @@ type dismissClient interface {
 }
 
 func runDismiss(cmd *cobra.Command, _ []string) error {
-	if Flags.PR == 0 {
-		return fmt.Errorf("--pr flag is required")
-	}
-
 	reviewID, err := cmd.Flags().GetString("review")
 	if err != nil {
 		return err
@@ -82,19 +77,19 @@ func runDismiss(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("--message is required unless --dry-run is set")
 	}
 
-	owner, repo, err := resolveRepo(Flags.Repo)
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
 	if err != nil {
 		return err
 	}
 
-	ctx := cmd.Context()
 	client := GitHubClient()
 	results, err := buildDismissResults(ctx, client, owner, repo, Flags.PR, reviewID, author, botsOnly, message, dryRun)
 	if err != nil {
 		return err
 	}
 
-	f, err := formatter.New(Flags.Format)
+	f, err := newFormatter()
 	if err != nil {
 		return err
 	}
@@ -23,9 +23,22 @@ func recountThreads(result *domain.CommentsResult) {
 	result.UnresolvedCount = unresolved
 	result.BotThreadCount = bot
 	result.UnansweredCount = unanswered
+	countConversation(result)
 }
 
-// FilterThreadsByBot filters threads by bot authorship in-place.
+// countConversation recalculates the conversation counters on a CommentsResult.
+func countConversation(result *domain.CommentsResult) {
+	unanswered := 0
+	for _, c := range result.Conversation {
+		if c.IsUnanswered() {
+			unanswered++
+		}
+	}
+	result.ConversationCount = len(result.Conversation)
+	result.UnansweredConversationCount = unanswered
+}
+
+// FilterThreadsByBot filters threads and conversation entries by bot authorship in-place.
 // When botsOnly is true, keeps only bot-originated threads.
 // When humansOnly is true, keeps only human-originated threads.
 // Caller must ensure botsOnly and humansOnly are not both true.
@@ -44,10 +57,19 @@ func FilterThreadsByBot(result *domain.CommentsResult, botsOnly, humansOnly bool
 		}
 	}
 	result.Threads = filtered
+
+	conversation := result.Conversation[:0]
+	for _, c := range result.Conversation {
+		if c.IsBot == botsOnly {
+			conversation = append(conversation, c)
+		}
+	}
+	result.Conversation = conversation
 	recountThreads(result)
 }
 
-// FilterThreadsByUnanswered keeps only threads with no replies (single comment).
+// FilterThreadsByUnanswered keeps only threads with no replies (single comment)
+// and conversation entries nobody has responded to.
 func FilterThreadsByUnanswered(result *domain.CommentsResult) {
 	if result == nil {
 		return
@@ -60,5 +82,13 @@ func FilterThreadsByUnanswered(result *domain.CommentsResult) {
 		}
 	}
 	result.Threads = filtered
+
+	conversation := result.Conversation[:0]
+	for _, c := range result.Conversation {
+		if c.IsUnanswered() {
+			conversation = append(conversation, c)
+		}
+	}
+	result.Conversation = conversation
 	recountThreads(result)
 }
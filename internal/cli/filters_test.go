@@ -200,3 +200,53 @@ func TestFilterEmptyResult(t *testing.T) {
 		t.Errorf("BotThreadCount = %d, want 0", result.BotThreadCount)
 	}
 }
+
+func TestFilterConversation(t *testing.T) {
+	t.Parallel()
+
+	newResult := func() *domain.CommentsResult {
+		r := &domain.CommentsResult{
+			Conversation: []domain.ConversationComment{
+				{ID: "c1", Author: "coderabbitai", IsBot: true, HasReply: true},
+				{ID: "c2", Author: "alice"},
+				{ID: "c3", Author: "copilot", IsBot: true},
+				{ID: "c4", Author: "bob", IsMinimized: true},
+			},
+		}
+		countConversation(r)
+		return r
+	}
+	ids := func(r *domain.CommentsResult) []string {
+		var out []string
+		for _, c := range r.Conversation {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	r := newResult()
+	if r.ConversationCount != 4 || r.UnansweredConversationCount != 2 {
+		t.Errorf("counts = %d/%d, want 4/2", r.ConversationCount, r.UnansweredConversationCount)
+	}
+
+	r = newResult()
+	FilterThreadsByBot(r, true, false)
+	if diff := cmp.Diff([]string{"c1", "c3"}, ids(r)); diff != "" {
+		t.Errorf("bots-only mismatch (-want +got):\n%s", diff)
+	}
+
+	r = newResult()
+	FilterThreadsByBot(r, false, true)
+	if diff := cmp.Diff([]string{"c2", "c4"}, ids(r)); diff != "" {
+		t.Errorf("humans-only mismatch (-want +got):\n%s", diff)
+	}
+
+	r = newResult()
+	FilterThreadsByUnanswered(r)
+	if diff := cmp.Diff([]string{"c2", "c3"}, ids(r)); diff != "" {
+		t.Errorf("unanswered mismatch (-want +got):\n%s", diff)
+	}
+	if r.ConversationCount != 2 {
+		t.Errorf("ConversationCount = %d, want 2", r.ConversationCount)
+	}
+}
@@ -4,13 +4,17 @@ import "time"
 
 // GlobalFlags holds flags shared across all subcommands.
 type GlobalFlags struct {
-	Repo    string
-	Format  string
-	Verbose bool
-	NoTUI   bool
-	Debug   bool
-	Solo    bool // relaxes approval requirement for single-maintainer repos
-	IsTTY   bool // resolved at runtime in PersistentPreRunE
-	PR      int
-	Since   time.Time // parsed from --since flag; zero means no filter
+	Repo     string
+	Format   string
+	Verbose  bool
+	NoTUI    bool
+	Debug    bool
+	Solo     bool      // relaxes approval requirement for single-maintainer repos
+	NoCache  bool      // bypasses the on-disk REST response cache
+	JQ       string    // --jq expression applied to JSON output
+	Template string    // --template Go template applied to JSON output
+	IsTTY    bool      // resolved at runtime in PersistentPreRunE
+	PR       int       // resolved PR number (see resolvePRTarget)
+	PRRef    string    // raw --pr value: number, URL, OWNER/REPO#N, or branch
+	Since    time.Time // parsed from --since flag; zero means no filter
 }
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+)
+
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [check]",
+		Short: "Show the full job log of a check",
+		Long: `Show the full, cleaned GitHub Actions log behind a check run.
+
+The check is selected by name (case-insensitive) or check run ID; without
+one, the first failing check is used. The check must have completed; a
+running job's log is not available yet. ANSI codes and timestamps are
+stripped. Each line keeps its number in the full job log, so gaps left by
+filtering stay visible.
+
+Use --step to narrow to one job step (by number or name), --grep and
+--around-errors to keep matching lines with --context lines around them,
+and --tail to keep only the last lines. Filters combine in that order.`,
+		Example: `  # Full log of the first failing check
+  gh ghent logs --pr 42
+
+  # Only the failing test step of the "test" check
+  gh ghent logs test --pr 42 --step "Run tests"
+
+  # Error lines with 10 lines of context
+  gh ghent logs test --pr 42 --around-errors --context 10
+
+  # Last 200 lines mentioning a package
+  gh ghent logs test --pr 42 --grep 'internal/config' --tail 200 --format md`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runLogs,
+	}
+
+	cmd.Flags().String("step", "", "only show the job step with this number or name")
+	cmd.Flags().String("grep", "", "only show lines matching this regular expression")
+	cmd.Flags().Bool("around-errors", false, "only show error-relevant lines")
+	cmd.Flags().Int("context", 3, "lines of context around --grep and --around-errors matches")
+	cmd.Flags().Int("tail", 0, "only show the last N lines (0 = all)")
+
+	return cmd
+}
+
+// logsClient is the subset of the GitHub client the logs command needs.
+type logsClient interface {
+	domain.CheckFetcher
+	jobLogFetcher
+}
+
+// logsOptions holds the parsed logs command flags.
+type logsOptions struct {
+	check  string
+	step   string
+	filter ghub.LogFilter
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	opts := logsOptions{}
+	if len(args) == 1 {
+		opts.check = args[0]
+	}
+	f := cmd.Flags()
+	var err error
+	if opts.step, err = f.GetString("step"); err != nil {
+		return err
+	}
+	grep, err := f.GetString("grep")
+	if err != nil {
+		return err
+	}
+	if grep != "" {
+		if opts.filter.Grep, err = regexp.Compile(grep); err != nil {
+			return fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	}
+	if opts.filter.AroundErrors, err = f.GetBool("around-errors"); err != nil {
+		return err
+	}
+	if opts.filter.Context, err = f.GetInt("context"); err != nil {
+		return err
+	}
+	if opts.filter.Tail, err = f.GetInt("tail"); err != nil {
+		return err
+	}
+	if opts.filter.Context < 0 || opts.filter.Tail < 0 {
+		return fmt.Errorf("--context and --tail must not be negative")
+	}
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := buildJobLogResult(ctx, GitHubClient(), owner, repo, Flags.PR, opts)
+	if err != nil {
+		return err
+	}
+
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatJobLog(os.Stdout, result); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+	return nil
+}
+
+func buildJobLogResult(
+	ctx context.Context,
+	client logsClient,
+	owner, repo string,
+	pr int,
+	opts logsOptions,
+) (*domain.JobLogResult, error) {
+	checks, err := client.FetchChecks(ctx, owner, repo, pr)
+	if err != nil {
+		return nil, fmt.Errorf("fetch checks: %w", err)
+	}
+	ch, err := selectJobCheck(checks.Checks, opts.check)
+	if err != nil {
+		return nil, err
+	}
+	// A running job has no final log yet, and the log and steps are cached
+	// as immutable once fetched.
+	if ch.Status != "completed" {
+		return nil, fmt.Errorf("%s is still %s; its log is available once it completes (use gh ghent checks --watch)", ch.Name, ch.Status)
+	}
+
+	logText, err := client.FetchJobLog(ctx, owner, repo, ch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch log for %s: %w", ch.Name, err)
+	}
+	steps, err := client.FetchJobSteps(ctx, owner, repo, ch.ID)
+	if err != nil {
+		if opts.step != "" {
+			return nil, fmt.Errorf("fetch steps for %s: %w", ch.Name, err)
+		}
+		steps = nil // steps are informational without --step
+	}
+
+	result := &domain.JobLogResult{
+		PRNumber:   pr,
+		CheckID:    ch.ID,
+		CheckName:  ch.Name,
+		Conclusion: ch.Conclusion,
+		HTMLURL:    ch.HTMLURL,
+		Steps:      steps,
+	}
+
+	stepNumber := 0
+	if opts.step != "" {
+		st, err := selectLogStep(steps, opts.step)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ch.Name, err)
+		}
+		result.Step = &st
+		stepNumber = st.Number
+	}
+
+	lines, err := ghub.JobLogLines(logText, steps, stepNumber)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ch.Name, err)
+	}
+	result.TotalLines = len(lines)
+	result.Lines = ghub.FilterLogLines(lines, opts.filter)
+	if result.Lines == nil {
+		result.Lines = []domain.LogLine{}
+	}
+	return result, nil
+}
+
+// selectJobCheck picks the check named or numbered by sel, or the first
+// failing check when sel is empty. Commit statuses have no Actions job.
+func selectJobCheck(checks []domain.CheckRun, sel string) (domain.CheckRun, error) {
+	var match *domain.CheckRun
+	for i := range checks {
+		ch := &checks[i]
+		switch {
+		case sel == "":
+			if domain.IsFailConclusion(ch.Conclusion) && !ch.IsCommitStatus() {
+				return *ch, nil
+			}
+		case strconv.FormatInt(ch.ID, 10) == sel, strings.EqualFold(ch.Name, sel):
+			if match == nil || (!domain.IsFailConclusion(match.Conclusion) && domain.IsFailConclusion(ch.Conclusion)) {
+				match = ch
+			}
+		}
+	}
+	if sel == "" {
+		return domain.CheckRun{}, fmt.Errorf("no failing check with a job log; name one: gh ghent logs <check>")
+	}
+	if match == nil {
+		return domain.CheckRun{}, fmt.Errorf("no check named %q", sel)
+	}
+	if match.IsCommitStatus() {
+		return domain.CheckRun{}, fmt.Errorf("%s is a commit status from external CI and has no Actions job; see %s", match.Name, match.HTMLURL)
+	}
+	return *match, nil
+}
+
+// selectLogStep picks the step with number sel, else the step named sel
+// (case-insensitive), else the only step whose name contains sel.
+func selectLogStep(steps []domain.JobStep, sel string) (domain.JobStep, error) {
+	if n, err := strconv.Atoi(sel); err == nil {
+		for _, st := range steps {
+			if st.Number == n {
+				return st, nil
+			}
+		}
+		return domain.JobStep{}, fmt.Errorf("no step %d", n)
+	}
+	var partial []domain.JobStep
+	for _, st := range steps {
+		if strings.EqualFold(st.Name, sel) {
+			return st, nil
+		}
+		if strings.Contains(strings.ToLower(st.Name), strings.ToLower(sel)) {
+			partial = append(partial, st)
+		}
+	}
+	switch len(partial) {
+	case 1:
+		return partial[0], nil
+	case 0:
+		return domain.JobStep{}, fmt.Errorf("no step named %q", sel)
+	default:
+		names := make([]string, len(partial))
+		for i, st := range partial {
+			names[i] = strconv.Itoa(st.Number) + ". " + st.Name
+		}
+		return domain.JobStep{}, fmt.Errorf("step %q is ambiguous: %s", sel, strings.Join(names, ", "))
+	}
+}
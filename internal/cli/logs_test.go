@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+)
+
+type stubLogsClient struct {
+	checks []domain.CheckRun
+	log    string
+	steps  []domain.JobStep
+	jobID  int64
+}
+
+func (s *stubLogsClient) FetchChecks(context.Context, string, string, int) (*domain.ChecksResult, error) {
+	return &domain.ChecksResult{Checks: s.checks}, nil
+}
+
+func (s *stubLogsClient) FetchJobLog(_ context.Context, _, _ string, jobID int64) (string, error) {
+	s.jobID = jobID
+	return s.log, nil
+}
+
+func (s *stubLogsClient) FetchJobSteps(context.Context, string, string, int64) ([]domain.JobStep, error) {
+	return s.steps, nil
+}
+
+func TestBuildJobLogResult(t *testing.T) {
+	at := func(sec int) time.Time { return time.Date(2025, 1, 15, 10, 30, sec, 0, time.UTC) }
+	client := &stubLogsClient{
+		checks: []domain.CheckRun{
+			{ID: 1, Name: "lint", Status: "completed", Conclusion: "success"},
+			{ID: 2, Name: "test", Status: "completed", Conclusion: "failure"},
+		},
+		log: "2025-01-15T10:30:00.0Z \x1b[32mSet up\x1b[0m\n" +
+			"2025-01-15T10:30:05.1Z ##[group]Run go test ./...\n" +
+			"2025-01-15T10:30:06.0Z ok  \texample.com/a\t0.1s\n" +
+			"2025-01-15T10:30:07.0Z --- FAIL: TestB (0.00s)\n" +
+			"2025-01-15T10:30:07.1Z     b_test.go:9: boom\n",
+		steps: []domain.JobStep{
+			{Number: 1, Name: "Set up job", Conclusion: "success", StartedAt: at(0), CompletedAt: at(4)},
+			{Number: 2, Name: "Run tests", Conclusion: "failure", StartedAt: at(5), CompletedAt: at(8)},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		opts      logsOptions
+		wantTotal int
+		want      []domain.LogLine
+	}{
+		{
+			name:      "defaults to the first failing check and keeps everything",
+			wantTotal: 5,
+			want: []domain.LogLine{
+				{Number: 1, Text: "Set up"},
+				{Number: 2, Text: "##[group]Run go test ./..."},
+				{Number: 3, Text: "ok  \texample.com/a\t0.1s"},
+				{Number: 4, Text: "--- FAIL: TestB (0.00s)"},
+				{Number: 5, Text: "    b_test.go:9: boom"},
+			},
+		},
+		{
+			name:      "step and tail",
+			opts:      logsOptions{check: "TEST", step: "tests", filter: ghub.LogFilter{Tail: 2}},
+			wantTotal: 4,
+			want: []domain.LogLine{
+				{Number: 4, Text: "--- FAIL: TestB (0.00s)"},
+				{Number: 5, Text: "    b_test.go:9: boom"},
+			},
+		},
+		{
+			name:      "grep without context",
+			opts:      logsOptions{check: "2", filter: ghub.LogFilter{Grep: regexp.MustCompile(`^ok`)}},
+			wantTotal: 5,
+			want:      []domain.LogLine{{Number: 3, Text: "ok  \texample.com/a\t0.1s"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildJobLogResult(context.Background(), client, "o", "r", 42, tt.opts)
+			if err != nil {
+				t.Fatalf("buildJobLogResult: %v", err)
+			}
+			if client.jobID != 2 || got.CheckName != "test" {
+				t.Errorf("fetched job %d for %q, want job 2 for test", client.jobID, got.CheckName)
+			}
+			if got.TotalLines != tt.wantTotal {
+				t.Errorf("TotalLines = %d, want %d", got.TotalLines, tt.wantTotal)
+			}
+			if diff := cmp.Diff(tt.want, got.Lines); diff != "" {
+				t.Errorf("lines mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildJobLogResultRunningCheck(t *testing.T) {
+	client := &stubLogsClient{checks: []domain.CheckRun{{ID: 3, Name: "build", Status: "in_progress"}}}
+
+	_, err := buildJobLogResult(context.Background(), client, "o", "r", 42, logsOptions{check: "build"})
+	if err == nil || !strings.Contains(err.Error(), "still in_progress") {
+		t.Errorf("error = %v, want still in_progress", err)
+	}
+	if client.jobID != 0 {
+		t.Errorf("fetched the log of job %d; a running job's log must not be cached", client.jobID)
+	}
+}
+
+func TestSelectJobCheck(t *testing.T) {
+	checks := []domain.CheckRun{
+		{ID: 1, Name: "ci/jenkins", Kind: domain.CheckKindStatus, Conclusion: "failure", HTMLURL: "https://ci.example.com/1"},
+		{ID: 2, Name: "test", Status: "completed", Conclusion: "success"},
+		{ID: 3, Name: "test", Status: "completed", Conclusion: "failure"},
+		{ID: 4, Name: "build", Status: "in_progress"},
+	}
+
+	if ch, err := selectJobCheck(checks, ""); err != nil || ch.ID != 3 {
+		t.Errorf("default: got %d, %v; want the failing Actions check 3", ch.ID, err)
+	}
+	if ch, err := selectJobCheck(checks, "Test"); err != nil || ch.ID != 3 {
+		t.Errorf("by name: got %d, %v; want the failing duplicate 3", ch.ID, err)
+	}
+	if ch, err := selectJobCheck(checks, "2"); err != nil || ch.ID != 2 {
+		t.Errorf("by ID: got %d, %v; want 2", ch.ID, err)
+	}
+	if _, err := selectJobCheck(checks, "ci/jenkins"); err == nil || !strings.Contains(err.Error(), "no Actions job") {
+		t.Errorf("commit status: error = %v, want no Actions job", err)
+	}
+	if ch, err := selectJobCheck(checks, "build"); err != nil || ch.ID != 4 {
+		t.Errorf("running check: got %d, %v; want 4 (rerun --cancel selects it)", ch.ID, err)
+	}
+	if _, err := selectJobCheck(checks, "nope"); err == nil {
+		t.Error("unknown check: want an error")
+	}
+}
+
+func TestSelectLogStep(t *testing.T) {
+	steps := []domain.JobStep{{Number: 1, Name: "Run lint"}, {Number: 2, Name: "Run tests"}, {Number: 3, Name: "Upload"}}
+
+	for sel, want := range map[string]int{"2": 2, "run TESTS": 2, "upl": 3} {
+		if st, err := selectLogStep(steps, sel); err != nil || st.Number != want {
+			t.Errorf("selectLogStep(%q) = %d, %v; want %d", sel, st.Number, err, want)
+		}
+	}
+	if _, err := selectLogStep(steps, "run"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ambiguous step: error = %v", err)
+	}
+	if _, err := selectLogStep(steps, "9"); err == nil {
+		t.Error("missing step number: want an error")
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+	"github.com/indrasvat/gh-ghent/internal/lsp"
+	"github.com/indrasvat/gh-ghent/internal/version"
+)
+
+func newLSPCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lsp",
+		Short: "Language Server showing PR feedback as editor diagnostics",
+		Long: `Run a Language Server (LSP over stdio) for the current pull request.
+
+Unresolved review threads and failing-check annotations are published as
+diagnostics on the lines they point at: human review threads as warnings,
+bot threads as information, annotations at their own level. Diagnostics
+refresh as CI checks complete, and again whenever new review activity or
+a push is detected.
+
+Code actions on a diagnostic reply to the thread (picking a canned reply),
+resolve it, or open it on GitHub. Editors can also invoke the commands
+directly: ghent.reply [threadID, body], ghent.resolve [threadID],
+ghent.open [url].
+
+The PR is --pr if given, otherwise the PR for the checked-out branch.`,
+		Example: `  # Serve the PR for the checked-out branch (editor runs this command)
+  gh ghent lsp
+
+  # Pin a PR and ignore feedback older than a day
+  gh ghent lsp --pr 42 --since 1d`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			owner, repo, err := resolvePRTarget(ctx)
+			if err != nil {
+				return err
+			}
+			backend := lspBackend(GitHubClient(), owner, repo, Flags.PR)
+			backend.Root = checkoutRoot(ctx, owner, repo)
+			return lsp.NewServer(backend, version.Version).Run(ctx, os.Stdin, os.Stdout)
+		},
+	}
+}
+
+// lspClient is everything the language server needs from GitHub.
+type lspClient interface {
+	statusWatcher
+	domain.ThreadFetcher
+	domain.ThreadResolver
+	domain.ThreadReplier
+}
+
+// lspBackend binds the language server to one PR. --since applies to
+// threads and checks the same way it does in pipe mode.
+func lspBackend(client lspClient, owner, repo string, pr int) lsp.Backend {
+	since := Flags.Since
+	return lsp.Backend{
+		Repo:         owner + "/" + repo,
+		PR:           pr,
+		PollInterval: ghub.DefaultPollInterval,
+		FetchThreads: func(ctx context.Context) (*domain.CommentsResult, error) {
+			result, err := client.FetchThreads(ctx, owner, repo, pr)
+			if err == nil {
+				FilterThreadsBySince(result, since)
+			}
+			return result, err
+		},
+		FetchChecks: func(ctx context.Context) (*domain.ChecksResult, error) {
+			result, err := client.FetchChecks(ctx, owner, repo, pr)
+			if err == nil {
+				FilterChecksBySince(result, since)
+			}
+			return result, err
+		},
+		WatchChecks: func(ctx context.Context, onPoll func(*domain.WatchStatus)) error {
+			_, err := client.WatchChecks(ctx, io.Discard, &watchHook{onStatus: onPoll},
+				owner, repo, pr, ghub.DefaultPollInterval, nil, true)
+			return err
+		},
+		Probe: func(ctx context.Context) (string, error) {
+			snap, err := client.ProbeActivity(ctx, owner, repo, pr)
+			if err != nil {
+				return "", err
+			}
+			return ghub.Fingerprint(snap), nil
+		},
+		Resolve: func(ctx context.Context, threadID string) error {
+			_, err := client.ResolveThread(ctx, owner, repo, pr, threadID)
+			return err
+		},
+		Reply: func(ctx context.Context, threadID, body string) error {
+			_, err := client.ReplyToThread(ctx, owner, repo, pr, threadID, body)
+			return err
+		},
+	}
+}
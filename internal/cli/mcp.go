@@ -0,0 +1,374 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+	"github.com/indrasvat/gh-ghent/internal/version"
+)
+
+func newMCPCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve ghent as Model Context Protocol tools over stdio",
+		Long: `Run a Model Context Protocol (MCP) server on stdin/stdout.
+
+Agents call ghent's operations as typed tools instead of shelling out and
+parsing stdout: status (optionally waiting for CI and review activity to
+settle, with progress notifications), comments, checks, resolve_threads,
+reply_to_thread, dismiss_reviews, and probe_activity. Tool results are the
+same structures "--format json" prints, and their schemas are published
+with the tool list.
+
+Each tool takes "pr" and an optional "repo" (OWNER/REPO); without it, the
+server's --repo or the current checkout is used. Global flags such as --solo
+and --since apply to every call.`,
+		Example: `  # Register with an MCP client (e.g. in its server config)
+  {"command": "gh", "args": ["ghent", "mcp"]}
+
+  # Serve a fixed repository regardless of the working directory
+  gh ghent mcp -R owner/repo --solo`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			server := newMCPServer(GitHubClient())
+			return server.Run(cmd.Context(), &mcp.StdioTransport{})
+		},
+	}
+}
+
+// mcpClient is everything the MCP tools need from GitHub.
+type mcpClient interface {
+	statusClient
+	statusWatcher
+	jobLogFetcher
+	domain.ThreadResolver
+	domain.ThreadReplier
+	domain.ReviewDismisser
+	domain.BaseFileFetcher
+}
+
+// mcpTarget identifies the pull request a tool call operates on.
+type mcpTarget struct {
+	Repo string `json:"repo,omitempty" jsonschema:"repository in OWNER/REPO format (default: the server's repository)"`
+	PR   int    `json:"pr" jsonschema:"pull request number"`
+}
+
+// resolve returns owner and repo for the call, falling back to the server's
+// --repo flag and then the current checkout.
+func (t mcpTarget) resolve() (string, string, error) {
+	if t.PR <= 0 {
+		return "", "", errors.New("pr must be a positive pull request number")
+	}
+	repo := t.Repo
+	if repo == "" {
+		repo = Flags.Repo
+	}
+	return resolveRepo(repo)
+}
+
+type mcpStatusInput struct {
+	mcpTarget
+	Logs          bool   `json:"logs,omitempty" jsonschema:"include failing job log excerpts"`
+	Watch         bool   `json:"watch,omitempty" jsonschema:"wait until every check completes before reporting"`
+	AwaitReview   bool   `json:"await_review,omitempty" jsonschema:"after CI completes, also wait for review activity to settle (implies watch)"`
+	ReviewTimeout string `json:"review_timeout,omitempty" jsonschema:"hard timeout for await_review as a Go duration (default 5m)"`
+}
+
+type mcpCommentsInput struct {
+	mcpTarget
+	BotsOnly   bool `json:"bots_only,omitempty" jsonschema:"only bot-originated threads and conversation comments"`
+	Unanswered bool `json:"unanswered,omitempty" jsonschema:"only threads and conversation comments with no replies"`
+}
+
+type mcpChecksInput struct {
+	mcpTarget
+	Logs bool `json:"logs,omitempty" jsonschema:"include failing job log excerpts"`
+}
+
+type mcpResolveInput struct {
+	mcpTarget
+	ThreadIDs []string `json:"thread_ids" jsonschema:"review thread node IDs (PRRT_...)"`
+	Unresolve bool     `json:"unresolve,omitempty" jsonschema:"unresolve instead of resolve"`
+}
+
+type mcpReplyInput struct {
+	mcpTarget
+	ThreadID string `json:"thread_id" jsonschema:"review thread node ID (PRRT_...)"`
+	Body     string `json:"body" jsonschema:"reply body (markdown)"`
+	Resolve  bool   `json:"resolve,omitempty" jsonschema:"resolve the thread after replying"`
+
+	IdempotencyKey string `json:"idempotency_key,omitempty" jsonschema:"post at most once per thread: if a reply with this key exists, return it instead"`
+}
+
+type mcpDismissInput struct {
+	mcpTarget
+	Review   string `json:"review,omitempty" jsonschema:"review node ID or numeric review ID to dismiss"`
+	Author   string `json:"author,omitempty" jsonschema:"only stale blocking reviews from this author"`
+	BotsOnly bool   `json:"bots_only,omitempty" jsonschema:"only stale blocking reviews from bot accounts"`
+	Message  string `json:"message,omitempty" jsonschema:"dismissal message (required unless dry_run)"`
+	DryRun   bool   `json:"dry_run,omitempty" jsonschema:"report what would be dismissed without dismissing"`
+}
+
+// newMCPServer registers one tool per ghent operation. Input schemas come
+// from the input structs above and output schemas from the domain result
+// types, so the published schema always matches what the tool returns.
+func newMCPServer(client mcpClient) *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "ghent", Version: version.Version}, nil)
+	readOnly := &mcp.ToolAnnotations{ReadOnlyHint: true}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "status",
+		Description: "Merge readiness of a pull request: review threads, PR conversation, checks, reviews, " +
+			"and blockers. With watch/await_review it first waits for CI (and review activity) to settle, " +
+			"reporting progress notifications while it waits.",
+		Annotations: readOnly,
+	}, func(ctx context.Context, req *mcp.CallToolRequest, in mcpStatusInput) (*mcp.CallToolResult, domain.StatusResult, error) {
+		result, err := mcpStatus(ctx, client, req, in)
+		if err != nil {
+			return nil, domain.StatusResult{}, err
+		}
+		return nil, *result, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "comments",
+		Description: "Unresolved review threads and top-level PR conversation of a pull request.",
+		Annotations: readOnly,
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, in mcpCommentsInput) (*mcp.CallToolResult, domain.CommentsResult, error) {
+		owner, repo, err := in.resolve()
+		if err != nil {
+			return nil, domain.CommentsResult{}, err
+		}
+		result, err := fetchComments(ctx, client, owner, repo, in.PR)
+		if err != nil {
+			return nil, domain.CommentsResult{}, err
+		}
+		FilterThreadsBySince(result, Flags.Since)
+		FilterThreadsByBot(result, in.BotsOnly, false)
+		if in.Unanswered {
+			FilterThreadsByUnanswered(result)
+		}
+		return nil, *result, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "checks",
+		Description: "CI check runs and commit statuses of a pull request, with annotations for failures.",
+		Annotations: readOnly,
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, in mcpChecksInput) (*mcp.CallToolResult, domain.ChecksResult, error) {
+		owner, repo, err := in.resolve()
+		if err != nil {
+			return nil, domain.ChecksResult{}, err
+		}
+		result, err := client.FetchChecks(ctx, owner, repo, in.PR)
+		if err != nil {
+			return nil, domain.ChecksResult{}, err
+		}
+		FilterChecksBySince(result, Flags.Since)
+		if in.Logs {
+			attachLogExcerpts(ctx, client, owner, repo, result)
+		}
+		return nil, *result, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "resolve_threads",
+		Description: "Resolve (or unresolve) review threads by node ID. Per-thread failures are reported in errors.",
+		Annotations: &mcp.ToolAnnotations{IdempotentHint: true},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, in mcpResolveInput) (*mcp.CallToolResult, domain.ResolveResults, error) {
+		owner, repo, err := in.resolve()
+		if err != nil {
+			return nil, domain.ResolveResults{}, err
+		}
+		if len(in.ThreadIDs) == 0 {
+			return nil, domain.ResolveResults{}, errors.New("thread_ids must not be empty")
+		}
+		return nil, *resolveThreadIDs(ctx, client, owner, repo, in.PR, in.ThreadIDs, in.Unresolve), nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "reply_to_thread",
+		Description: "Post a reply to a review thread, optionally resolving it afterwards.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, in mcpReplyInput) (*mcp.CallToolResult, domain.ReplyResult, error) {
+		owner, repo, err := in.resolve()
+		if err != nil {
+			return nil, domain.ReplyResult{}, err
+		}
+		if in.ThreadID == "" || in.Body == "" {
+			return nil, domain.ReplyResult{}, errors.New("thread_id and body are required")
+		}
+		body := in.Body
+		if in.IdempotencyKey != "" {
+			if body, err = ghub.WithIdempotencyKey(body, in.IdempotencyKey); err != nil {
+				return nil, domain.ReplyResult{}, err
+			}
+		}
+		result, err := client.ReplyToThread(ctx, owner, repo, in.PR, in.ThreadID, body)
+		if err != nil {
+			return nil, domain.ReplyResult{}, err
+		}
+		if in.Resolve {
+			// A failed resolve after a posted reply is reported, not raised:
+			// the reply exists and must not be retried.
+			resolved, resolveErr := client.ResolveThread(ctx, owner, repo, in.PR, in.ThreadID)
+			if resolveErr != nil {
+				result.ResolveError = resolveErr.Error()
+			} else {
+				result.Resolved = resolved
+			}
+		}
+		return nil, *result, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "dismiss_reviews",
+		Description: "Dismiss stale CHANGES_REQUESTED reviews (review commit older than the PR head). " +
+			"Current reviews are never dismissed. Use dry_run first.",
+		Annotations: &mcp.ToolAnnotations{DestructiveHint: ptrTo(true)},
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, in mcpDismissInput) (*mcp.CallToolResult, domain.DismissResults, error) {
+		owner, repo, err := in.resolve()
+		if err != nil {
+			return nil, domain.DismissResults{}, err
+		}
+		if !in.DryRun && in.Message == "" {
+			return nil, domain.DismissResults{}, errors.New("message is required unless dry_run is set")
+		}
+		results, err := buildDismissResults(ctx, client, owner, repo, in.PR, in.Review, in.Author, in.BotsOnly, in.Message, in.DryRun)
+		if err != nil {
+			return nil, domain.DismissResults{}, err
+		}
+		return nil, *results, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "probe_activity",
+		Description: "Lightweight fingerprint of review activity (head SHA, thread and review counts, " +
+			"bot review signals). Compare two probes to tell whether anything changed.",
+		Annotations: readOnly,
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, in mcpTarget) (*mcp.CallToolResult, domain.ActivitySnapshot, error) {
+		owner, repo, err := in.resolve()
+		if err != nil {
+			return nil, domain.ActivitySnapshot{}, err
+		}
+		snap, err := client.ProbeActivity(ctx, owner, repo, in.PR)
+		if err != nil {
+			return nil, domain.ActivitySnapshot{}, err
+		}
+		return nil, *snap, nil
+	})
+
+	return server
+}
+
+// mcpStatus is the status command's pipe-mode flow: optional watch and
+// review await, then a full readiness evaluation.
+func mcpStatus(ctx context.Context, client mcpClient, req *mcp.CallToolRequest, in mcpStatusInput) (*domain.StatusResult, error) {
+	owner, repo, err := in.resolve()
+	if err != nil {
+		return nil, err
+	}
+	reviewTimeout := 5 * time.Minute
+	if in.ReviewTimeout != "" {
+		reviewTimeout, err = time.ParseDuration(in.ReviewTimeout)
+		if err != nil || reviewTimeout <= 0 {
+			return nil, fmt.Errorf("invalid review_timeout %q: expected a positive duration such as 3m", in.ReviewTimeout)
+		}
+	}
+
+	policy, err := loadMergePolicy(ctx, client, owner, repo, in.PR)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewMonitor *domain.ReviewMonitor
+	watch := in.Watch || in.AwaitReview
+	if watch {
+		reviewMonitor, err = watchUntilSettled(ctx, client, io.Discard, mcpProgress(ctx, req), owner, repo, in.PR, in.AwaitReview, reviewTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, _, _, err := collectStatus(ctx, client, owner, repo, in.PR, policy)
+	if err != nil {
+		return nil, err
+	}
+	if in.Logs || watch {
+		attachLogExcerpts(ctx, client, owner, repo, &result.Checks)
+	}
+	result.ReviewMonitor = reviewMonitor
+	result.ReviewSettled = reviewMonitor
+	return result, nil
+}
+
+// resolveThreadIDs resolves or unresolves each thread, collecting failures
+// per thread instead of stopping at the first one.
+func resolveThreadIDs(ctx context.Context, client domain.ThreadResolver, owner, repo string, pr int, ids []string, unresolve bool) *domain.ResolveResults {
+	results := &domain.ResolveResults{Results: []domain.ResolveResult{}}
+	for _, id := range ids {
+		result, msg := doResolve(ctx, client, owner, repo, pr, id, unresolve)
+		if msg != "" {
+			results.FailureCount++
+			results.Errors = append(results.Errors, domain.ResolveError{ThreadID: id, Message: msg})
+			continue
+		}
+		results.Results = append(results.Results, *result)
+		results.SuccessCount++
+	}
+	return results
+}
+
+// mcpProgress turns the watch statuses of an MCP call into progress
+// notifications on the caller's progress token. Without a token the
+// statuses are dropped.
+func mcpProgress(ctx context.Context, req *mcp.CallToolRequest) *watchHook {
+	var count float64
+	return &watchHook{onStatus: func(status *domain.WatchStatus) {
+		if req == nil || req.Session == nil {
+			return
+		}
+		token := req.Params.GetProgressToken()
+		if token == nil {
+			return
+		}
+		count++
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      count,
+			Message:       watchProgressMessage(status),
+		})
+		if err != nil {
+			// A lost notification must not abort the wait itself.
+			slog.Debug("mcp progress notification failed", "error", err)
+		}
+	}}
+}
+
+// watchProgressMessage summarizes a watch status in one line.
+func watchProgressMessage(s *domain.WatchStatus) string {
+	if s.ReviewPhase != "" {
+		msg := fmt.Sprintf("review %s", s.ReviewPhase)
+		if s.ReviewConfidence != "" {
+			msg += fmt.Sprintf(" (confidence %s)", s.ReviewConfidence)
+		}
+		if s.ReviewTimeoutIn > 0 {
+			msg += fmt.Sprintf(", idle %ds, timeout in %ds", s.ReviewIdleSecs, s.ReviewTimeoutIn)
+		}
+		return msg
+	}
+	return fmt.Sprintf("checks %d/%d complete: %d passed, %d failed, %d pending (%s)",
+		s.Completed, s.Total, s.PassCount, s.FailCount, s.PendingCount, s.OverallStatus)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+)
+
+// stubMCPClient adds the watch, reply, resolve, and log ports to
+// stubMergeClient. WatchChecks emits the configured statuses through the
+// formatter it is given, the way the real poller does.
+type stubMCPClient struct {
+	*stubMergeClient
+	watch    []domain.WatchStatus
+	resolved []string
+}
+
+func (s *stubMCPClient) ProbeActivity(context.Context, string, string, int) (*domain.ActivitySnapshot, error) {
+	return &domain.ActivitySnapshot{HeadSHA: s.pr.HeadSHA, ThreadCount: 2}, nil
+}
+
+func (s *stubMCPClient) WatchChecks(_ context.Context, w io.Writer, f domain.Formatter, _, _ string, _ int,
+	_ time.Duration, _ func() time.Time, _ bool) (domain.OverallStatus, error) {
+	for i := range s.watch {
+		if err := f.FormatWatchStatus(w, &s.watch[i]); err != nil {
+			return "", err
+		}
+	}
+	return domain.StatusPass, nil
+}
+
+func (s *stubMCPClient) WatchReviews(context.Context, io.Writer, domain.Formatter, string, string, int,
+	string, string, ghub.ReviewWatchConfig, func() time.Time) (*ghub.WatchReviewResult, error) {
+	return &ghub.WatchReviewResult{}, nil
+}
+
+func (s *stubMCPClient) FetchJobLog(context.Context, string, string, int64) (string, error) {
+	return "", nil
+}
+
+func (s *stubMCPClient) FetchBaseChecks(context.Context, string, string, int) (*domain.ChecksResult, error) {
+	return &domain.ChecksResult{}, nil
+}
+
+func (s *stubMCPClient) FetchBaseFile(context.Context, string, string, int, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *stubMCPClient) FetchJobSteps(context.Context, string, string, int64) ([]domain.JobStep, error) {
+	return nil, nil
+}
+
+func (s *stubMCPClient) ResolveThread(_ context.Context, _, _ string, _ int, id string) (*domain.ResolveResult, error) {
+	s.resolved = append(s.resolved, id)
+	return &domain.ResolveResult{ThreadID: id, IsResolved: true, Action: "resolved"}, nil
+}
+
+func (s *stubMCPClient) UnresolveThread(_ context.Context, _, _ string, _ int, id string) (*domain.ResolveResult, error) {
+	return &domain.ResolveResult{ThreadID: id, Action: "unresolved"}, nil
+}
+
+func (s *stubMCPClient) ReplyToThread(_ context.Context, _, _ string, _ int, threadID, body string) (*domain.ReplyResult, error) {
+	return &domain.ReplyResult{ThreadID: threadID, CommentID: 1, Body: body}, nil
+}
+
+func (s *stubMCPClient) DismissReview(context.Context, string, string, int, domain.Review, string) (*domain.DismissResult, error) {
+	return &domain.DismissResult{Dismissed: true, Action: "dismissed"}, nil
+}
+
+// connectMCP serves client over an in-memory transport and returns a
+// connected client session. Progress notifications are appended to progress.
+func connectMCP(t *testing.T, client mcpClient, progress *[]string) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverT, clientT := mcp.NewInMemoryTransports()
+	if _, err := newMCPServer(client).Connect(ctx, serverT, nil); err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	var mu sync.Mutex
+	c := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			*progress = append(*progress, req.Params.Message)
+		},
+	})
+	session, err := c.Connect(ctx, clientT, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestMCPListsToolsWithSchemas(t *testing.T) {
+	var progress []string
+	session := connectMCP(t, &stubMCPClient{stubMergeClient: readyMergeClient()}, &progress)
+
+	res, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	var names []string
+	for _, tool := range res.Tools {
+		names = append(names, tool.Name)
+		if tool.InputSchema == nil || tool.OutputSchema == nil {
+			t.Errorf("tool %s is missing an input or output schema", tool.Name)
+		}
+	}
+	sort.Strings(names)
+	want := []string{"checks", "comments", "dismiss_reviews", "probe_activity", "reply_to_thread", "resolve_threads", "status"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("tools mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMCPStatusStreamsWatchProgress(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	client := &stubMCPClient{
+		stubMergeClient: readyMergeClient(),
+		watch: []domain.WatchStatus{
+			{OverallStatus: domain.StatusPending, Total: 2, Completed: 1, PassCount: 1, PendingCount: 1},
+			{OverallStatus: domain.StatusPass, Total: 2, Completed: 2, PassCount: 2},
+		},
+	}
+	var progress []string
+	session := connectMCP(t, client, &progress)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "status",
+		Arguments: map[string]any{"repo": "nobody/nothing", "pr": 42, "watch": true},
+		Meta:      mcp.Meta{"progressToken": "tok"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("status returned a tool error: %+v", res.Content)
+	}
+
+	raw, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got domain.StatusResult
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	if !got.IsMergeReady || got.PRNumber != 42 {
+		t.Errorf("status = ready %v, pr %d; want ready PR 42", got.IsMergeReady, got.PRNumber)
+	}
+
+	wantProgress := []string{
+		"checks 1/2 complete: 1 passed, 0 failed, 1 pending (pending)",
+		"checks 2/2 complete: 2 passed, 0 failed, 0 pending (pass)",
+	}
+	if diff := cmp.Diff(wantProgress, progress); diff != "" {
+		t.Errorf("progress mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMCPReplyAndResolve(t *testing.T) {
+	client := &stubMCPClient{stubMergeClient: readyMergeClient()}
+	var progress []string
+	session := connectMCP(t, client, &progress)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "reply_to_thread",
+		Arguments: map[string]any{"repo": "o/r", "pr": 42, "thread_id": "PRRT_1", "body": "Fixed", "resolve": true},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("reply returned a tool error: %+v", res.Content)
+	}
+	if diff := cmp.Diff([]string{"PRRT_1"}, client.resolved); diff != "" {
+		t.Errorf("resolved threads mismatch (-want +got):\n%s", diff)
+	}
+
+	res, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "reply_to_thread",
+		Arguments: map[string]any{"repo": "o/r", "pr": 0, "thread_id": "PRRT_1", "body": "Fixed"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError {
+		t.Error("reply without a PR number succeeded; want a tool error")
+	}
+}
+
+func TestMCPResolveThreads(t *testing.T) {
+	client := &stubMCPClient{stubMergeClient: readyMergeClient()}
+	var progress []string
+	session := connectMCP(t, client, &progress)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "resolve_threads",
+		Arguments: map[string]any{"repo": "o/r", "pr": 42, "thread_ids": []string{"PRRT_1", "PRRT_2"}},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("resolve returned a tool error: %+v", res.Content)
+	}
+	if diff := cmp.Diff([]string{"PRRT_1", "PRRT_2"}, client.resolved); diff != "" {
+		t.Errorf("resolved threads mismatch (-want +got):\n%s", diff)
+	}
+
+	// The PR is required so every resolve lands in the audit log with it.
+	res, err = session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "resolve_threads",
+		Arguments: map[string]any{"repo": "o/r", "thread_ids": []string{"PRRT_3"}},
+	})
+	if err == nil && !res.IsError {
+		t.Error("resolve without a PR number succeeded; want an error")
+	}
+}
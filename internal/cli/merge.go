@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func newMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge",
+		Short: "Merge a PR after verifying it is merge-ready",
+		Long: `Evaluate merge readiness exactly like 'status' and, if nothing blocks,
+merge the pull request.
+
+The merge is pinned to the head SHA that readiness was verified against. ghent
+re-reads the PR just before merging and passes that SHA to GitHub, so a push
+that lands in between makes the merge fail instead of shipping unreviewed
+commits.
+
+With --auto or --queue, requirements enforced by the base branch's rules
+(pending required checks, approvals, up-to-date branch) are left for GitHub
+to wait on; everything else — unresolved threads, failing checks, drafts,
+conflicts, and policy file rules — must already pass.
+
+Exit codes: 0 = merged, auto-merge enabled, enqueued, or ready (--dry-run),
+1 = blocked or head changed, 2 = merge request failed.`,
+		Example: `  # Merge once status reports ready
+  gh ghent merge --pr 42
+
+  # Squash-merge with a custom commit subject
+  gh ghent merge --pr 42 --method squash --subject "Add retry budget (#42)"
+
+  # Let GitHub merge when required checks finish
+  gh ghent merge --pr 42 --auto
+
+  # Add to the merge queue
+  gh ghent merge --pr 42 --queue
+
+  # Check readiness and report what would happen
+  gh ghent merge --pr 42 --dry-run`,
+		RunE: runMerge,
+	}
+
+	cmd.Flags().String("method", domain.MergeMethodMerge, "merge method: merge, squash, or rebase")
+	cmd.Flags().Bool("auto", false, "enable auto-merge instead of merging now")
+	cmd.Flags().Bool("queue", false, "add the PR to the merge queue instead of merging now")
+	cmd.Flags().String("subject", "", "commit subject for merge and squash merges")
+	cmd.Flags().String("body", "", "commit body for merge and squash merges")
+	cmd.Flags().Bool("dry-run", false, "evaluate readiness without merging")
+	cmd.MarkFlagsMutuallyExclusive("auto", "queue")
+
+	return cmd
+}
+
+type mergeClient interface {
+	statusClient
+	domain.PullRequestMerger
+}
+
+// mergeOptions carries the merge command's flags into buildMergeResult.
+type mergeOptions struct {
+	Mode   string // domain.MergeModeDirect, MergeModeAuto, or MergeModeQueue
+	Req    domain.MergeRequest
+	DryRun bool
+}
+
+func runMerge(cmd *cobra.Command, _ []string) error {
+	method, err := cmd.Flags().GetString("method")
+	if err != nil {
+		return err
+	}
+	auto, err := cmd.Flags().GetBool("auto")
+	if err != nil {
+		return err
+	}
+	queue, err := cmd.Flags().GetBool("queue")
+	if err != nil {
+		return err
+	}
+	subject, err := cmd.Flags().GetString("subject")
+	if err != nil {
+		return err
+	}
+	body, err := cmd.Flags().GetString("body")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	method = strings.ToLower(method)
+	switch method {
+	case domain.MergeMethodMerge, domain.MergeMethodSquash, domain.MergeMethodRebase:
+	default:
+		return fmt.Errorf("invalid --method %q: must be merge, squash, or rebase", method)
+	}
+	opts := mergeOptions{
+		Mode:   domain.MergeModeDirect,
+		Req:    domain.MergeRequest{Method: method, CommitHeadline: subject, CommitBody: body},
+		DryRun: dryRun,
+	}
+	switch {
+	case auto:
+		opts.Mode = domain.MergeModeAuto
+	case queue:
+		opts.Mode = domain.MergeModeQueue
+	}
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	client := GitHubClient()
+	policy, err := loadMergePolicy(ctx, client, owner, repo, Flags.PR)
+	if err != nil {
+		return err
+	}
+
+	result, err := buildMergeResult(ctx, client, owner, repo, Flags.PR, policy, opts)
+	if err != nil {
+		return err
+	}
+
+	f, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	if err := f.FormatMergeResult(os.Stdout, result); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+
+	if exitCode := mergeExitCode(result); exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+// buildMergeResult evaluates readiness, re-checks the head SHA, and performs
+// the merge. Refusals and GitHub's rejection of the merge itself are reported
+// in the result; only failures to read the PR are returned as errors.
+func buildMergeResult(
+	ctx context.Context,
+	client mergeClient,
+	owner, repo string,
+	pr int,
+	policy *domain.MergePolicy,
+	opts mergeOptions,
+) (*domain.MergeResult, error) {
+	status, info, _, err := collectStatus(ctx, client, owner, repo, pr, policy)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("PR #%d: could not read pull request metadata; refusing to merge", pr)
+	}
+
+	result := &domain.MergeResult{
+		PRNumber: pr,
+		Method:   opts.Req.Method,
+		Mode:     opts.Mode,
+		HeadSHA:  info.HeadSHA,
+		Blockers: []domain.MergeBlocker{},
+		DryRun:   opts.DryRun,
+	}
+	if opts.Mode == domain.MergeModeQueue {
+		// The merge queue's own settings decide how the PR lands.
+		result.Method = ""
+	}
+
+	// Checks and PR metadata are fetched in parallel; a push between the two
+	// would pin a head whose checks were never looked at.
+	if status.Checks.HeadSHA != info.HeadSHA {
+		result.Action = "head_changed"
+		result.Error = fmt.Sprintf("checks were read for %s but the head is %s; run merge again", shortSHA(status.Checks.HeadSHA), shortSHA(info.HeadSHA))
+		return result, nil
+	}
+
+	blockers := status.Blockers
+	if req := status.MergeRequirements; req != nil && req.RequireLinearHistory &&
+		opts.Req.Method == domain.MergeMethodMerge && opts.Mode != domain.MergeModeQueue {
+		blockers = append(blockers, domain.MergeBlocker{
+			Rule:    "linear_history",
+			Source:  domain.BlockerSourceBranchRules,
+			Message: "branch rules require linear history; use --method squash or rebase",
+		})
+	}
+	for _, b := range blockers {
+		if opts.Mode != domain.MergeModeDirect && deferrableBlocker(b) {
+			result.Deferred = append(result.Deferred, b)
+			continue
+		}
+		result.Blockers = append(result.Blockers, b)
+	}
+	if len(result.Blockers) > 0 {
+		result.Action = "blocked"
+		return result, nil
+	}
+	if opts.DryRun {
+		result.Action = "would_merge"
+		return result, nil
+	}
+
+	// Readiness took several round trips; make sure nobody pushed meanwhile.
+	current, err := client.FetchPullRequest(ctx, owner, repo, pr)
+	if err != nil {
+		return nil, fmt.Errorf("re-fetch pull request: %w", err)
+	}
+	if current.HeadSHA != info.HeadSHA {
+		result.Action = "head_changed"
+		result.Error = fmt.Sprintf("head moved from %s to %s after readiness was verified", shortSHA(info.HeadSHA), shortSHA(current.HeadSHA))
+		return result, nil
+	}
+
+	switch opts.Mode {
+	case domain.MergeModeAuto:
+		err = client.EnableAutoMerge(ctx, info, opts.Req)
+		result.Action = "auto_merge_enabled"
+	case domain.MergeModeQueue:
+		err = client.EnqueuePullRequest(ctx, info)
+		result.Action = "enqueued"
+	default:
+		var commit string
+		commit, err = client.MergePullRequest(ctx, info, opts.Req)
+		result.Action = "merged"
+		result.Merged = err == nil
+		result.MergeCommitSHA = commit
+	}
+	if err != nil {
+		result.Action = "failed"
+		if isHeadMismatch(err) {
+			result.Action = "head_changed"
+		}
+		result.Error = err.Error()
+	}
+	return result, nil
+}
+
+// deferrableBlocker reports whether GitHub itself will wait on b before an
+// auto-merge or merge queue lands the PR. Only base-branch rules qualify;
+// linear history is checked up front because GitHub rejects the request.
+func deferrableBlocker(b domain.MergeBlocker) bool {
+	return b.Source == domain.BlockerSourceBranchRules && b.Rule != "linear_history"
+}
+
+// isHeadMismatch reports whether GitHub rejected a merge because the head
+// no longer matched expectedHeadOid.
+func isHeadMismatch(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "head branch was modified")
+}
+
+// mergeExitCode maps a merge result to the status-style exit codes:
+// 0 = done or would merge, 1 = refused, 2 = GitHub rejected the merge.
+func mergeExitCode(result *domain.MergeResult) int {
+	switch result.Action {
+	case "blocked", "head_changed":
+		return 1
+	case "failed":
+		return 2
+	default:
+		return 0
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,401 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// stubMergeClient serves a fixed PR for readiness and then heads[n] for each
+// subsequent FetchPullRequest, so tests can simulate a push mid-merge.
+type stubMergeClient struct {
+	threads  *domain.CommentsResult
+	checks   *domain.ChecksResult
+	base     *domain.ChecksResult // base branch checks; nil fails the fetch
+	reviews  []domain.Review
+	pr       *domain.PullRequestInfo
+	req      *domain.MergeRequirements
+	heads    []string
+	mergeErr error
+	logs     map[int64]string // job logs by check ID; a missing one fails the fetch
+
+	prFetches  int
+	mergeCalls int
+	autoCalls  int
+	queueCalls int
+}
+
+func (s *stubMergeClient) FetchThreads(context.Context, string, string, int) (*domain.CommentsResult, error) {
+	return s.threads, nil
+}
+
+func (s *stubMergeClient) FetchConversation(context.Context, string, string, int) ([]domain.ConversationComment, error) {
+	return nil, nil
+}
+
+// FetchChecks returns the checks of the PR's head unless the test pinned
+// them to another commit.
+func (s *stubMergeClient) FetchChecks(context.Context, string, string, int) (*domain.ChecksResult, error) {
+	checks := *s.checks
+	if checks.HeadSHA == "" {
+		checks.HeadSHA = s.pr.HeadSHA
+	}
+	return &checks, nil
+}
+
+func (s *stubMergeClient) FetchBaseChecks(context.Context, string, string, int) (*domain.ChecksResult, error) {
+	if s.base == nil {
+		return nil, errors.New("no base checks")
+	}
+	return s.base, nil
+}
+
+func (s *stubMergeClient) FetchReviews(context.Context, string, string, int) ([]domain.Review, error) {
+	return s.reviews, nil
+}
+
+func (s *stubMergeClient) FetchPullRequest(context.Context, string, string, int) (*domain.PullRequestInfo, error) {
+	info := *s.pr
+	if s.prFetches > 0 && s.prFetches <= len(s.heads) {
+		info.HeadSHA = s.heads[s.prFetches-1]
+	}
+	s.prFetches++
+	return &info, nil
+}
+
+func (s *stubMergeClient) FetchMergeRequirements(context.Context, string, string, string) (*domain.MergeRequirements, error) {
+	if s.req == nil {
+		return nil, errors.New("no rules")
+	}
+	return s.req, nil
+}
+
+func (s *stubMergeClient) FetchJobLog(_ context.Context, _, _ string, jobID int64) (string, error) {
+	if log, ok := s.logs[jobID]; ok {
+		return log, nil
+	}
+	return "", errors.New("no log")
+}
+
+func (s *stubMergeClient) FetchJobSteps(context.Context, string, string, int64) ([]domain.JobStep, error) {
+	return nil, nil
+}
+
+func (s *stubMergeClient) MergePullRequest(context.Context, *domain.PullRequestInfo, domain.MergeRequest) (string, error) {
+	s.mergeCalls++
+	if s.mergeErr != nil {
+		return "", s.mergeErr
+	}
+	return "fff999", nil
+}
+
+func (s *stubMergeClient) EnableAutoMerge(context.Context, *domain.PullRequestInfo, domain.MergeRequest) error {
+	s.autoCalls++
+	return s.mergeErr
+}
+
+func (s *stubMergeClient) EnqueuePullRequest(context.Context, *domain.PullRequestInfo) error {
+	s.queueCalls++
+	return s.mergeErr
+}
+
+func readyMergeClient() *stubMergeClient {
+	return &stubMergeClient{
+		threads: &domain.CommentsResult{},
+		checks:  &domain.ChecksResult{OverallStatus: domain.StatusPass},
+		reviews: []domain.Review{{Author: "alice", State: domain.ReviewApproved}},
+		pr:      &domain.PullRequestInfo{ID: "PR_1", Number: 42, BaseRef: "main", HeadSHA: "aaa1111", MergeStateStatus: "CLEAN"},
+	}
+}
+
+func TestBuildMergeResult(t *testing.T) {
+	direct := mergeOptions{Mode: domain.MergeModeDirect, Req: domain.MergeRequest{Method: domain.MergeMethodSquash}}
+	auto := mergeOptions{Mode: domain.MergeModeAuto, Req: domain.MergeRequest{Method: domain.MergeMethodSquash}}
+	needsApproval := &domain.MergeRequirements{BaseBranch: "main", RequiredApprovals: 1, Sources: []string{"branch_protection"}}
+
+	tests := []struct {
+		name       string
+		setup      func(*stubMergeClient)
+		opts       mergeOptions
+		wantAction string
+		wantExit   int
+		wantCalls  int // merge + auto-merge + enqueue mutations
+		wantBlock  []string
+		wantDefer  []string
+	}{
+		{
+			name:       "ready PR is merged",
+			opts:       direct,
+			wantAction: "merged",
+			wantCalls:  1,
+		},
+		{
+			name:       "dry run does not merge",
+			opts:       mergeOptions{Mode: domain.MergeModeDirect, Req: direct.Req, DryRun: true},
+			wantAction: "would_merge",
+		},
+		{
+			name: "unresolved thread blocks",
+			setup: func(s *stubMergeClient) {
+				s.threads = &domain.CommentsResult{Threads: []domain.ReviewThread{{ID: "T1"}}, UnresolvedCount: 1}
+			},
+			opts:       direct,
+			wantAction: "blocked",
+			wantExit:   1,
+			wantBlock:  []string{"unresolved_threads"},
+		},
+		{
+			name:       "head moved after readiness",
+			setup:      func(s *stubMergeClient) { s.heads = []string{"bbb2222"} },
+			opts:       direct,
+			wantAction: "head_changed",
+			wantExit:   1,
+		},
+		{
+			name:       "checks read for an older head",
+			setup:      func(s *stubMergeClient) { s.checks.HeadSHA = "old0000" },
+			opts:       direct,
+			wantAction: "head_changed",
+			wantExit:   1,
+		},
+		{
+			name: "GitHub rejects a stale head",
+			setup: func(s *stubMergeClient) {
+				s.mergeErr = errors.New("merge pull request: Head branch was modified. Review and try the merge again.")
+			},
+			opts:       direct,
+			wantAction: "head_changed",
+			wantExit:   1,
+			wantCalls:  1,
+		},
+		{
+			name:       "merge failure",
+			setup:      func(s *stubMergeClient) { s.mergeErr = errors.New("merge pull request: not mergeable") },
+			opts:       direct,
+			wantAction: "failed",
+			wantExit:   2,
+			wantCalls:  1,
+		},
+		{
+			name: "branch rule blocks a direct merge",
+			setup: func(s *stubMergeClient) {
+				s.reviews = []domain.Review{}
+				s.req = needsApproval
+			},
+			opts:       direct,
+			wantAction: "blocked",
+			wantExit:   1,
+			wantBlock:  []string{"required_approvals"},
+		},
+		{
+			name: "auto-merge defers branch rules to GitHub",
+			setup: func(s *stubMergeClient) {
+				s.reviews = []domain.Review{}
+				s.req = needsApproval
+			},
+			opts:       auto,
+			wantAction: "auto_merge_enabled",
+			wantCalls:  1,
+			wantDefer:  []string{"required_approvals"},
+		},
+		{
+			name: "auto-merge still refuses failing checks",
+			setup: func(s *stubMergeClient) {
+				s.checks = &domain.ChecksResult{OverallStatus: domain.StatusFail, FailCount: 1}
+			},
+			opts:       auto,
+			wantAction: "blocked",
+			wantExit:   1,
+			wantBlock:  []string{"checks"},
+		},
+		{
+			name: "linear history rejects merge commits",
+			setup: func(s *stubMergeClient) {
+				s.req = &domain.MergeRequirements{BaseBranch: "main", RequireLinearHistory: true, Sources: []string{"branch_protection"}}
+			},
+			opts:       mergeOptions{Mode: domain.MergeModeAuto, Req: domain.MergeRequest{Method: domain.MergeMethodMerge}},
+			wantAction: "blocked",
+			wantExit:   1,
+			wantBlock:  []string{"linear_history"},
+		},
+		{
+			name:       "queue enqueues",
+			opts:       mergeOptions{Mode: domain.MergeModeQueue, Req: direct.Req},
+			wantAction: "enqueued",
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := readyMergeClient()
+			if tt.setup != nil {
+				tt.setup(client)
+			}
+
+			got, err := buildMergeResult(context.Background(), client, "owner", "repo", 42, nil, tt.opts)
+			if err != nil {
+				t.Fatalf("buildMergeResult() error: %v", err)
+			}
+			if got.Action != tt.wantAction {
+				t.Errorf("Action = %q, want %q (error %q)", got.Action, tt.wantAction, got.Error)
+			}
+			if code := mergeExitCode(got); code != tt.wantExit {
+				t.Errorf("mergeExitCode() = %d, want %d", code, tt.wantExit)
+			}
+			if calls := client.mergeCalls + client.autoCalls + client.queueCalls; calls != tt.wantCalls {
+				t.Errorf("mutation calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if got.HeadSHA != "aaa1111" {
+				t.Errorf("HeadSHA = %q, want the verified head aaa1111", got.HeadSHA)
+			}
+			if diff := cmp.Diff(tt.wantBlock, blockerRules(got.Blockers)); diff != "" {
+				t.Errorf("blockers mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDefer, blockerRules(got.Deferred)); diff != "" {
+				t.Errorf("deferred mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildMergeResult_PreExistingFailures(t *testing.T) {
+	policy := &domain.MergePolicy{IgnorePreExistingFailures: true}
+	opts := mergeOptions{Mode: domain.MergeModeDirect, Req: domain.MergeRequest{Method: domain.MergeMethodSquash}}
+	goTestLog := func(tests ...string) string {
+		var b strings.Builder
+		for _, name := range tests {
+			fmt.Fprintf(&b, "--- FAIL: %s (0.01s)\n", name)
+		}
+		b.WriteString("FAIL\texample.com/app\t0.02s\n")
+		return b.String()
+	}
+	baseLint := &domain.ChecksResult{HeadSHA: "base111", BaseRef: "main", Checks: []domain.CheckRun{
+		{ID: 2, Name: "lint", Status: "completed", Conclusion: "failure"},
+	}}
+
+	tests := []struct {
+		name       string
+		base       *domain.ChecksResult
+		logs       map[int64]string
+		wantAction string
+	}{
+		{
+			name:       "same failing tests as the base",
+			base:       baseLint,
+			logs:       map[int64]string{1: goTestLog("TestOld"), 2: goTestLog("TestOld")},
+			wantAction: "merged",
+		},
+		{
+			name:       "no recognizable tests",
+			base:       baseLint,
+			logs:       map[int64]string{1: "lint: 3 issues\n", 2: "lint: 3 issues\n"},
+			wantAction: "merged",
+		},
+		{
+			name:       "new failing test in a red check",
+			base:       baseLint,
+			logs:       map[int64]string{1: goTestLog("TestOld", "TestNew"), 2: goTestLog("TestOld")},
+			wantAction: "blocked",
+		},
+		{
+			name:       "base log unavailable",
+			base:       baseLint,
+			logs:       map[int64]string{1: goTestLog("TestOld")},
+			wantAction: "blocked",
+		},
+		{
+			name:       "PR log unavailable",
+			base:       baseLint,
+			logs:       map[int64]string{2: goTestLog("TestOld")},
+			wantAction: "blocked",
+		},
+		{
+			name:       "base checks unavailable",
+			wantAction: "blocked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := readyMergeClient()
+			client.checks = &domain.ChecksResult{
+				OverallStatus: domain.StatusFail,
+				FailCount:     1,
+				Checks:        []domain.CheckRun{{ID: 1, Name: "lint", Status: "completed", Conclusion: "failure"}},
+			}
+			client.base = tt.base
+			client.logs = tt.logs
+
+			got, err := buildMergeResult(context.Background(), client, "owner", "repo", 42, policy, opts)
+			if err != nil {
+				t.Fatalf("buildMergeResult() error: %v", err)
+			}
+			if got.Action != tt.wantAction {
+				t.Errorf("Action = %q, want %q (blockers %v)", got.Action, tt.wantAction, blockerRules(got.Blockers))
+			}
+		})
+	}
+}
+
+func TestBuildMergeResult_MergedCommit(t *testing.T) {
+	client := readyMergeClient()
+	opts := mergeOptions{Mode: domain.MergeModeDirect, Req: domain.MergeRequest{Method: domain.MergeMethodRebase}}
+
+	got, err := buildMergeResult(context.Background(), client, "owner", "repo", 42, nil, opts)
+	if err != nil {
+		t.Fatalf("buildMergeResult() error: %v", err)
+	}
+	want := &domain.MergeResult{
+		PRNumber: 42, Method: "rebase", Mode: "direct", HeadSHA: "aaa1111",
+		Action: "merged", Merged: true, MergeCommitSHA: "fff999", Blockers: []domain.MergeBlocker{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergeResult mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func blockerRules(blockers []domain.MergeBlocker) []string {
+	var rules []string
+	for _, b := range blockers {
+		rules = append(rules, b.Rule)
+	}
+	return rules
+}
+
+func TestBuildMergeResult_SinceDoesNotHideBlockers(t *testing.T) {
+	saved := Flags
+	t.Cleanup(func() { Flags = saved })
+	Flags.Since = time.Now().Add(-time.Hour)
+
+	old := time.Now().Add(-48 * time.Hour)
+	client := readyMergeClient()
+	client.threads = &domain.CommentsResult{
+		Threads:         []domain.ReviewThread{{ID: "T1", Comments: []domain.Comment{{CreatedAt: old}}}},
+		UnresolvedCount: 1,
+	}
+	client.checks = &domain.ChecksResult{
+		OverallStatus: domain.StatusFail,
+		FailCount:     1,
+		Checks:        []domain.CheckRun{{Name: "lint", Status: "completed", Conclusion: "failure", CompletedAt: old}},
+	}
+
+	got, err := buildMergeResult(context.Background(), client, "owner", "repo", 42, nil,
+		mergeOptions{Mode: domain.MergeModeDirect, Req: domain.MergeRequest{Method: domain.MergeMethodSquash}})
+	if err != nil {
+		t.Fatalf("buildMergeResult() error: %v", err)
+	}
+	if got.Action != "blocked" || client.mergeCalls != 0 {
+		t.Fatalf("Action = %q with %d merge calls, want blocked", got.Action, client.mergeCalls)
+	}
+	if diff := cmp.Diff([]string{"unresolved_threads", "checks"}, blockerRules(got.Blockers)); diff != "" {
+		t.Errorf("blockers mismatch (-want +got):\n%s", diff)
+	}
+}
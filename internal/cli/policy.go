@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"gopkg.in/yaml.v3"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// Policy file locations. The repo file is read from the PR's base branch so
+// it is reviewed like any other change; the user file holds personal
+// defaults.
+const (
+	repoPolicyPath = ".github/ghent.yml"
+	userPolicyFile = "policy.yml" // under <UserConfigDir>/gh-ghent
+)
+
+// policyFile is the on-disk merge policy schema. Pointer and nil-slice fields
+// distinguish "unset" from an explicit value so a repo file can override
+// individual user settings.
+type policyFile struct {
+	IgnoreChecks               []string `yaml:"ignore_checks"`
+	RequiredChecks             []string `yaml:"required_checks"`
+	NeutralIsFailure           *bool    `yaml:"neutral_is_failure"`
+	SkippedIsFailure           *bool    `yaml:"skipped_is_failure"`
+	MinApprovals               *int     `yaml:"min_approvals"`
+	IgnoreBotApprovals         *bool    `yaml:"ignore_bot_approvals"`
+	BlockingLabels             []string `yaml:"blocking_labels"`
+	AllowLabels                []string `yaml:"allow_labels"`
+	OutdatedThreadsNonBlocking *bool    `yaml:"outdated_threads_non_blocking"`
+	IgnorePreExistingFailures  *bool    `yaml:"ignore_pre_existing_failures"`
+}
+
+// loadMergePolicy reads the user policy and, on top of it, the repo policy
+// as committed on the head of the PR's base branch. Neither the working tree
+// nor the PR itself is consulted, so a PR cannot loosen the rules it is
+// judged by. It returns nil when neither file exists. Malformed files are
+// errors: silently ignoring a policy would report PRs as merge-ready that the
+// team considers blocked.
+func loadMergePolicy(ctx context.Context, client domain.BaseFileFetcher, owner, repo string, pr int) (*domain.MergePolicy, error) {
+	var policy *domain.MergePolicy
+	// apply overlays one file; name is shown in blocker sources.
+	apply := func(data []byte, path, name string) error {
+		pf, err := parsePolicy(data)
+		if err != nil {
+			return fmt.Errorf("merge policy %s: %w", path, err)
+		}
+		if policy == nil {
+			policy = &domain.MergePolicy{Origins: map[string]string{}}
+		}
+		applyPolicy(policy, pf, name)
+		slog.Debug("loaded merge policy", "path", path)
+		return nil
+	}
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		path := filepath.Join(dir, "gh-ghent", userPolicyFile)
+		data, err := os.ReadFile(path) //nolint:gosec // fixed policy location
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("read merge policy: %w", err)
+		default:
+			if err := apply(data, path, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	data, err := client.FetchBaseFile(ctx, owner, repo, pr, repoPolicyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read merge policy: %w", err)
+	}
+	if data != nil {
+		if err := apply(data, repoPolicyPath, repoPolicyPath); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// checkoutRoot returns the top-level directory of the current git checkout
+// if its remote resolves to owner/repo, so --repo pointing elsewhere never
+// picks up an unrelated repo's files.
+func checkoutRoot(ctx context.Context, owner, repo string) string {
+	current, err := repository.Current()
+	if err != nil || !strings.EqualFold(current.Owner+"/"+current.Name, owner+"/"+repo) {
+		return ""
+	}
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// parsePolicy decodes a policy file, rejecting unknown keys so typos surface.
+func parsePolicy(data []byte) (*policyFile, error) {
+	var pf policyFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&pf); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if pf.MinApprovals != nil && *pf.MinApprovals < 0 {
+		return nil, fmt.Errorf("min_approvals must be >= 0, got %d", *pf.MinApprovals)
+	}
+	for _, pattern := range pf.IgnoreChecks {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("ignore_checks: invalid pattern %q", pattern)
+		}
+	}
+	return &pf, nil
+}
+
+// applyPolicy overlays every field set in pf onto p, recording origin as the
+// source of each overridden rule.
+func applyPolicy(p *domain.MergePolicy, pf *policyFile, origin string) {
+	setList := func(key string, dst *[]string, src []string) {
+		if src != nil {
+			*dst = src
+			p.Origins[key] = origin
+		}
+	}
+	setBool := func(key string, dst *bool, src *bool) {
+		if src != nil {
+			*dst = *src
+			p.Origins[key] = origin
+		}
+	}
+
+	setList("ignore_checks", &p.IgnoreChecks, pf.IgnoreChecks)
+	setList("required_checks", &p.RequiredChecks, pf.RequiredChecks)
+	setBool("neutral_is_failure", &p.NeutralIsFailure, pf.NeutralIsFailure)
+	setBool("skipped_is_failure", &p.SkippedIsFailure, pf.SkippedIsFailure)
+	if pf.MinApprovals != nil {
+		n := *pf.MinApprovals
+		p.MinApprovals = &n
+		p.Origins["min_approvals"] = origin
+	}
+	setBool("ignore_bot_approvals", &p.IgnoreBotApprovals, pf.IgnoreBotApprovals)
+	setList("blocking_labels", &p.BlockingLabels, pf.BlockingLabels)
+	setList("allow_labels", &p.AllowLabels, pf.AllowLabels)
+	setBool("outdated_threads_non_blocking", &p.OutdatedThreadsNonBlocking, pf.OutdatedThreadsNonBlocking)
+	setBool("ignore_pre_existing_failures", &p.IgnorePreExistingFailures, pf.IgnorePreExistingFailures)
+}
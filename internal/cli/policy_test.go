@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "empty file", input: ""},
+		{name: "comments only", input: "# nothing yet\n"},
+		{name: "all keys", input: `
+ignore_checks: ["preview/*"]
+required_checks: [build]
+neutral_is_failure: true
+skipped_is_failure: false
+min_approvals: 2
+ignore_bot_approvals: true
+blocking_labels: [do-not-merge]
+allow_labels: [ready]
+outdated_threads_non_blocking: true
+ignore_pre_existing_failures: true
+`},
+		{name: "unknown key rejected", input: "min_approvls: 2\n", wantErr: true},
+		{name: "negative approvals rejected", input: "min_approvals: -1\n", wantErr: true},
+		{name: "bad glob rejected", input: "ignore_checks: ['[']\n", wantErr: true},
+		{name: "wrong type rejected", input: "min_approvals: two\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePolicy([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyPolicy_RepoOverridesUser(t *testing.T) {
+	user, err := parsePolicy([]byte("min_approvals: 1\nignore_bot_approvals: true\nblocking_labels: [wip]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := parsePolicy([]byte("min_approvals: 2\nblocking_labels: []\nrequired_checks: [build]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := &domain.MergePolicy{Origins: map[string]string{}}
+	applyPolicy(got, user, "user.yml")
+	applyPolicy(got, repo, "repo.yml")
+
+	two := 2
+	want := &domain.MergePolicy{
+		RequiredChecks:     []string{"build"},
+		MinApprovals:       &two,
+		IgnoreBotApprovals: true,
+		BlockingLabels:     []string{},
+		Origins: map[string]string{
+			"min_approvals":        "repo.yml",
+			"ignore_bot_approvals": "user.yml",
+			"blocking_labels":      "repo.yml",
+			"required_checks":      "repo.yml",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("applyPolicy mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// stubBaseFile serves one file as the base branch's copy of every path.
+type stubBaseFile []byte
+
+func (s stubBaseFile) FetchBaseFile(context.Context, string, string, int, string) ([]byte, error) {
+	return s, nil
+}
+
+func TestLoadMergePolicy_UserFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Skip("no user config dir")
+	}
+
+	// No policy file → nil policy.
+	got, err := loadMergePolicy(context.Background(), stubBaseFile(nil), "nobody", "nothing", 1)
+	if err != nil || got != nil {
+		t.Fatalf("loadMergePolicy() = %v, %v; want nil, nil", got, err)
+	}
+
+	path := filepath.Join(cfgDir, "gh-ghent", userPolicyFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("allow_labels: [ready]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err = loadMergePolicy(context.Background(), stubBaseFile(nil), "nobody", "nothing", 1)
+	if err != nil {
+		t.Fatalf("loadMergePolicy() error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"ready"}, got.AllowLabels); diff != "" {
+		t.Errorf("AllowLabels mismatch (-want +got):\n%s", diff)
+	}
+	if got.Source("allow_labels") != "policy:"+path {
+		t.Errorf("Source() = %q, want policy:%s", got.Source("allow_labels"), path)
+	}
+
+	if err := os.WriteFile(path, []byte("allow_labels: ready: yes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadMergePolicy(context.Background(), stubBaseFile(nil), "nobody", "nothing", 1); err == nil {
+		t.Error("loadMergePolicy() accepted a malformed file")
+	}
+}
+
+func TestLoadMergePolicy_BaseBranchFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Skip("no user config dir")
+	}
+	path := filepath.Join(cfgDir, "gh-ghent", userPolicyFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("min_approvals: 1\nallow_labels: [ready]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadMergePolicy(context.Background(), stubBaseFile("min_approvals: 2\n"), "o", "r", 1)
+	if err != nil {
+		t.Fatalf("loadMergePolicy() error: %v", err)
+	}
+	if got.MinApprovals == nil || *got.MinApprovals != 2 {
+		t.Errorf("MinApprovals = %v, want 2 from the base branch file", got.MinApprovals)
+	}
+	if diff := cmp.Diff(map[string]string{"min_approvals": repoPolicyPath, "allow_labels": path}, got.Origins); diff != "" {
+		t.Errorf("Origins mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := loadMergePolicy(context.Background(), stubBaseFile("min_approvals: two\n"), "o", "r", 1); err == nil {
+		t.Error("loadMergePolicy() accepted a malformed base branch file")
+	}
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+)
+
+// prRef is a parsed --pr value. Exactly one of Number or Branch is meaningful;
+// when both are empty the PR is looked up from the checked-out branch.
+type prRef struct {
+	Owner  string // set when the reference names a repository (URL or OWNER/REPO#N)
+	Repo   string
+	Number int
+	Branch string
+}
+
+// parsePRRef parses a --pr value. Accepted forms:
+//
+//	42, #42                              PR number
+//	https://github.com/owner/repo/pull/42  PR URL (any host, trailing path ignored)
+//	owner/repo#42                        repository-qualified number
+//	feature/login                        head branch name
+//
+// An empty value means "the PR for the current branch".
+func parsePRRef(s string) (prRef, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return prRef{}, nil
+	}
+
+	if n, ok := parsePRNumber(strings.TrimPrefix(s, "#")); ok {
+		return prRef{Number: n}, nil
+	}
+	if strings.HasPrefix(s, "#") {
+		return prRef{}, fmt.Errorf("invalid --pr value %q: expected a positive PR number", s)
+	}
+
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+		return parsePRURL(s)
+	}
+
+	if repoPart, numPart, ok := strings.Cut(s, "#"); ok {
+		owner, repo, found := strings.Cut(repoPart, "/")
+		n, numOK := parsePRNumber(numPart)
+		if !found || owner == "" || repo == "" || strings.Contains(repo, "/") || !numOK {
+			return prRef{}, fmt.Errorf("invalid --pr value %q: expected OWNER/REPO#NUMBER", s)
+		}
+		return prRef{Owner: owner, Repo: repo, Number: n}, nil
+	}
+
+	return prRef{Branch: s}, nil
+}
+
+// parsePRURL extracts owner, repo, and number from a pull request URL such as
+// https://github.com/owner/repo/pull/42/files.
+func parsePRURL(s string) (prRef, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return prRef{}, fmt.Errorf("invalid --pr URL %q: %w", s, err)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" || parts[0] == "" || parts[1] == "" {
+		return prRef{}, fmt.Errorf("invalid --pr URL %q: expected https://HOST/OWNER/REPO/pull/NUMBER", s)
+	}
+	n, ok := parsePRNumber(parts[3])
+	if !ok {
+		return prRef{}, fmt.Errorf("invalid --pr URL %q: %q is not a PR number", s, parts[3])
+	}
+	return prRef{Owner: parts[0], Repo: parts[1], Number: n}, nil
+}
+
+func parsePRNumber(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// resolvePRTarget determines the repository and pull request for the current
+// invocation from --repo and --pr. The resolved values are written back to
+// Flags.Repo and Flags.PR so downstream helpers see a consistent target.
+//
+// When --pr is a branch name, or is omitted entirely, the open PR for that
+// branch (or the checked-out branch) is looked up on GitHub.
+func resolvePRTarget(ctx context.Context) (string, string, error) {
+	ref, err := parsePRRef(Flags.PRRef)
+	if err != nil {
+		return "", "", err
+	}
+
+	owner, repo := ref.Owner, ref.Repo
+	if owner == "" {
+		owner, repo, err = resolveRepo(Flags.Repo)
+		if err != nil {
+			return "", "", err
+		}
+	} else if Flags.Repo != "" && !strings.EqualFold(Flags.Repo, owner+"/"+repo) {
+		return "", "", fmt.Errorf("--pr %q refers to %s/%s but --repo is %s", Flags.PRRef, owner, repo, Flags.Repo)
+	}
+
+	number := ref.Number
+	if number == 0 {
+		branch, headOwner := ref.Branch, ""
+		if branch == "" {
+			branch, headOwner, err = currentBranch(ctx)
+			if err != nil {
+				return "", "", err
+			}
+		}
+		number, err = GitHubClient().FindPRForBranch(ctx, owner, repo, branch, headOwner)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	Flags.Repo = owner + "/" + repo
+	Flags.PR = number
+	return owner, repo, nil
+}
+
+// currentBranch returns the remote branch name that the checked-out branch
+// tracks, falling back to the local branch name when no upstream is set,
+// and the owner of the repository it is pushed to. The owner is "" when no
+// remote is configured for the branch.
+func currentBranch(ctx context.Context) (branch, headOwner string, err error) {
+	out, err := exec.CommandContext(ctx, "git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine current branch (detached HEAD or not a git repo); use --pr")
+	}
+	local := strings.TrimSpace(string(out))
+
+	branch = local
+	if upstream := strings.TrimPrefix(gitConfig(ctx, "branch."+local+".merge"), "refs/heads/"); upstream != "" {
+		branch = upstream
+	}
+	return branch, pushOwner(ctx, local), nil
+}
+
+// pushOwner returns the owner of the repository a local branch is pushed
+// to: its pushRemote, remote.pushDefault, or its upstream remote, in git's
+// order of precedence.
+func pushOwner(ctx context.Context, local string) string {
+	remote := gitConfig(ctx, "branch."+local+".pushRemote")
+	if remote == "" {
+		remote = gitConfig(ctx, "remote.pushDefault")
+	}
+	if remote == "" {
+		remote = gitConfig(ctx, "branch."+local+".remote")
+	}
+	if remote == "" || remote == "." {
+		return ""
+	}
+	out, err := exec.CommandContext(ctx, "git", "remote", "get-url", remote).Output() //nolint:gosec // remote name comes from git config
+	if err != nil {
+		return ""
+	}
+	r, err := repository.Parse(strings.TrimSpace(string(out)))
+	if err != nil {
+		return ""
+	}
+	return r.Owner
+}
+
+// gitConfig returns a git config value, or "" when it is unset.
+func gitConfig(ctx context.Context, key string) string {
+	out, err := exec.CommandContext(ctx, "git", "config", "--get", key).Output() //nolint:gosec // key is built from git's own names
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePRRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    prRef
+		wantErr bool
+	}{
+		{name: "empty means current branch", input: "", want: prRef{}},
+		{name: "plain number", input: "42", want: prRef{Number: 42}},
+		{name: "hash number", input: "#42", want: prRef{Number: 42}},
+		{name: "whitespace trimmed", input: "  42 ", want: prRef{Number: 42}},
+		{
+			name:  "github URL",
+			input: "https://github.com/owner/repo/pull/42",
+			want:  prRef{Owner: "owner", Repo: "repo", Number: 42},
+		},
+		{
+			name:  "URL with trailing path",
+			input: "https://github.com/owner/repo/pull/42/files#diff-abc",
+			want:  prRef{Owner: "owner", Repo: "repo", Number: 42},
+		},
+		{
+			name:  "enterprise host",
+			input: "https://ghe.example.com/org/svc/pull/7",
+			want:  prRef{Owner: "org", Repo: "svc", Number: 7},
+		},
+		{name: "owner/repo#N", input: "owner/repo#42", want: prRef{Owner: "owner", Repo: "repo", Number: 42}},
+		{name: "branch name", input: "feature/login", want: prRef{Branch: "feature/login"}},
+		{name: "simple branch", input: "main", want: prRef{Branch: "main"}},
+		{name: "negative hash", input: "#-1", wantErr: true},
+		{name: "URL not a PR", input: "https://github.com/owner/repo/issues/42", wantErr: true},
+		{name: "URL bad number", input: "https://github.com/owner/repo/pull/abc", wantErr: true},
+		{name: "URL too short", input: "https://github.com/owner", wantErr: true},
+		{name: "owner/repo# missing number", input: "owner/repo#", wantErr: true},
+		{name: "repo#N without owner", input: "repo#42", wantErr: true},
+		{name: "nested repo path", input: "a/b/c#42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePRRef(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePRRef(%q) expected error, got %+v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePRRef(%q) unexpected error: %v", tt.input, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parsePRRef(%q) mismatch (-want +got):\n%s", tt.input, diff)
+			}
+		})
+	}
+}
+
+func TestResolvePRTarget_RepoConflict(t *testing.T) {
+	saved := Flags
+	t.Cleanup(func() { Flags = saved })
+
+	Flags = GlobalFlags{
+		Repo:  "other/repo",
+		PRRef: "https://github.com/owner/repo/pull/42",
+	}
+
+	_, _, err := resolvePRTarget(t.Context())
+	if err == nil {
+		t.Fatal("expected error when --pr URL conflicts with --repo")
+	}
+}
+
+func TestResolvePRTarget_ExplicitNumber(t *testing.T) {
+	saved := Flags
+	t.Cleanup(func() { Flags = saved })
+
+	Flags = GlobalFlags{
+		Repo:  "owner/repo",
+		PRRef: "owner/repo#17",
+	}
+
+	owner, repo, err := resolvePRTarget(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner != "owner" || repo != "repo" {
+		t.Errorf("got %s/%s, want owner/repo", owner, repo)
+	}
+	if Flags.PR != 17 {
+		t.Errorf("Flags.PR = %d, want 17", Flags.PR)
+	}
+}
+
+func TestCurrentBranch_PushOwner(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Chdir(t.TempDir())
+	git := func(args ...string) {
+		t.Helper()
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "fix")
+	git("remote", "add", "origin", "https://github.com/upstream/repo.git")
+	git("remote", "add", "fork", "git@github.com:me/repo.git")
+
+	tests := []struct {
+		name       string
+		config     [][2]string
+		wantBranch string
+		wantOwner  string
+	}{
+		{name: "no remote", wantBranch: "fix"},
+		{
+			name:       "upstream remote",
+			config:     [][2]string{{"branch.fix.remote", "origin"}, {"branch.fix.merge", "refs/heads/patch-1"}},
+			wantBranch: "patch-1",
+			wantOwner:  "upstream",
+		},
+		{
+			name:       "push remote wins",
+			config:     [][2]string{{"branch.fix.pushRemote", "fork"}},
+			wantBranch: "patch-1",
+			wantOwner:  "me",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, kv := range tt.config {
+			git("config", kv[0], kv[1])
+		}
+		branch, owner, err := currentBranch(t.Context())
+		if err != nil {
+			t.Fatalf("%s: currentBranch() error: %v", tt.name, err)
+		}
+		if branch != tt.wantBranch || owner != tt.wantOwner {
+			t.Errorf("%s: currentBranch() = %q, %q, want %q, %q", tt.name, branch, owner, tt.wantBranch, tt.wantOwner)
+		}
+	}
+}
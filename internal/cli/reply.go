@@ -8,7 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/indrasvat/gh-ghent/internal/formatter"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
 )
 
 func newReplyCmd() *cobra.Command {
@@ -24,6 +24,14 @@ Supports markdown in the reply body.
 With --resolve, also resolves the thread after posting the reply.
 If the thread is already resolved, this is treated as success.
 
+With --idempotency-key, the key is embedded in the reply as a hidden HTML
+comment. If the thread already has a comment carrying the key, that
+comment is returned with already_posted set instead of posting again, so
+a retried reply is never duplicated. --idempotent derives the key from
+the body, so the same text is never posted twice to a thread. A reply
+without a key is not retried after a server error, since it may have
+been posted anyway.
+
 Exit codes: 0 = success, 1 = thread not found, 2 = error, 4 = reply posted but resolve failed.`,
 		Example: `  # Reply inline
   gh ghent reply --pr 42 --thread PRRT_abc123 --body "Fixed in latest commit"
@@ -37,13 +45,12 @@ Exit codes: 0 = success, 1 = thread not found, 2 = error, 4 = reply posted but r
   # Reply from stdin (pipe-friendly)
   echo "Acknowledged, will fix" | gh ghent reply --pr 42 --thread PRRT_abc123 --body-file -
 
+  # Safe to retry: the reply is posted at most once
+  gh ghent reply --pr 42 --thread PRRT_abc123 --body "Fixed" --idempotency-key fix-abc123
+
   # JSON confirmation
   gh ghent reply --pr 42 --thread PRRT_abc123 --body "Done" --format json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if Flags.PR == 0 {
-				return fmt.Errorf("--pr flag is required")
-			}
-
 			threadID, err := cmd.Flags().GetString("thread")
 			if err != nil {
 				return err
@@ -53,13 +60,17 @@ Exit codes: 0 = success, 1 = thread not found, 2 = error, 4 = reply posted but r
 			if err != nil {
 				return err
 			}
-
-			owner, repo, err := resolveRepo(Flags.Repo)
+			body, err = markIdempotent(cmd, body)
 			if err != nil {
 				return err
 			}
 
 			ctx := cmd.Context()
+			owner, repo, err := resolvePRTarget(ctx)
+			if err != nil {
+				return err
+			}
+
 			client := GitHubClient()
 
 			result, err := client.ReplyToThread(ctx, owner, repo, Flags.PR, threadID, body)
@@ -79,11 +90,11 @@ Exit codes: 0 = success, 1 = thread not found, 2 = error, 4 = reply posted but r
 			// on already-resolved threads, so no special "already resolved" handling needed.
 			resolve, _ := cmd.Flags().GetBool("resolve")
 			if resolve {
-				resolveResult, resolveErr := client.ResolveThread(ctx, threadID)
+				resolveResult, resolveErr := client.ResolveThread(ctx, owner, repo, Flags.PR, threadID)
 				if resolveErr != nil {
 					// Partial success: reply posted, resolve failed.
 					result.ResolveError = resolveErr.Error()
-					f, fmtErr := formatter.New(Flags.Format)
+					f, fmtErr := newFormatter()
 					if fmtErr != nil {
 						return fmtErr
 					}
@@ -94,7 +105,7 @@ Exit codes: 0 = success, 1 = thread not found, 2 = error, 4 = reply posted but r
 				result.Resolved = resolveResult
 			}
 
-			f, err := formatter.New(Flags.Format)
+			f, err := newFormatter()
 			if err != nil {
 				return err
 			}
@@ -111,11 +122,36 @@ Exit codes: 0 = success, 1 = thread not found, 2 = error, 4 = reply posted but r
 	cmd.Flags().String("body", "", "reply body text (supports markdown)")
 	cmd.Flags().String("body-file", "", "read reply body from file (use '-' for stdin)")
 	cmd.Flags().Bool("resolve", false, "resolve the thread after posting the reply")
+	cmd.Flags().String("idempotency-key", "", "post at most once per thread: skip if a reply with this key exists")
+	cmd.Flags().Bool("idempotent", false, "like --idempotency-key, with a key derived from the body")
 	_ = cmd.MarkFlagRequired("thread")
 
 	return cmd
 }
 
+// markIdempotent embeds the --idempotency-key, or with --idempotent a key
+// derived from the body, in the reply body.
+func markIdempotent(cmd *cobra.Command, body string) (string, error) {
+	key, err := cmd.Flags().GetString("idempotency-key")
+	if err != nil {
+		return "", err
+	}
+	idempotent, err := cmd.Flags().GetBool("idempotent")
+	if err != nil {
+		return "", err
+	}
+	if key != "" && idempotent {
+		return "", fmt.Errorf("--idempotency-key and --idempotent are mutually exclusive")
+	}
+	if idempotent {
+		key = ghub.ContentIdempotencyKey(body)
+	}
+	if key == "" {
+		return body, nil
+	}
+	return ghub.WithIdempotencyKey(body, key)
+}
+
 // resolveBody reads the reply body from --body or --body-file flags.
 // The two flags are mutually exclusive; at least one must be set.
 func resolveBody(cmd *cobra.Command) (string, error) {
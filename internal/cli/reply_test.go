@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
 )
 
 func newTestReplyCmd() *cobra.Command {
@@ -115,3 +117,40 @@ func TestResolveBody_StdinDash(t *testing.T) {
 		t.Errorf("body = %q, want %q", got, "stdin body content")
 	}
 }
+
+func TestMarkIdempotent(t *testing.T) {
+	newCmd := func(flags map[string]string) *cobra.Command {
+		cmd := newTestReplyCmd()
+		cmd.Flags().String("idempotency-key", "", "")
+		cmd.Flags().Bool("idempotent", false, "")
+		for name, value := range flags {
+			_ = cmd.Flags().Set(name, value)
+		}
+		return cmd
+	}
+
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantKey string
+		wantErr bool
+	}{
+		{name: "no key", flags: nil},
+		{name: "explicit key", flags: map[string]string{"idempotency-key": "fix-1"}, wantKey: "fix-1"},
+		{name: "content key", flags: map[string]string{"idempotent": "true"}, wantKey: ghub.ContentIdempotencyKey("Fixed")},
+		{name: "invalid key", flags: map[string]string{"idempotency-key": "a b"}, wantErr: true},
+		{name: "both", flags: map[string]string{"idempotency-key": "fix-1", "idempotent": "true"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := markIdempotent(newCmd(tt.flags), "Fixed")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("markIdempotent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if key := ghub.IdempotencyKey(got); key != tt.wantKey {
+				t.Errorf("key = %q, want %q", key, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+	"github.com/indrasvat/gh-ghent/internal/tui"
+)
+
+// rerunStartPoll and rerunStartTimeout bound how --watch waits for GitHub
+// to queue the new attempt; until then the check runs still show the
+// previous result.
+const (
+	rerunStartPoll    = 2 * time.Second
+	rerunStartTimeout = 2 * time.Minute
+)
+
+func newRerunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rerun [check]",
+		Short: "Re-run failed CI jobs or cancel running workflows",
+		Long: `Re-run GitHub Actions jobs behind a pull request's checks via the REST API.
+
+Without arguments, the failed jobs of every workflow run with a failing
+check are re-run (one request per run). Name a check (or give its check
+run ID) to re-run only that job. With --cancel, the workflow runs that are
+still queued or in progress are cancelled instead, optionally narrowed to
+the run of one check. With --flaky, only the failed jobs whose check
+history marks them flaky are re-run, one job at a time, so real failures
+in the same run are left alone.
+
+Each run or job is attempted independently; failures are reported per
+entry instead of stopping at the first one. Commit statuses from external
+CI cannot be re-run. Use --watch to wait for the new attempt to start and
+follow it until every check completes; watch progress goes to stderr.
+
+Exit codes: 0 = all success, 1 = partial failure, 2 = total failure.
+With --watch, the watched checks decide: 0 = pass, 1 = failure.`,
+		Example: `  # Re-run the failed jobs of every failing workflow run
+  gh ghent rerun --pr 42
+
+  # Re-run one job and follow it
+  gh ghent rerun "test (ubuntu-latest)" --pr 42 --watch
+
+  # Retry only the failures that look flaky
+  gh ghent rerun --pr 42 --flaky
+
+  # Cancel in-progress runs
+  gh ghent rerun --pr 42 --cancel
+
+  # Preview what would be re-run
+  gh ghent rerun --pr 42 --dry-run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runRerun,
+	}
+
+	cmd.Flags().Bool("cancel", false, "cancel queued and in-progress workflow runs instead of re-running")
+	cmd.Flags().Bool("watch", false, "after re-running, poll until all checks complete")
+	cmd.Flags().Bool("dry-run", false, "show what would be re-run or cancelled without executing")
+	cmd.Flags().Bool("flaky", false, "only re-run failed jobs whose check history marks them flaky")
+	cmd.Flags().Int("flaky-commits", ghub.DefaultFlakyCommits, "how many recent commits --flaky looks back over")
+
+	return cmd
+}
+
+type rerunClient interface {
+	domain.CheckFetcher
+	domain.CheckHistoryFetcher
+	domain.WorkflowRunner
+}
+
+// rerunOptions holds the parsed rerun command flags.
+type rerunOptions struct {
+	check        string
+	cancel       bool
+	dryRun       bool
+	flakyOnly    bool
+	flakyCommits int
+}
+
+func runRerun(cmd *cobra.Command, args []string) error {
+	opts := rerunOptions{}
+	if len(args) == 1 {
+		opts.check = args[0]
+	}
+	f := cmd.Flags()
+	var err error
+	if opts.cancel, err = f.GetBool("cancel"); err != nil {
+		return err
+	}
+	watch, err := f.GetBool("watch")
+	if err != nil {
+		return err
+	}
+	if opts.dryRun, err = f.GetBool("dry-run"); err != nil {
+		return err
+	}
+	if opts.flakyOnly, err = f.GetBool("flaky"); err != nil {
+		return err
+	}
+	if opts.flakyCommits, err = f.GetInt("flaky-commits"); err != nil {
+		return err
+	}
+	if watch && (opts.cancel || opts.dryRun) {
+		return fmt.Errorf("--watch cannot be combined with --cancel or --dry-run")
+	}
+	if opts.flakyOnly && (opts.cancel || opts.check != "") {
+		return fmt.Errorf("--flaky cannot be combined with --cancel or a check name")
+	}
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	client := GitHubClient()
+	checks, err := client.FetchChecks(ctx, owner, repo, Flags.PR)
+	if err != nil {
+		return fmt.Errorf("fetch checks: %w", err)
+	}
+	results, err := buildRerunResults(ctx, client, owner, repo, Flags.PR, checks.Checks, opts)
+	if err != nil {
+		return err
+	}
+
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatRerunResults(os.Stdout, results); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+
+	if !watch || results.SuccessCount == 0 {
+		if exitCode := rerunExitCode(results); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	}
+
+	if err := awaitRerunStart(ctx, client, owner, repo, Flags.PR, checks.Checks, results, rerunStartPoll, rerunStartTimeout); err != nil {
+		return err
+	}
+	if Flags.IsTTY {
+		fetchFn := func() (*domain.ChecksResult, error) {
+			return client.FetchChecks(ctx, owner, repo, Flags.PR)
+		}
+		return launchTUI(tui.ViewWatch,
+			withRepo(owner+"/"+repo), withPR(Flags.PR),
+			withWatchFetch(fetchFn, ghub.DefaultPollInterval),
+		)
+	}
+	// Watch progress goes to stderr so stdout stays the rerun results alone.
+	finalStatus, err := client.WatchChecks(
+		ctx, os.Stderr, formatter,
+		owner, repo, Flags.PR,
+		ghub.DefaultPollInterval, nil,
+		true, // wait for every re-run job, not just the first failure
+	)
+	if err != nil {
+		return fmt.Errorf("watch checks: %w", err)
+	}
+	if finalStatus != domain.StatusPass {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// rerunTarget is one workflow run (or single job) to act on.
+type rerunTarget struct {
+	runID  int64
+	jobID  int64
+	checks []string
+	err    error // run ID could not be resolved
+}
+
+// buildRerunResults re-runs or cancels the jobs behind checks, the PR's
+// current check runs.
+func buildRerunResults(
+	ctx context.Context,
+	client rerunClient,
+	owner, repo string,
+	pr int,
+	checks []domain.CheckRun,
+	opts rerunOptions,
+) (*domain.RerunResults, error) {
+	action := domain.RerunActionRerunFailed
+	var selected []domain.CheckRun
+	var err error
+	switch {
+	case opts.check != "":
+		ch, err := selectJobCheck(checks, opts.check)
+		if err != nil {
+			return nil, err
+		}
+		selected = []domain.CheckRun{ch}
+		if !opts.cancel {
+			action = domain.RerunActionRerunJob
+		}
+	case opts.cancel:
+		for _, ch := range checks {
+			if ch.Status != "completed" && !ch.IsCommitStatus() {
+				selected = append(selected, ch)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no queued or in-progress workflow runs to cancel")
+		}
+	default:
+		for _, ch := range checks {
+			if domain.IsFailConclusion(ch.Conclusion) && !ch.IsCommitStatus() {
+				selected = append(selected, ch)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no failed Actions checks to re-run")
+		}
+		if opts.flakyOnly {
+			if selected, err = selectFlakyChecks(ctx, client, owner, repo, pr, opts.flakyCommits, selected); err != nil {
+				return nil, err
+			}
+			// rerun-failed-jobs would also retry the run's real failures.
+			action = domain.RerunActionRerunJob
+		}
+	}
+	if opts.cancel {
+		action = domain.RerunActionCancel
+	}
+
+	results := &domain.RerunResults{
+		PRNumber: pr,
+		Results:  []domain.RerunResult{},
+		DryRun:   opts.dryRun,
+	}
+	for _, t := range rerunTargets(ctx, client, owner, repo, selected, action == domain.RerunActionRerunJob) {
+		r := domain.RerunResult{RunID: t.runID, JobID: t.jobID, Checks: t.checks, Action: action}
+		switch {
+		case t.err != nil:
+			r.Error = t.err.Error()
+		case opts.dryRun:
+			r.Action = "would_" + action
+		default:
+			var err error
+			switch action {
+			case domain.RerunActionRerunJob:
+				err = client.RerunJob(ctx, owner, repo, t.jobID)
+			case domain.RerunActionCancel:
+				err = client.CancelRun(ctx, owner, repo, t.runID)
+			default:
+				err = client.RerunFailedJobs(ctx, owner, repo, t.runID)
+			}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Success = true
+			}
+		}
+		if r.Error != "" {
+			results.FailureCount++
+		} else {
+			results.SuccessCount++
+		}
+		results.Results = append(results.Results, r)
+	}
+	return results, nil
+}
+
+// awaitRerunStart polls until every check re-run successfully shows its new
+// attempt: a check run ID not in before, or a status other than completed.
+// Watching any earlier would report the previous attempt's result.
+func awaitRerunStart(
+	ctx context.Context,
+	client domain.CheckFetcher,
+	owner, repo string,
+	pr int,
+	before []domain.CheckRun,
+	results *domain.RerunResults,
+	interval, timeout time.Duration,
+) error {
+	rerun := make(map[string]bool)
+	for _, r := range results.Results {
+		if r.Success {
+			for _, name := range r.Checks {
+				rerun[name] = true
+			}
+		}
+	}
+	oldIDs := make(map[int64]bool)
+	for _, ch := range before {
+		if rerun[ch.Name] {
+			oldIDs[ch.ID] = true
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		checks, err := client.FetchChecks(ctx, owner, repo, pr)
+		if err != nil {
+			return fmt.Errorf("fetch checks: %w", err)
+		}
+		waiting := make(map[string]bool, len(rerun))
+		for name := range rerun {
+			waiting[name] = true
+		}
+		for _, ch := range checks.Checks {
+			if waiting[ch.Name] && (!oldIDs[ch.ID] || ch.Status != "completed") {
+				delete(waiting, ch.Name)
+			}
+		}
+		if len(waiting) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			names := make([]string, 0, len(waiting))
+			for name := range waiting {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("re-run of %s did not start within %s; watch with gh ghent checks --watch", strings.Join(names, ", "), timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// selectFlakyChecks keeps the failed checks whose history over the PR's last
+// commits marks them flaky.
+func selectFlakyChecks(
+	ctx context.Context,
+	client domain.CheckHistoryFetcher,
+	owner, repo string,
+	pr, commits int,
+	failed []domain.CheckRun,
+) ([]domain.CheckRun, error) {
+	history, err := client.FetchCheckHistory(ctx, owner, repo, pr, commits)
+	if err != nil {
+		return nil, fmt.Errorf("fetch check history: %w", err)
+	}
+	marked := &domain.ChecksResult{Checks: failed}
+	if ghub.MarkFlakyChecks(marked, history) == 0 {
+		return nil, fmt.Errorf("none of the %d failed checks looks flaky over the last %d commits", len(failed), commits)
+	}
+	var flaky []domain.CheckRun
+	for _, ch := range marked.Checks {
+		if ch.Flaky != nil {
+			flaky = append(flaky, ch)
+		}
+	}
+	return flaky, nil
+}
+
+// rerunTargets groups checks by workflow run, or keeps one target per job
+// when perJob is set. The run ID comes from the check's Actions URL, falling
+// back to the jobs API.
+func rerunTargets(ctx context.Context, client rerunClient, owner, repo string, checks []domain.CheckRun, perJob bool) []rerunTarget {
+	var targets []rerunTarget
+	byRun := make(map[int64]int)
+	for _, ch := range checks {
+		runID := ghub.RunIDFromURL(ch.HTMLURL)
+		var err error
+		if runID == 0 {
+			if runID, err = client.FetchJobRunID(ctx, owner, repo, ch.ID); err != nil {
+				err = fmt.Errorf("resolve workflow run of %s: %w", ch.Name, err)
+			}
+		}
+		if perJob || err != nil {
+			targets = append(targets, rerunTarget{runID: runID, jobID: ch.ID, checks: []string{ch.Name}, err: err})
+			continue
+		}
+		if i, ok := byRun[runID]; ok {
+			targets[i].checks = append(targets[i].checks, ch.Name)
+			continue
+		}
+		byRun[runID] = len(targets)
+		targets = append(targets, rerunTarget{runID: runID, checks: []string{ch.Name}})
+	}
+	return targets
+}
+
+func rerunExitCode(results *domain.RerunResults) int {
+	if results.FailureCount > 0 && results.SuccessCount > 0 {
+		return 1
+	}
+	if results.FailureCount > 0 {
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+type stubRerunClient struct {
+	checks    []domain.CheckRun
+	history   []domain.CommitCheckRuns
+	runIDs    map[int64]int64 // job ID → run ID for FetchJobRunID
+	errs      map[int64]error // keyed by run or job ID
+	calls     []string
+	callIDs   []int64
+	fetchRuns []int64
+}
+
+func (s *stubRerunClient) FetchChecks(_ context.Context, _, _ string, pr int) (*domain.ChecksResult, error) {
+	return &domain.ChecksResult{PRNumber: pr, Checks: s.checks}, nil
+}
+
+func (s *stubRerunClient) FetchCheckHistory(context.Context, string, string, int, int) ([]domain.CommitCheckRuns, error) {
+	return s.history, nil
+}
+
+func (s *stubRerunClient) record(action string, id int64) error {
+	s.calls = append(s.calls, action)
+	s.callIDs = append(s.callIDs, id)
+	return s.errs[id]
+}
+
+func (s *stubRerunClient) RerunFailedJobs(_ context.Context, _, _ string, runID int64) error {
+	return s.record(domain.RerunActionRerunFailed, runID)
+}
+
+func (s *stubRerunClient) RerunJob(_ context.Context, _, _ string, jobID int64) error {
+	return s.record(domain.RerunActionRerunJob, jobID)
+}
+
+func (s *stubRerunClient) CancelRun(_ context.Context, _, _ string, runID int64) error {
+	return s.record(domain.RerunActionCancel, runID)
+}
+
+func (s *stubRerunClient) FetchJobRunID(_ context.Context, _, _ string, jobID int64) (int64, error) {
+	s.fetchRuns = append(s.fetchRuns, jobID)
+	if id, ok := s.runIDs[jobID]; ok {
+		return id, nil
+	}
+	return 0, errors.New("not found")
+}
+
+func rerunFixture() []domain.CheckRun {
+	return []domain.CheckRun{
+		{ID: 11, Name: "lint", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/o/r/actions/runs/100/job/11"},
+		{ID: 12, Name: "test (ubuntu)", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/o/r/actions/runs/100/job/12"},
+		{ID: 13, Name: "build", Status: "completed", Conclusion: "success", HTMLURL: "https://github.com/o/r/actions/runs/100/job/13"},
+		{ID: 21, Name: "e2e", Status: "completed", Conclusion: "timed_out", HTMLURL: "https://github.com/o/r/runs/21"},
+		{ID: 31, Name: "deploy", Status: "in_progress", HTMLURL: "https://github.com/o/r/actions/runs/300/job/31"},
+		{ID: 41, Name: "ci/jenkins", Kind: domain.CheckKindStatus, Status: "completed", Conclusion: "failure", HTMLURL: "https://ci.example.com/1"},
+	}
+}
+
+func TestBuildRerunResultsGroupsFailedJobsByRun(t *testing.T) {
+	client := &stubRerunClient{checks: rerunFixture(), runIDs: map[int64]int64{21: 200}}
+
+	results, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{})
+	if err != nil {
+		t.Fatalf("buildRerunResults: %v", err)
+	}
+
+	want := &domain.RerunResults{
+		PRNumber: 42,
+		Results: []domain.RerunResult{
+			{RunID: 100, Checks: []string{"lint", "test (ubuntu)"}, Action: domain.RerunActionRerunFailed, Success: true},
+			{RunID: 200, Checks: []string{"e2e"}, Action: domain.RerunActionRerunFailed, Success: true},
+		},
+		SuccessCount: 2,
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int64{21}, client.fetchRuns); diff != "" {
+		t.Errorf("FetchJobRunID calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildRerunResultsSingleJob(t *testing.T) {
+	client := &stubRerunClient{checks: rerunFixture()}
+
+	results, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{check: "Test (Ubuntu)"})
+	if err != nil {
+		t.Fatalf("buildRerunResults: %v", err)
+	}
+
+	want := []domain.RerunResult{
+		{RunID: 100, JobID: 12, Checks: []string{"test (ubuntu)"}, Action: domain.RerunActionRerunJob, Success: true},
+	}
+	if diff := cmp.Diff(want, results.Results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int64{12}, client.callIDs); diff != "" {
+		t.Errorf("RerunJob calls mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{check: "ci/jenkins"}); err == nil {
+		t.Error("commit status: want an error")
+	}
+}
+
+func TestBuildRerunResultsCancel(t *testing.T) {
+	client := &stubRerunClient{checks: rerunFixture()}
+
+	results, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{cancel: true})
+	if err != nil {
+		t.Fatalf("buildRerunResults: %v", err)
+	}
+
+	want := []domain.RerunResult{
+		{RunID: 300, Checks: []string{"deploy"}, Action: domain.RerunActionCancel, Success: true},
+	}
+	if diff := cmp.Diff(want, results.Results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{domain.RerunActionCancel}, client.calls); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildRerunResultsDryRun(t *testing.T) {
+	client := &stubRerunClient{checks: rerunFixture(), runIDs: map[int64]int64{21: 200}}
+
+	results, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{dryRun: true})
+	if err != nil {
+		t.Fatalf("buildRerunResults: %v", err)
+	}
+	if len(client.calls) != 0 {
+		t.Fatalf("dry run made %d API calls, want 0", len(client.calls))
+	}
+	if !results.DryRun || results.SuccessCount != 2 {
+		t.Fatalf("results = %+v, want dry run with 2 successes", results)
+	}
+	for _, r := range results.Results {
+		if r.Action != "would_"+domain.RerunActionRerunFailed || r.Success {
+			t.Errorf("dry-run result = %+v, want would_rerun_failed without success", r)
+		}
+	}
+}
+
+func TestBuildRerunResultsPartialFailure(t *testing.T) {
+	client := &stubRerunClient{
+		checks: rerunFixture(),
+		errs:   map[int64]error{100: errors.New("run is still in progress")},
+	}
+
+	results, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{})
+	if err != nil {
+		t.Fatalf("buildRerunResults: %v", err)
+	}
+
+	want := &domain.RerunResults{
+		PRNumber: 42,
+		Results: []domain.RerunResult{
+			{RunID: 100, Checks: []string{"lint", "test (ubuntu)"}, Action: domain.RerunActionRerunFailed, Error: "run is still in progress"},
+			{JobID: 21, Checks: []string{"e2e"}, Action: domain.RerunActionRerunFailed, Error: "resolve workflow run of e2e: not found"},
+		},
+		FailureCount: 2,
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+	if got := rerunExitCode(results); got != 2 {
+		t.Errorf("exit code = %d, want 2", got)
+	}
+	if got := rerunExitCode(&domain.RerunResults{SuccessCount: 1, FailureCount: 1}); got != 1 {
+		t.Errorf("partial exit code = %d, want 1", got)
+	}
+}
+
+func TestBuildRerunResultsFlakyOnly(t *testing.T) {
+	client := &stubRerunClient{
+		checks: rerunFixture(),
+		history: []domain.CommitCheckRuns{{
+			SHA:     "abc",
+			TreeSHA: "tree",
+			Checks: []domain.CheckRun{
+				{ID: 9, Name: "test (ubuntu)", Status: "completed", Conclusion: "success"},
+				{ID: 12, Name: "test (ubuntu)", Status: "completed", Conclusion: "failure"},
+				{ID: 11, Name: "lint", Status: "completed", Conclusion: "failure"},
+			},
+		}},
+	}
+
+	results, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{flakyOnly: true, flakyCommits: 10})
+	if err != nil {
+		t.Fatalf("buildRerunResults: %v", err)
+	}
+
+	want := []domain.RerunResult{
+		{RunID: 100, JobID: 12, Checks: []string{"test (ubuntu)"}, Action: domain.RerunActionRerunJob, Success: true},
+	}
+	if diff := cmp.Diff(want, results.Results); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+
+	client.history = nil
+	if _, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{flakyOnly: true}); err == nil {
+		t.Error("no flaky checks: want an error")
+	}
+}
+
+func TestBuildRerunResultsNothingToDo(t *testing.T) {
+	client := &stubRerunClient{checks: []domain.CheckRun{
+		{ID: 1, Name: "build", Status: "completed", Conclusion: "success", HTMLURL: "https://github.com/o/r/actions/runs/1/job/1"},
+	}}
+
+	if _, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{}); err == nil {
+		t.Error("no failed checks: want an error")
+	}
+	if _, err := buildRerunResults(context.Background(), client, "o", "r", 42, client.checks, rerunOptions{cancel: true}); err == nil {
+		t.Error("no running checks: want an error")
+	}
+}
+
+// sequenceChecksClient returns each fetch's checks in turn, repeating the last.
+type sequenceChecksClient struct {
+	fetches [][]domain.CheckRun
+	calls   int
+}
+
+func (s *sequenceChecksClient) FetchChecks(_ context.Context, _, _ string, pr int) (*domain.ChecksResult, error) {
+	i := min(s.calls, len(s.fetches)-1)
+	s.calls++
+	return &domain.ChecksResult{PRNumber: pr, Checks: s.fetches[i]}, nil
+}
+
+func TestAwaitRerunStart(t *testing.T) {
+	before := []domain.CheckRun{
+		{ID: 11, Name: "lint", Status: "completed", Conclusion: "failure"},
+		{ID: 12, Name: "test", Status: "completed", Conclusion: "failure"},
+		{ID: 13, Name: "build", Status: "completed", Conclusion: "success"},
+	}
+	results := &domain.RerunResults{Results: []domain.RerunResult{
+		{RunID: 100, Checks: []string{"lint", "test"}, Success: true},
+		{RunID: 200, Checks: []string{"e2e"}, Error: "forbidden"},
+	}}
+
+	tests := []struct {
+		name      string
+		fetches   [][]domain.CheckRun
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name: "new attempt IDs",
+			fetches: [][]domain.CheckRun{
+				before,
+				{{ID: 14, Name: "lint", Status: "queued"}, {ID: 15, Name: "test", Status: "completed", Conclusion: "failure"}, before[2]},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "one started, then the other",
+			fetches: [][]domain.CheckRun{
+				{{ID: 14, Name: "lint", Status: "queued"}, before[1], before[2]},
+				{{ID: 14, Name: "lint", Status: "in_progress"}, {ID: 12, Name: "test", Status: "queued"}, before[2]},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:    "never started",
+			fetches: [][]domain.CheckRun{before},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &sequenceChecksClient{fetches: tt.fetches}
+			err := awaitRerunStart(context.Background(), client, "o", "r", 42, before, results, 10*time.Millisecond, 25*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("awaitRerunStart error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "lint, test") {
+				t.Errorf("error = %v, want it to name lint and test", err)
+			}
+			if !tt.wantErr && client.calls != tt.wantCalls {
+				t.Errorf("fetched checks %d times, want %d", client.calls, tt.wantCalls)
+			}
+		})
+	}
+}
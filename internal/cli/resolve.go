@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/indrasvat/gh-ghent/internal/domain"
-	"github.com/indrasvat/gh-ghent/internal/formatter"
 	"github.com/indrasvat/gh-ghent/internal/github"
 	"github.com/indrasvat/gh-ghent/internal/tui"
 )
@@ -22,7 +21,9 @@ func newResolveCmd() *cobra.Command {
 
 Use --thread to resolve a single thread by ID, or --all to resolve
 every unresolved thread in bulk. Use --file and --author to batch
-resolve threads matching a glob pattern or author login.
+resolve threads matching a glob pattern or author login, and
+--addressed to batch resolve threads whose commented code has changed
+at the PR head since the comment (likely addressed by a later push).
 Add --unresolve to reverse the operation.
 
 Use --dry-run to preview what would be resolved without executing.
@@ -43,6 +44,9 @@ Exit codes: 0 = all success, 1 = partial failure, 2 = total failure.`,
   # Batch resolve by author
   gh ghent resolve --pr 42 --author reviewer1
 
+  # Preview threads whose code changed since the comment
+  gh ghent resolve --pr 42 --addressed --dry-run
+
   # Combined filters (intersection)
   gh ghent resolve --pr 42 --file "*.go" --author reviewer1
 
@@ -62,16 +66,13 @@ Exit codes: 0 = all success, 1 = partial failure, 2 = total failure.`,
 	cmd.Flags().Bool("unresolve", false, "unresolve instead of resolve")
 	cmd.Flags().String("file", "", "resolve threads in files matching glob (e.g., 'internal/api/*.go')")
 	cmd.Flags().String("author", "", "resolve threads started by a specific author")
+	cmd.Flags().Bool("addressed", false, "resolve threads whose commented code changed at the PR head since the comment")
 	cmd.Flags().Bool("dry-run", false, "show what would be resolved without executing")
 
 	return cmd
 }
 
 func runResolve(cmd *cobra.Command, _ []string) error {
-	if Flags.PR == 0 {
-		return fmt.Errorf("--pr flag is required")
-	}
-
 	threadID, err := cmd.Flags().GetString("thread")
 	if err != nil {
 		return err
@@ -96,24 +97,32 @@ func runResolve(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	addressed, err := cmd.Flags().GetBool("addressed")
+	if err != nil {
+		return err
+	}
 
-	hasBatchFilter := fileGlob != "" || author != ""
+	hasBatchFilter := fileGlob != "" || author != "" || addressed
 
 	// Validate flag combinations.
 	if threadID != "" && hasBatchFilter {
-		return fmt.Errorf("--thread cannot be combined with --file or --author")
+		return fmt.Errorf("--thread cannot be combined with --file, --author, or --addressed")
+	}
+	if addressed && unresolve {
+		return fmt.Errorf("--addressed cannot be combined with --unresolve")
 	}
 	if dryRun && !hasBatchFilter && !all {
-		return fmt.Errorf("--dry-run requires --file, --author, or --all")
+		return fmt.Errorf("--dry-run requires --file, --author, --addressed, or --all")
+	}
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
 	}
 
 	// TTY without explicit flags → launch interactive resolve TUI.
 	if Flags.IsTTY && threadID == "" && !all && !hasBatchFilter {
-		owner, repo, repoErr := resolveRepo(Flags.Repo)
-		if repoErr != nil {
-			return repoErr
-		}
-		ctx := cmd.Context()
 		client := GitHubClient()
 		threads, fetchErr := client.FetchThreads(ctx, owner, repo, Flags.PR)
 		if fetchErr != nil {
@@ -121,7 +130,7 @@ func runResolve(cmd *cobra.Command, _ []string) error {
 		}
 		repoStr := owner + "/" + repo
 		resolverFn := func(threadID string) error {
-			_, err := client.ResolveThread(ctx, threadID)
+			_, err := client.ResolveThread(ctx, owner, repo, Flags.PR, threadID)
 			return err
 		}
 		return launchTUI(tui.ViewResolve,
@@ -132,24 +141,23 @@ func runResolve(cmd *cobra.Command, _ []string) error {
 	}
 
 	if threadID == "" && !all && !hasBatchFilter {
-		return fmt.Errorf("either --thread, --all, --file, or --author is required")
+		return fmt.Errorf("either --thread, --all, --file, --author, or --addressed is required")
 	}
 	if threadID != "" && all {
 		return fmt.Errorf("--thread and --all are mutually exclusive")
 	}
 
-	f, err := formatter.New(Flags.Format)
+	f, err := newFormatter()
 	if err != nil {
 		return err
 	}
 
-	ctx := cmd.Context()
 	client := GitHubClient()
 
 	var results *domain.ResolveResults
 	switch {
 	case hasBatchFilter:
-		results, err = resolveBatch(ctx, client, fileGlob, author, unresolve, dryRun)
+		results, err = resolveBatch(ctx, client, batchFilter{fileGlob: fileGlob, author: author, addressed: addressed}, unresolve, dryRun)
 	case all:
 		if dryRun {
 			results, err = resolveBatchAll(ctx, client, unresolve)
@@ -157,7 +165,7 @@ func runResolve(cmd *cobra.Command, _ []string) error {
 			results, err = resolveAll(ctx, client, unresolve)
 		}
 	default:
-		results, err = resolveSingle(ctx, client, threadID, unresolve)
+		results, err = resolveSingle(ctx, client, owner, repo, threadID, unresolve)
 	}
 	if err != nil {
 		return err
@@ -178,8 +186,8 @@ func runResolve(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func resolveSingle(ctx context.Context, client *github.Client, threadID string, unresolve bool) (*domain.ResolveResults, error) {
-	result, msg := doResolve(ctx, client, threadID, unresolve)
+func resolveSingle(ctx context.Context, client *github.Client, owner, repo, threadID string, unresolve bool) (*domain.ResolveResults, error) {
+	result, msg := doResolve(ctx, client, owner, repo, Flags.PR, threadID, unresolve)
 	if msg != "" {
 		return &domain.ResolveResults{
 			FailureCount: 1,
@@ -197,14 +205,14 @@ func resolveSingle(ctx context.Context, client *github.Client, threadID string,
 
 // doResolve executes a single resolve/unresolve mutation, returning the result
 // or an error message string.
-func doResolve(ctx context.Context, client *github.Client, threadID string, unresolve bool) (*domain.ResolveResult, string) {
+func doResolve(ctx context.Context, client domain.ThreadResolver, owner, repo string, pr int, threadID string, unresolve bool) (*domain.ResolveResult, string) {
 	var result *domain.ResolveResult
 	var err error
 
 	if unresolve {
-		result, err = client.UnresolveThread(ctx, threadID)
+		result, err = client.UnresolveThread(ctx, owner, repo, pr, threadID)
 	} else {
-		result, err = client.ResolveThread(ctx, threadID)
+		result, err = client.ResolveThread(ctx, owner, repo, pr, threadID)
 	}
 	if err != nil {
 		return nil, err.Error()
@@ -229,9 +237,22 @@ func matchesFilters(t domain.ReviewThread, fileGlob, author string) bool {
 	return true
 }
 
-// resolveBatch fetches threads and applies --file/--author filters before
+// batchFilter holds the --file, --author, and --addressed selectors.
+type batchFilter struct {
+	fileGlob  string
+	author    string
+	addressed bool
+}
+
+// isAddressed reports whether code change analysis found the thread's
+// commented lines changed at the PR head.
+func isAddressed(t domain.ReviewThread) bool {
+	return t.CodeChangedSinceComment != nil && *t.CodeChangedSinceComment
+}
+
+// resolveBatch fetches threads and applies the batch filters before
 // resolving. Supports --dry-run to preview without executing.
-func resolveBatch(ctx context.Context, client *github.Client, fileGlob, author string, unresolve, dryRun bool) (*domain.ResolveResults, error) {
+func resolveBatch(ctx context.Context, client *github.Client, filter batchFilter, unresolve, dryRun bool) (*domain.ResolveResults, error) {
 	owner, repo, err := resolveRepo(Flags.Repo)
 	if err != nil {
 		return nil, err
@@ -246,6 +267,11 @@ func resolveBatch(ctx context.Context, client *github.Client, fileGlob, author s
 	if err != nil {
 		return nil, fmt.Errorf("fetch threads: %w", err)
 	}
+	if filter.addressed {
+		if err := client.MarkCodeChanges(ctx, owner, repo, Flags.PR, threads); err != nil {
+			return nil, fmt.Errorf("compare threads with the PR head: %w", err)
+		}
+	}
 
 	action := "resolved"
 	wouldAction := "would_resolve"
@@ -257,7 +283,7 @@ func resolveBatch(ctx context.Context, client *github.Client, fileGlob, author s
 	results := &domain.ResolveResults{DryRun: dryRun}
 
 	for _, t := range threads.Threads {
-		if !matchesFilters(t, fileGlob, author) {
+		if !matchesFilters(t, filter.fileGlob, filter.author) || (filter.addressed && !isAddressed(t)) {
 			continue
 		}
 
@@ -290,7 +316,7 @@ func resolveBatch(ctx context.Context, client *github.Client, fileGlob, author s
 			continue
 		}
 
-		result, msg := doResolve(ctx, client, t.ID, unresolve)
+		result, msg := doResolve(ctx, client, owner, repo, Flags.PR, t.ID, unresolve)
 		if msg != "" {
 			results.FailureCount++
 			results.Errors = append(results.Errors, domain.ResolveError{
@@ -310,7 +336,7 @@ func resolveBatch(ctx context.Context, client *github.Client, fileGlob, author s
 
 // resolveBatchAll is --all --dry-run: preview all threads without executing.
 func resolveBatchAll(ctx context.Context, client *github.Client, unresolve bool) (*domain.ResolveResults, error) {
-	return resolveBatch(ctx, client, "", "", unresolve, true)
+	return resolveBatch(ctx, client, batchFilter{}, unresolve, true)
 }
 
 func resolveAll(ctx context.Context, client *github.Client, unresolve bool) (*domain.ResolveResults, error) {
@@ -350,7 +376,7 @@ func resolveAll(ctx context.Context, client *github.Client, unresolve bool) (*do
 			continue
 		}
 
-		result, msg := doResolve(ctx, client, t.ID, unresolve)
+		result, msg := doResolve(ctx, client, owner, repo, Flags.PR, t.ID, unresolve)
 		if msg != "" {
 			results.FailureCount++
 			results.Errors = append(results.Errors, domain.ResolveError{
@@ -9,6 +9,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/indrasvat/gh-ghent/internal/debug"
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	"github.com/indrasvat/gh-ghent/internal/formatter"
 	"github.com/indrasvat/gh-ghent/internal/github"
 	"github.com/indrasvat/gh-ghent/internal/version"
 )
@@ -24,6 +26,27 @@ func GitHubClient() *github.Client {
 	return ghClient
 }
 
+// newFormatter returns the pipe-mode formatter for this invocation: a jq or
+// template transform of the JSON output when --jq/--template is set,
+// otherwise the --format formatter.
+func newFormatter() (domain.Formatter, error) {
+	if Flags.JQ != "" || Flags.Template != "" {
+		return formatter.NewTransform(Flags.JQ, Flags.Template)
+	}
+	return formatter.New(Flags.Format)
+}
+
+// locationsAnnotation marks commands whose output has file locations and so
+// supports the location-only formats.
+const locationsAnnotation = "ghent:locations"
+
+// locationFormats only render file-located feedback (threads, annotations).
+var locationFormats = map[string]bool{"quickfix": true, "sarif": true}
+
+// junitAnnotation marks commands that report check runs and so support
+// --format junit.
+const junitAnnotation = "ghent:junit"
+
 // NewRootCmd creates the root ghent command.
 func NewRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -68,10 +91,16 @@ for AI agents. Works wherever gh is authenticated — zero config.
 			if err != nil {
 				return err
 			}
-			Flags.PR, err = f.GetInt("pr")
+			Flags.PRRef, err = f.GetString("pr")
+			if err != nil {
+				return err
+			}
+			// Validate --pr syntax up front; branch lookups happen per command.
+			ref, err := parsePRRef(Flags.PRRef)
 			if err != nil {
 				return err
 			}
+			Flags.PR = ref.Number
 			Flags.Debug, err = f.GetBool("debug")
 			if err != nil {
 				return err
@@ -85,6 +114,35 @@ for AI agents. Works wherever gh is authenticated — zero config.
 				Flags.Solo = true
 			}
 
+			Flags.NoCache, err = f.GetBool("no-cache")
+			if err != nil {
+				return err
+			}
+			Flags.JQ, err = f.GetString("jq")
+			if err != nil {
+				return err
+			}
+			Flags.Template, err = f.GetString("template")
+			if err != nil {
+				return err
+			}
+			if Flags.JQ != "" || Flags.Template != "" {
+				if f.Changed("format") && Flags.Format != "json" {
+					return fmt.Errorf("--jq and --template operate on JSON output; drop --format %s", Flags.Format)
+				}
+				// Validate the expression before any API call.
+				if _, err := formatter.NewTransform(Flags.JQ, Flags.Template); err != nil {
+					return err
+				}
+			}
+
+			if locationFormats[Flags.Format] && cmd.Annotations[locationsAnnotation] == "" {
+				return fmt.Errorf("--format %s is only supported by comments, checks, and status", Flags.Format)
+			}
+			if Flags.Format == "junit" && cmd.Annotations[junitAnnotation] == "" {
+				return fmt.Errorf("--format junit is only supported by checks")
+			}
+
 			sinceStr, err := f.GetString("since")
 			if err != nil {
 				return err
@@ -101,13 +159,18 @@ for AI agents. Works wherever gh is authenticated — zero config.
 
 			// TTY detection via go-gh
 			Flags.IsTTY = term.FromEnv().IsTerminalOutput()
-			if Flags.NoTUI {
+			if Flags.NoTUI || Flags.JQ != "" || Flags.Template != "" || locationFormats[Flags.Format] || Flags.Format == "junit" {
 				Flags.IsTTY = false
 			}
 
 			// Only initialize GitHub client for subcommands (not root help/version)
-			if cmd.Name() != "ghent" {
-				ghClient, err = github.New()
+			// that talk to GitHub.
+			if cmd.Name() != "ghent" && cmd.Annotations[offlineAnnotation] == "" {
+				opts := []github.Option{github.WithAuditLog(github.DefaultAuditLogPath(), commandLine(os.Args))}
+				if !Flags.NoCache {
+					opts = append(opts, github.WithCache(github.DefaultCacheDir()))
+				}
+				ghClient, err = github.New(opts...)
 				if err != nil {
 					return fmt.Errorf("github client: %w", err)
 				}
@@ -124,12 +187,16 @@ for AI agents. Works wherever gh is authenticated — zero config.
 
 	// Global persistent flags
 	cmd.PersistentFlags().StringP("repo", "R", "", "repository in OWNER/REPO format (default: current repo)")
-	cmd.PersistentFlags().StringP("format", "f", "json", "output format: json, md, xml (pipe mode)")
+	cmd.PersistentFlags().StringP("format", "f", "json", "output format: json, md, xml, quickfix, sarif, junit (pipe mode)")
 	cmd.PersistentFlags().Bool("verbose", false, "show additional context (diff hunks, debug info)")
 	cmd.PersistentFlags().Bool("no-tui", false, "force pipe mode even in TTY (for agents)")
 	cmd.PersistentFlags().Bool("debug", false, "enable debug logging to stderr")
 	cmd.PersistentFlags().Bool("solo", false, "skip approval requirement for single-maintainer repos (or set GH_GHENT_SOLO=1)")
-	cmd.PersistentFlags().Int("pr", 0, "pull request number (required by subcommands)")
+	cmd.PersistentFlags().String("pr", "", "pull request number, URL, OWNER/REPO#N, or branch (default: PR for current branch)")
+	cmd.PersistentFlags().StringP("jq", "q", "", "filter JSON output with a jq expression (pipe mode)")
+	cmd.PersistentFlags().StringP("template", "t", "", "render JSON output with a Go template (pipe mode)")
+	cmd.MarkFlagsMutuallyExclusive("jq", "template")
+	cmd.PersistentFlags().Bool("no-cache", false, "bypass the local API response cache")
 	cmd.PersistentFlags().String("since", "", "filter by timestamp (ISO 8601 or relative: 1h, 30m, 2d)")
 
 	// Subcommands
@@ -138,8 +205,18 @@ for AI agents. Works wherever gh is authenticated — zero config.
 		newChecksCmd(),
 		newResolveCmd(),
 		newReplyCmd(),
+		newSuggestionsCmd(),
 		newDismissCmd(),
+		newApplyCmd(),
+		newAuditCmd(),
+		newUpdateBranchCmd(),
+		newMergeCmd(),
+		newLogsCmd(),
+		newRerunCmd(),
 		newStatusCmd(),
+		newCacheCmd(),
+		newMCPCmd(),
+		newLSPCmd(),
 	)
 
 	// Styled help/version output (Tokyo Night theme, TTY-aware).
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -10,7 +11,7 @@ import (
 func TestRootHasSubcommands(t *testing.T) {
 	cmd := NewRootCmd()
 
-	want := []string{"checks", "comments", "dismiss", "reply", "resolve", "status"}
+	want := []string{"apply", "audit", "cache", "checks", "comments", "dismiss", "logs", "lsp", "mcp", "merge", "reply", "rerun", "resolve", "status", "suggestions", "update-branch"}
 	var got []string
 	for _, sub := range cmd.Commands() {
 		got = append(got, sub.Name())
@@ -173,3 +174,59 @@ func TestNoTUIOverridesIsTTY(t *testing.T) {
 		t.Error("NoTUI should be true when --no-tui is set")
 	}
 }
+
+func TestCachePruneRunsOffline(t *testing.T) {
+	t.Setenv("GH_CACHE_DIR", t.TempDir())
+
+	cmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetArgs([]string{"cache", "prune", "--all"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cache prune failed: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("Pruned 0 cache entries")) {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestJQRejectsNonJSONFormat(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"comments", "--jq", ".threads", "--format", "md"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "JSON output") {
+		t.Errorf("--jq with --format md: error = %v, want JSON output error", err)
+	}
+}
+
+func TestInvalidJQFailsBeforeAPICalls(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"comments", "--jq", ".threads | "})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --jq expression") {
+		t.Errorf("error = %v, want invalid --jq expression", err)
+	}
+}
+
+func TestQuickfixFormatLimitedToLocationCommands(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"resolve", "--format", "quickfix", "--thread", "PRRT_1"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "quickfix is only supported") {
+		t.Errorf("resolve --format quickfix: error = %v, want unsupported error", err)
+	}
+}
+
+func TestJUnitFormatLimitedToChecks(t *testing.T) {
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"comments", "--format", "junit", "--pr", "1"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "junit is only supported by checks") {
+		t.Errorf("comments --format junit: error = %v, want unsupported error", err)
+	}
+}
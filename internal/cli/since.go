@@ -64,7 +64,8 @@ func parseRelativeDuration(s string) (time.Duration, error) {
 }
 
 // FilterThreadsBySince filters threads, keeping only those where the newest comment
-// was created at or after the since timestamp. Updates counts on the result.
+// was created at or after the since timestamp. Conversation entries are kept if
+// they were written or edited since then. Updates counts on the result.
 func FilterThreadsBySince(result *domain.CommentsResult, since time.Time) {
 	if since.IsZero() || result == nil {
 		return
@@ -80,6 +81,14 @@ func FilterThreadsBySince(result *domain.CommentsResult, since time.Time) {
 	}
 	result.Threads = filtered
 
+	conversation := result.Conversation[:0]
+	for _, c := range result.Conversation {
+		if !c.LastActivity().Before(since) {
+			conversation = append(conversation, c)
+		}
+	}
+	result.Conversation = conversation
+
 	recountThreads(result)
 }
 
@@ -172,6 +172,33 @@ func TestFilterThreadsBySince(t *testing.T) {
 	}
 }
 
+func TestFilterThreadsBySince_Conversation(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	recent := now.Add(-1 * time.Hour)
+
+	result := &domain.CommentsResult{
+		Conversation: []domain.ConversationComment{
+			{ID: "stale", CreatedAt: old},
+			{ID: "edited", CreatedAt: old, UpdatedAt: recent},
+			{ID: "new", CreatedAt: recent},
+		},
+	}
+
+	FilterThreadsBySince(result, now.Add(-2*time.Hour))
+
+	var got []string
+	for _, c := range result.Conversation {
+		got = append(got, c.ID)
+	}
+	if diff := cmp.Diff([]string{"edited", "new"}, got); diff != "" {
+		t.Errorf("conversation mismatch (-want +got):\n%s", diff)
+	}
+	if result.ConversationCount != 2 {
+		t.Errorf("ConversationCount = %d, want 2", result.ConversationCount)
+	}
+}
+
 func TestFilterThreadsBySince_ZeroTime(t *testing.T) {
 	result := &domain.CommentsResult{
 		Threads: []domain.ReviewThread{
@@ -1,7 +1,10 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"time"
 
@@ -18,6 +21,9 @@ func newStatusCmd() *cobra.Command {
 	var (
 		compact       bool
 		withLogs      bool
+		flaky         bool
+		compareBase   bool
+		flakyCommits  int
 		quiet         bool
 		watch         bool
 		awaitReview   bool
@@ -35,14 +41,32 @@ displays a unified view with merge-readiness assessment. In TTY mode,
 shows KPI cards and section summaries. In pipe mode, outputs all
 sections in a single structured response.
 
-Use --logs to include failing job log excerpts in output.
+Use --logs to include failing job log excerpts and the failed tests
+recognized in them in output. --format quickfix always fetches logs.
+Use --flaky to mark checks whose recent history shows them flaky.
+Use --compare-base to classify failing checks (and, with --logs, failed
+tests) as new_failure or pre_existing against the base branch head, and
+passing ones the base fails as fixed_by_pr.
 Use --watch to poll until all checks complete, then output full status.
 Use --await-review to additionally wait for review activity to settle after CI.
 Use --quiet for silent exit on merge-ready (exit 0), full output on not-ready (exit 1).
 
-Merge-ready when: no unresolved threads + all checks pass + approved.
+Merge readiness follows the base branch's protection rules and rulesets
+(required approvals, code-owner review, required checks, conversation
+resolution). Each unmet rule is listed under "blockers". Without branch
+rules, merge-ready means: no unresolved threads + all checks pass + approved.
+Draft PRs and PRs with merge conflicts are never merge-ready; a head that is
+behind its base blocks when branch rules require it to be up to date (fix
+with "gh ghent update-branch").
 With --solo, the approval requirement is skipped (for single-maintainer repos).
 
+A merge policy in .github/ghent.yml (read from the PR's base branch) or
+gh-ghent/policy.yml in the user config dir adds team rules on top: ignored
+or extra required checks, minimum approvals, bot approvals, blocking/allowed
+labels, and whether neutral/skipped checks or outdated threads block. Repo
+settings override user settings key by key; blockers from a policy name the
+file they came from.
+
 Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 		Example: `  # Interactive dashboard
   gh ghent status --pr 42
@@ -67,19 +91,21 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 
   # Custom review timeout
   gh ghent status --pr 42 --await-review --review-timeout 3m`,
+		Annotations: map[string]string{locationsAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if Flags.PR == 0 {
-				return fmt.Errorf("--pr flag is required")
-			}
-
-			owner, repo, err := resolveRepo(Flags.Repo)
+			ctx := cmd.Context()
+			owner, repo, err := resolvePRTarget(ctx)
 			if err != nil {
 				return err
 			}
 
-			ctx := cmd.Context()
 			client := GitHubClient()
 
+			policy, err := loadMergePolicy(ctx, client, owner, repo, Flags.PR)
+			if err != nil {
+				return err
+			}
+
 			// --await-review implies --watch.
 			if awaitReview {
 				watch = true
@@ -87,40 +113,30 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 
 			var reviewMonitor *domain.ReviewMonitor
 
+			// The TUI shows the same readiness as pipe mode: evaluated on
+			// unfiltered data, with --since and --bots-only for display only.
+			fetchStatus := func() (*domain.StatusResult, *domain.PullRequestInfo, error) {
+				result, prInfo, _, err := collectStatus(ctx, client, owner, repo, Flags.PR, policy)
+				if err != nil {
+					return nil, nil, err
+				}
+				FilterThreadsByBot(&result.Comments, botsOnly, false)
+				return result, prInfo, nil
+			}
+
 			// Watch mode: poll until CI terminal status, then output full status.
 			if watch {
 				// TTY → launch watch TUI with optional review-await and status transition.
 				if Flags.IsTTY {
 					repoStr := owner + "/" + repo
-					sinceFilter := Flags.Since
-					botsOnlyFilter := botsOnly
 					fetchFn := func() (*domain.ChecksResult, error) {
 						return client.FetchChecks(ctx, owner, repo, Flags.PR)
 					}
 					opts := []tuiOption{
-						withRepo(repoStr), withPR(Flags.PR), withSolo(Flags.Solo),
+						withRepo(repoStr), withPR(Flags.PR), withSolo(Flags.Solo), withMergePolicy(policy),
 						withWatchFetch(fetchFn, ghub.DefaultPollInterval),
 						withStatusTransition(true),
-						withAsyncFetch(
-							func() (*domain.CommentsResult, error) {
-								result, err := client.FetchThreads(ctx, owner, repo, Flags.PR)
-								if err == nil {
-									FilterThreadsBySince(result, sinceFilter)
-									FilterThreadsByBot(result, botsOnlyFilter, false)
-								}
-								return result, err
-							},
-							func() (*domain.ChecksResult, error) {
-								result, err := client.FetchChecks(ctx, owner, repo, Flags.PR)
-								if err == nil {
-									FilterChecksBySince(result, sinceFilter)
-								}
-								return result, err
-							},
-							func() ([]domain.Review, error) {
-								return client.FetchReviews(ctx, owner, repo, Flags.PR)
-							},
-						),
+						withStatusFetch(fetchStatus),
 					}
 					if awaitReview {
 						probeFn := func() (*domain.ActivitySnapshot, error) {
@@ -138,72 +154,14 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 				}
 
 				// Non-TTY: watch progress → stderr, final status → stdout.
-				f, fErr := formatter.New(Flags.Format)
+				f, fErr := newFormatter()
 				if fErr != nil {
 					return fErr
 				}
 
-				// Take baseline activity probe before CI watch starts.
-				// This lets the review phase detect activity that happened during CI.
-				var baselineHash string
-				if awaitReview {
-					baselineSnap, probeErr := client.ProbeActivity(ctx, owner, repo, Flags.PR)
-					if probeErr == nil {
-						baselineHash = ghub.Fingerprint(baselineSnap)
-					}
-					// Non-fatal: if probe fails, proceed without baseline.
-				}
-
-				// CI watch → review watch loop (restarts if head SHA changes).
-				const maxRestarts = 3
-				for restart := 0; restart <= maxRestarts; restart++ {
-					overallStatus, watchErr := client.WatchChecks(
-						ctx, os.Stderr, f,
-						owner, repo, Flags.PR,
-						ghub.DefaultPollInterval, nil,
-						true, // waitAll: wait for every check to complete
-					)
-					if watchErr != nil {
-						return fmt.Errorf("watch checks: %w", watchErr)
-					}
-
-					// If CI failed, skip review phase.
-					if overallStatus == domain.StatusFail {
-						break
-					}
-
-					// Review-await phase (if --await-review).
-					if awaitReview {
-						// Get current head SHA from a fresh check fetch.
-						currentChecks, checkErr := client.FetchChecks(ctx, owner, repo, Flags.PR)
-						if checkErr != nil {
-							return fmt.Errorf("fetch head sha: %w", checkErr)
-						}
-
-						cfg := ghub.DefaultReviewWatchConfig()
-						cfg.HardTimeout = reviewTimeout
-						result, reviewErr := client.WatchReviews(
-							ctx, os.Stderr, f,
-							owner, repo, Flags.PR,
-							currentChecks.HeadSHA, baselineHash,
-							cfg, nil,
-						)
-						if reviewErr != nil {
-							return fmt.Errorf("watch reviews: %w", reviewErr)
-						}
-						if result.HeadChanged {
-							// Head SHA changed — restart CI watch.
-							// Take fresh baseline for the new cycle.
-							freshSnap, probeErr := client.ProbeActivity(ctx, owner, repo, Flags.PR)
-							if probeErr == nil {
-								baselineHash = ghub.Fingerprint(freshSnap)
-							}
-							fmt.Fprintf(os.Stderr, "New push detected, restarting CI watch...\n")
-							continue
-						}
-						reviewMonitor = &result.Settlement
-					}
-					break
+				reviewMonitor, err = watchUntilSettled(ctx, client, os.Stderr, f, owner, repo, Flags.PR, awaitReview, reviewTimeout)
+				if err != nil {
+					return err
 				}
 
 				// Fall through to fetch full status data below.
@@ -211,129 +169,52 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 
 			// TTY (non-watch) → launch TUI immediately with async fetch.
 			if !watch && Flags.IsTTY {
-				repoStr := owner + "/" + repo
-				sinceFilter := Flags.Since // capture for closures
-				botsOnlyFilter := botsOnly // capture for closure
 				return launchTUI(tui.ViewStatus,
-					withRepo(repoStr), withPR(Flags.PR), withSolo(Flags.Solo),
-					withAsyncFetch(
-						func() (*domain.CommentsResult, error) {
-							result, err := client.FetchThreads(ctx, owner, repo, Flags.PR)
-							if err == nil {
-								FilterThreadsBySince(result, sinceFilter)
-								FilterThreadsByBot(result, botsOnlyFilter, false)
-							}
-							return result, err
-						},
-						func() (*domain.ChecksResult, error) {
-							result, err := client.FetchChecks(ctx, owner, repo, Flags.PR)
-							if err == nil {
-								FilterChecksBySince(result, sinceFilter)
-							}
-							return result, err
-						},
-						func() ([]domain.Review, error) {
-							return client.FetchReviews(ctx, owner, repo, Flags.PR)
-						},
-					),
+					withRepo(owner+"/"+repo), withPR(Flags.PR), withSolo(Flags.Solo), withMergePolicy(policy),
+					withStatusFetch(fetchStatus),
 				)
 			}
 
 			// Non-TTY / pipe mode: block until all data is fetched.
-			cmdCtx := ctx // preserve for post-errgroup log fetching
-			g, ctx := errgroup.WithContext(ctx)
-
-			var threads *domain.CommentsResult
-			var checks *domain.ChecksResult
-			var reviews []domain.Review
-			var reviewFetchFailed bool
-
-			g.Go(func() error {
-				var fetchErr error
-				threads, fetchErr = client.FetchThreads(ctx, owner, repo, Flags.PR)
-				if fetchErr != nil {
-					return fmt.Errorf("fetch threads: %w", fetchErr)
-				}
-				return nil
-			})
-
-			g.Go(func() error {
-				var fetchErr error
-				checks, fetchErr = client.FetchChecks(ctx, owner, repo, Flags.PR)
-				if fetchErr != nil {
-					return fmt.Errorf("fetch checks: %w", fetchErr)
-				}
-				return nil
-			})
-
-			g.Go(func() error {
-				var fetchErr error
-				reviews, fetchErr = client.FetchReviews(ctx, owner, repo, Flags.PR)
-				if fetchErr != nil {
-					// Tolerate review fetch failure — degrade gracefully, but
-					// mark as failed so merge-readiness defaults to not-ready.
-					reviews = nil
-					reviewFetchFailed = true
-				}
-				return nil
-			})
-
-			if err := g.Wait(); err != nil {
+			// Merge readiness is computed BEFORE the --bots-only filter,
+			// otherwise filtering out human threads hides unresolved counts.
+			result, _, base, err := collectStatus(ctx, client, owner, repo, Flags.PR, policy)
+			if err != nil {
 				return err
 			}
 
-			// Apply --since filter (no-op if not set).
-			FilterThreadsBySince(threads, Flags.Since)
-			FilterChecksBySince(checks, Flags.Since)
-
-			// Fetch logs for failing checks when --logs is set (or implied by --watch).
-			// Use cmdCtx (not ctx) because errgroup's derived context is cancelled
-			// after g.Wait() returns. IsFailConclusion covers all failure-classified
-			// conclusions (failure, timed_out, cancelled, etc.), not just "failure".
-			if withLogs || watch {
-				for i := range checks.Checks {
-					ch := &checks.Checks[i]
-					if !domain.IsFailConclusion(ch.Conclusion) {
-						continue
-					}
-					logText, logErr := client.FetchJobLog(cmdCtx, owner, repo, ch.ID)
-					if logErr != nil {
-						continue // graceful degradation
-					}
-					ch.LogExcerpt = ghub.ExtractErrorLines(logText)
+			// Fetch logs for failing checks when --logs is set (or implied by
+			// --watch or --format quickfix, which lists their file:line refs).
+			if watch || Flags.Format == "quickfix" {
+				withLogs = true
+			}
+			if withLogs {
+				attachLogExcerpts(ctx, client, owner, repo, &result.Checks)
+			}
+			if flaky {
+				attachFlakiness(ctx, client, owner, repo, Flags.PR, flakyCommits, &result.Checks)
+			}
+			if compareBase {
+				if base == nil {
+					base = compareWithBase(ctx, client, owner, repo, Flags.PR, &result.Checks)
+				}
+				if base != nil && withLogs {
+					compareFailedTests(ctx, client, owner, repo, &result.Checks, base)
 				}
 			}
 
-			// Merge readiness MUST be computed BEFORE --bots-only filter,
-			// otherwise filtering out human threads hides unresolved counts.
-			mergeReady := !reviewFetchFailed && IsMergeReady(threads, checks, reviews, Flags.Solo)
-			staleReviews := staleBlockingReviews(reviews)
-
 			// Apply --bots-only filter to threads section (display only).
-			FilterThreadsByBot(threads, botsOnly, false)
+			FilterThreadsByBot(&result.Comments, botsOnly, false)
 
 			// --quiet: silent exit on merge-ready, full output on not-ready.
-			if quiet && mergeReady {
+			if quiet && result.IsMergeReady {
 				return nil // exit 0, no output
 			}
 
-			now := time.Now()
-
-			result := &domain.StatusResult{
-				PRNumber:      Flags.PR,
-				Comments:      *threads,
-				Checks:        *checks,
-				Reviews:       reviews,
-				StaleReviews:  staleReviews,
-				IsMergeReady:  mergeReady,
-				PRAge:         computePRAge(threads, reviews, now),
-				LastUpdate:    computeLastUpdate(threads, reviews, now),
-				ReviewCycles:  computeReviewCycles(reviews),
-				ReviewMonitor: reviewMonitor,
-				ReviewSettled: reviewMonitor,
-			}
+			result.ReviewMonitor = reviewMonitor
+			result.ReviewSettled = reviewMonitor
 
-			f, err := formatter.New(Flags.Format)
+			f, err := newFormatter()
 			if err != nil {
 				return err
 			}
@@ -349,7 +230,7 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 			}
 
 			// Exit codes: 0=ready, 1=not ready.
-			if !mergeReady {
+			if !result.IsMergeReady {
 				os.Exit(1)
 			}
 
@@ -358,7 +239,10 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 	}
 
 	cmd.Flags().BoolVar(&compact, "compact", false, "one-line-per-thread compact digest (optimized for agents)")
-	cmd.Flags().BoolVar(&withLogs, "logs", false, "include failing job log excerpts in output")
+	cmd.Flags().BoolVar(&withLogs, "logs", false, "include failing job log excerpts and failed tests in output")
+	cmd.Flags().BoolVar(&flaky, "flaky", false, "mark flaky checks using the check history of recent commits")
+	cmd.Flags().BoolVar(&compareBase, "compare-base", false, "classify failures as new or pre-existing against the base branch")
+	cmd.Flags().IntVar(&flakyCommits, "flaky-commits", ghub.DefaultFlakyCommits, "how many recent commits --flaky looks back over")
 	cmd.Flags().BoolVar(&quiet, "quiet", false, "silent on merge-ready (exit 0), full output on not-ready (exit 1)")
 	cmd.Flags().BoolVar(&watch, "watch", false, "poll until all checks complete, then output full status")
 	cmd.Flags().BoolVar(&awaitReview, "await-review", false, "after CI completes, wait for review activity to settle (implies --watch)")
@@ -368,7 +252,8 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 	return cmd
 }
 
-// IsMergeReady determines if a PR is ready to merge based on threads, checks, and reviews.
+// IsMergeReady reports whether a PR passes ghent's default readiness rules,
+// used when the base branch has no protection rules or rulesets.
 //
 // Conditions:
 //  1. No unresolved threads
@@ -378,39 +263,382 @@ Exit codes: 0 = merge-ready, 1 = not merge-ready.`,
 // If reviews is nil (fetch failed), the approval requirement is skipped.
 // If solo is true, the approval requirement is skipped but CHANGES_REQUESTED still blocks.
 func IsMergeReady(threads *domain.CommentsResult, checks *domain.ChecksResult, reviews []domain.Review, solo bool) bool {
-	// Condition 1: No unresolved threads.
-	if threads != nil && threads.UnresolvedCount > 0 {
-		return false
+	return len(domain.EvaluateReadiness(domain.ReadinessInput{
+		Threads: threads,
+		Checks:  checks,
+		Reviews: reviews,
+		Solo:    solo,
+	})) == 0
+}
+
+// statusClient is the subset of the GitHub client needed to evaluate merge
+// readiness the way the status command does.
+type statusClient interface {
+	commentsClient
+	domain.CheckFetcher
+	domain.BaseCheckFetcher
+	domain.ReviewFetcher
+	mergeContextClient
+	jobLogFetcher
+}
+
+// collectStatus fetches threads, checks, reviews, and merge context in
+// parallel, evaluates merge readiness, and then applies --since to the
+// threads and checks it reports. It is shared by status and merge so both
+// judge readiness identically. The returned PR
+// metadata is nil if it could not be fetched; the base branch checks are
+// returned when the policy compared against them, and nil otherwise.
+func collectStatus(
+	ctx context.Context,
+	client statusClient,
+	owner, repo string,
+	pr int,
+	policy *domain.MergePolicy,
+) (*domain.StatusResult, *domain.PullRequestInfo, *domain.ChecksResult, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var threads *domain.CommentsResult
+	var checks *domain.ChecksResult
+	var reviews []domain.Review
+	var reviewFetchFailed bool
+	var prInfo *domain.PullRequestInfo
+	var requirements *domain.MergeRequirements
+
+	g.Go(func() error {
+		var fetchErr error
+		threads, fetchErr = fetchComments(gctx, client, owner, repo, pr)
+		return fetchErr
+	})
+
+	g.Go(func() error {
+		var fetchErr error
+		checks, fetchErr = client.FetchChecks(gctx, owner, repo, pr)
+		if fetchErr != nil {
+			return fmt.Errorf("fetch checks: %w", fetchErr)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		var fetchErr error
+		reviews, fetchErr = client.FetchReviews(gctx, owner, repo, pr)
+		if fetchErr != nil {
+			// Tolerate review fetch failure — degrade gracefully, but
+			// mark as failed so merge-readiness defaults to not-ready.
+			reviews = nil
+			reviewFetchFailed = true
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		prInfo, requirements = fetchMergeContext(gctx, client, owner, repo, pr)
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Pre-existing failures can only be exempted once they are identified,
+	// down to the failed tests in their logs.
+	var base *domain.ChecksResult
+	if policy != nil && policy.IgnorePreExistingFailures {
+		if base = compareWithBase(ctx, client, owner, repo, pr, checks); base != nil {
+			classifyPreExistingFailures(ctx, client, owner, repo, checks, base)
+		}
 	}
 
-	// Condition 2: All checks pass.
-	if checks != nil && checks.OverallStatus != domain.StatusPass {
-		return false
+	blockers := domain.EvaluateReadiness(domain.ReadinessInput{
+		Threads:      threads,
+		Checks:       checks,
+		Reviews:      reviews,
+		PR:           prInfo,
+		Requirements: requirements,
+		Policy:       policy,
+		Solo:         Flags.Solo,
+	})
+	if reviewFetchFailed {
+		blockers = append(blockers, domain.MergeBlocker{
+			Rule:    "reviews_unavailable",
+			Source:  domain.BlockerSourceDefault,
+			Message: "reviews could not be fetched",
+		})
 	}
 
-	// Condition 3: At least 1 approval and no changes_requested.
-	// If reviews fetch failed (nil), don't block on approvals.
-	// If solo mode, skip approval requirement but still block on changes_requested.
-	if reviews != nil {
-		for _, r := range reviews {
-			if r.State == domain.ReviewChangesRequested {
-				return false
-			}
+	now := time.Now()
+	result := &domain.StatusResult{
+		PRNumber:          pr,
+		Comments:          *threads,
+		Checks:            *checks,
+		Reviews:           reviews,
+		StaleReviews:      staleBlockingReviews(reviews),
+		IsMergeReady:      len(blockers) == 0,
+		Blockers:          blockers,
+		MergeRequirements: requirements,
+		MergePolicy:       policy,
+		PRAge:             computePRAge(threads, reviews, now),
+		LastUpdate:        computeLastUpdate(threads, reviews, now),
+		ReviewCycles:      computeReviewCycles(reviews),
+	}
+	applyMergeState(result, prInfo)
+
+	// --since narrows what is shown, never what blocks: an old unresolved
+	// thread or failing check still stops the merge.
+	FilterThreadsBySince(&result.Comments, Flags.Since)
+	FilterChecksBySince(&result.Checks, Flags.Since)
+	return result, prInfo, base, nil
+}
+
+// statusWatcher is the subset of the GitHub client that the pipe-mode
+// --watch / --await-review loop needs.
+type statusWatcher interface {
+	domain.CheckFetcher
+	domain.ActivityProber
+	WatchChecks(ctx context.Context, w io.Writer, f domain.Formatter, owner, repo string, pr int,
+		interval time.Duration, clock func() time.Time, waitAll bool) (domain.OverallStatus, error)
+	WatchReviews(ctx context.Context, w io.Writer, f domain.Formatter, owner, repo string, pr int,
+		initialHeadSHA, baselineHash string, cfg ghub.ReviewWatchConfig, clock func() time.Time) (*ghub.WatchReviewResult, error)
+}
+
+// watchHook is a Formatter for watch loops whose statuses feed code rather
+// than a writer. The loops only call FormatWatchStatus.
+type watchHook struct {
+	formatter.JSONFormatter
+	onStatus func(*domain.WatchStatus)
+}
+
+func (h *watchHook) FormatWatchStatus(_ io.Writer, status *domain.WatchStatus) error {
+	h.onStatus(status)
+	return nil
+}
+
+// watchUntilSettled waits for every check to complete and, with awaitReview,
+// for review activity to settle afterwards, restarting the CI watch when a new
+// push lands. Progress is emitted through f to w. It returns the review
+// settlement, or nil when reviews were not awaited or CI failed.
+func watchUntilSettled(
+	ctx context.Context,
+	client statusWatcher,
+	w io.Writer,
+	f domain.Formatter,
+	owner, repo string,
+	pr int,
+	awaitReview bool,
+	reviewTimeout time.Duration,
+) (*domain.ReviewMonitor, error) {
+	// Take baseline activity probe before CI watch starts.
+	// This lets the review phase detect activity that happened during CI.
+	var baselineHash string
+	if awaitReview {
+		baselineSnap, probeErr := client.ProbeActivity(ctx, owner, repo, pr)
+		if probeErr == nil {
+			baselineHash = ghub.Fingerprint(baselineSnap)
 		}
-		if !solo {
-			hasApproval := false
-			for _, r := range reviews {
-				if r.State == domain.ReviewApproved {
-					hasApproval = true
-				}
-			}
-			if !hasApproval {
-				return false
+		// Non-fatal: if probe fails, proceed without baseline.
+	}
+
+	// CI watch → review watch loop (restarts if head SHA changes).
+	const maxRestarts = 3
+	for restart := 0; restart <= maxRestarts; restart++ {
+		overallStatus, watchErr := client.WatchChecks(
+			ctx, w, f,
+			owner, repo, pr,
+			ghub.DefaultPollInterval, nil,
+			true, // waitAll: wait for every check to complete
+		)
+		if watchErr != nil {
+			return nil, fmt.Errorf("watch checks: %w", watchErr)
+		}
+
+		// If CI failed, skip review phase.
+		if overallStatus == domain.StatusFail || !awaitReview {
+			return nil, nil
+		}
+
+		// Get current head SHA from a fresh check fetch.
+		currentChecks, checkErr := client.FetchChecks(ctx, owner, repo, pr)
+		if checkErr != nil {
+			return nil, fmt.Errorf("fetch head sha: %w", checkErr)
+		}
+
+		cfg := ghub.DefaultReviewWatchConfig()
+		cfg.HardTimeout = reviewTimeout
+		result, reviewErr := client.WatchReviews(
+			ctx, w, f,
+			owner, repo, pr,
+			currentChecks.HeadSHA, baselineHash,
+			cfg, nil,
+		)
+		if reviewErr != nil {
+			return nil, fmt.Errorf("watch reviews: %w", reviewErr)
+		}
+		if !result.HeadChanged {
+			return &result.Settlement, nil
+		}
+		// Head SHA changed — restart CI watch.
+		// Take fresh baseline for the new cycle.
+		freshSnap, probeErr := client.ProbeActivity(ctx, owner, repo, pr)
+		if probeErr == nil {
+			baselineHash = ghub.Fingerprint(freshSnap)
+		}
+		fmt.Fprintf(w, "New push detected, restarting CI watch...\n")
+	}
+	return nil, nil
+}
+
+// jobLogFetcher fetches the raw log and step list of an Actions job.
+type jobLogFetcher interface {
+	FetchJobLog(ctx context.Context, owner, repo string, jobID int64) (string, error)
+	FetchJobSteps(ctx context.Context, owner, repo string, jobID int64) ([]domain.JobStep, error)
+}
+
+// attachLogExcerpts fills LogExcerpt, FailedSteps, and FailedTests for every
+// failing check run. The excerpt comes from the failing steps only when the
+// step list is available, and from the whole log otherwise.
+// IsFailConclusion covers all failure-classified conclusions (failure,
+// timed_out, cancelled, etc.); commit statuses have no Actions job behind
+// them. A log that cannot be fetched is skipped.
+// Checks that already carry log data are left as they are.
+func attachLogExcerpts(ctx context.Context, client jobLogFetcher, owner, repo string, checks *domain.ChecksResult) {
+	for i := range checks.Checks {
+		ch := &checks.Checks[i]
+		if !domain.IsFailConclusion(ch.Conclusion) || ch.IsCommitStatus() || hasLogData(*ch) {
+			continue
+		}
+		_ = attachLogExcerpt(ctx, client, owner, repo, ch) // graceful degradation
+	}
+}
+
+// attachLogExcerpt fills one check's log excerpt, failed steps, and failed
+// tests from its job log.
+func attachLogExcerpt(ctx context.Context, client jobLogFetcher, owner, repo string, ch *domain.CheckRun) error {
+	logText, err := client.FetchJobLog(ctx, owner, repo, ch.ID)
+	if err != nil {
+		return err
+	}
+	steps, err := client.FetchJobSteps(ctx, owner, repo, ch.ID)
+	if err != nil {
+		steps = nil // flat excerpt
+	}
+	ch.LogExcerpt, ch.FailedSteps = ghub.ExtractStepErrorLines(logText, steps)
+	ch.FailedTests = ghub.ExtractFailedTests(logText)
+	return nil
+}
+
+func hasLogData(ch domain.CheckRun) bool {
+	return ch.LogExcerpt != "" || len(ch.FailedSteps) > 0 || len(ch.FailedTests) > 0
+}
+
+// attachFlakiness marks the checks whose history over the PR's last commits
+// shows them flaky (see ghub.MarkFlakyChecks). History that cannot be
+// fetched leaves the checks unmarked.
+func attachFlakiness(ctx context.Context, client domain.CheckHistoryFetcher, owner, repo string, pr, commits int, checks *domain.ChecksResult) {
+	history, err := client.FetchCheckHistory(ctx, owner, repo, pr, commits)
+	if err != nil {
+		slog.Debug("check history fetch failed, skipping flakiness", "error", err)
+		return // graceful degradation
+	}
+	ghub.MarkFlakyChecks(checks, history)
+}
+
+// compareWithBase classifies checks against the base branch head (see
+// ghub.CompareWithBase) and returns the base checks. When they cannot be
+// fetched the checks stay unclassified and nil is returned, so pre-existing
+// failures keep blocking.
+func compareWithBase(ctx context.Context, client domain.BaseCheckFetcher, owner, repo string, pr int, checks *domain.ChecksResult) *domain.ChecksResult {
+	base, err := client.FetchBaseChecks(ctx, owner, repo, pr)
+	if err != nil {
+		slog.Debug("base check fetch failed, skipping comparison", "error", err)
+		return nil // graceful degradation
+	}
+	ghub.CompareWithBase(checks, base)
+	return base
+}
+
+// compareFailedTests classifies the failed tests of compared checks: all are
+// new when the check is a new failure, and for pre-existing failures each is
+// looked up among the failed tests in the base check's job log. A base log
+// that cannot be fetched leaves the tests unclassified.
+func compareFailedTests(ctx context.Context, client jobLogFetcher, owner, repo string, checks, base *domain.ChecksResult) {
+	for i := range checks.Checks {
+		ch := &checks.Checks[i]
+		if len(ch.FailedTests) == 0 {
+			continue
+		}
+		if ch.FailedTests[0].BaseComparison != "" {
+			continue // already compared
+		}
+		switch ch.BaseComparison {
+		case domain.BaseNewFailure:
+			ghub.CompareFailedTests(ch.FailedTests, nil)
+		case domain.BasePreExisting:
+			baseCh, _ := ghub.BaseCheck(base, *ch)
+			logText, err := client.FetchJobLog(ctx, owner, repo, baseCh.ID)
+			if err != nil {
+				continue // graceful degradation
 			}
+			ghub.CompareFailedTests(ch.FailedTests, ghub.ExtractFailedTests(logText))
 		}
 	}
+}
+
+// classifyPreExistingFailures fetches the job logs of checks that fail on
+// the base branch too and compares their failed tests with the base's, so
+// readiness can tell a check that is only as red as the base from one with
+// new failing tests. A check whose log cannot be read loses its pre_existing
+// classification and keeps blocking.
+func classifyPreExistingFailures(ctx context.Context, client jobLogFetcher, owner, repo string, checks, base *domain.ChecksResult) {
+	for i := range checks.Checks {
+		ch := &checks.Checks[i]
+		if ch.BaseComparison != domain.BasePreExisting || ch.IsCommitStatus() || hasLogData(*ch) {
+			continue
+		}
+		if err := attachLogExcerpt(ctx, client, owner, repo, ch); err != nil {
+			slog.Debug("job log fetch failed, pre-existing failure stays blocking", "check", ch.Name, "error", err)
+			ch.BaseComparison = ""
+		}
+	}
+	compareFailedTests(ctx, client, owner, repo, checks, base)
+}
+
+// applyMergeState copies draft, conflict, and behind-base state into the
+// status result. A nil pr (metadata unavailable) leaves the fields unset.
+func applyMergeState(result *domain.StatusResult, pr *domain.PullRequestInfo) {
+	if pr == nil {
+		return
+	}
+	result.BaseRef = pr.BaseRef
+	result.IsDraft = pr.IsDraft
+	result.Mergeable = pr.Mergeable
+	result.MergeStateStatus = pr.MergeStateStatus
+	result.HasConflicts = pr.HasConflicts()
+	result.IsBehind = pr.IsBehind()
+	result.BehindBy = pr.BehindBy
+}
 
-	return true
+// mergeContextClient is the subset of the GitHub client needed to load the
+// PR metadata and base-branch rules for readiness evaluation.
+type mergeContextClient interface {
+	domain.PullRequestFetcher
+	domain.MergeRequirementsFetcher
+}
+
+// fetchMergeContext loads PR metadata and the base branch's merge rules.
+// Failures are not fatal: a nil result makes readiness fall back to ghent's
+// default rules.
+func fetchMergeContext(ctx context.Context, client mergeContextClient, owner, repo string, pr int) (*domain.PullRequestInfo, *domain.MergeRequirements) {
+	info, err := client.FetchPullRequest(ctx, owner, repo, pr)
+	if err != nil {
+		slog.Debug("pull request metadata unavailable", "error", err)
+		return nil, nil
+	}
+	req, err := client.FetchMergeRequirements(ctx, owner, repo, info.BaseRef)
+	if err != nil {
+		slog.Debug("merge requirements unavailable, using default readiness rules", "error", err)
+		return info, nil
+	}
+	return info, req
 }
 
 // computePRAge derives PR age from the earliest timestamp in threads/reviews.
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	ghub "github.com/indrasvat/gh-ghent/internal/github"
+)
+
+func newSuggestionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggestions",
+		Short: "List, preview, and apply reviewer suggestions locally",
+		Long: `List the suggestion blocks in unresolved review threads and apply them
+to the local working tree.
+
+Each suggestion is checked against the local file: the lines it replaces
+must still read as they did when the comment was made. When they have
+moved, the suggestion follows them if they occur exactly once in the
+file. Suggestions whose lines changed, or that overlap an earlier
+suggestion, are reported as conflicts and never applied. Suggestions
+already present in the file are reported as already_applied.
+
+Without --apply the suggestions are only listed, each with a unified diff
+of the change. --dry-run reports what --apply would do. After applying,
+--reply posts a reply to each thread and --resolve resolves threads
+whose suggestions are all in place.
+
+Must be run inside a checkout of the PR's repository, ideally on its head.
+
+Exit codes with --apply: 0 = all applied, 1 = partial (conflicts or
+failures), 2 = nothing applied.`,
+		Example: `  # List suggestions with their diffs
+  gh ghent suggestions --pr 42 --format md
+
+  # Plan for agents: what --apply would change
+  gh ghent suggestions --pr 42 --dry-run --format json
+
+  # Apply the suggestions on one file
+  gh ghent suggestions --pr 42 --apply --file "internal/api/*.go"
+
+  # Apply, reply, and resolve the threads
+  gh ghent suggestions --pr 42 --apply --reply "Applied the suggestion" --resolve`,
+		RunE: runSuggestions,
+	}
+
+	cmd.Flags().String("thread", "", "only suggestions in this thread (PRRT_... node ID)")
+	cmd.Flags().String("file", "", "only suggestions on files matching glob (e.g., 'internal/api/*.go')")
+	cmd.Flags().String("author", "", "only suggestions made by this author")
+	cmd.Flags().Bool("apply", false, "write applicable suggestions to the local files")
+	cmd.Flags().Bool("dry-run", false, "show what --apply would change without writing")
+	cmd.Flags().String("reply", "", "after applying, reply to each thread with this text")
+	cmd.Flags().Bool("resolve", false, "after applying, resolve threads whose suggestions are all in place")
+
+	return cmd
+}
+
+// suggestionsClient is the subset of the GitHub client the suggestions
+// command needs.
+type suggestionsClient interface {
+	domain.ThreadFetcher
+	domain.ThreadReplier
+	domain.ThreadResolver
+}
+
+// suggestionsOptions holds the parsed suggestions command flags.
+type suggestionsOptions struct {
+	threadID string
+	fileGlob string
+	author   string
+	apply    bool // also set by dryRun
+	dryRun   bool
+	reply    string
+	resolve  bool
+}
+
+func runSuggestions(cmd *cobra.Command, _ []string) error {
+	opts := suggestionsOptions{}
+	f := cmd.Flags()
+	var err error
+	if opts.threadID, err = f.GetString("thread"); err != nil {
+		return err
+	}
+	if opts.fileGlob, err = f.GetString("file"); err != nil {
+		return err
+	}
+	if opts.author, err = f.GetString("author"); err != nil {
+		return err
+	}
+	if opts.apply, err = f.GetBool("apply"); err != nil {
+		return err
+	}
+	if opts.dryRun, err = f.GetBool("dry-run"); err != nil {
+		return err
+	}
+	if opts.reply, err = f.GetString("reply"); err != nil {
+		return err
+	}
+	if opts.resolve, err = f.GetBool("resolve"); err != nil {
+		return err
+	}
+	if (opts.reply != "" || opts.resolve) && !opts.apply && !opts.dryRun {
+		return fmt.Errorf("--reply and --resolve require --apply")
+	}
+	opts.apply = opts.apply || opts.dryRun
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
+	}
+	root := checkoutRoot(ctx, owner, repo)
+	if root == "" {
+		return fmt.Errorf("suggestions are applied to local files: run inside a checkout of %s/%s", owner, repo)
+	}
+
+	results, err := buildSuggestionResults(ctx, GitHubClient(), owner, repo, Flags.PR, root, opts)
+	if err != nil {
+		return err
+	}
+
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	if err := formatter.FormatSuggestions(os.Stdout, results); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+
+	if opts.apply {
+		if exitCode := suggestionsExitCode(results); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}
+	return nil
+}
+
+func buildSuggestionResults(
+	ctx context.Context,
+	client suggestionsClient,
+	owner, repo string,
+	pr int,
+	root string,
+	opts suggestionsOptions,
+) (*domain.SuggestionResults, error) {
+	threads, err := client.FetchThreads(ctx, owner, repo, pr)
+	if err != nil {
+		return nil, fmt.Errorf("fetch threads: %w", err)
+	}
+
+	// Suggestions grouped by file, in thread order.
+	var paths []string
+	byPath := make(map[string][]domain.Suggestion)
+	for _, t := range threads.Threads {
+		if opts.threadID != "" && t.ID != opts.threadID {
+			continue
+		}
+		if !matchesFilters(t, opts.fileGlob, "") {
+			continue
+		}
+		for _, s := range ghub.ExtractSuggestions(t) {
+			if opts.author != "" && s.Author != opts.author {
+				continue
+			}
+			if _, ok := byPath[s.Path]; !ok {
+				paths = append(paths, s.Path)
+			}
+			byPath[s.Path] = append(byPath[s.Path], s)
+		}
+	}
+
+	results := &domain.SuggestionResults{
+		PRNumber:    pr,
+		Suggestions: []domain.Suggestion{},
+		DryRun:      opts.dryRun,
+	}
+	for _, p := range paths {
+		results.Suggestions = append(results.Suggestions, applyFileSuggestions(root, p, byPath[p], opts)...)
+	}
+	if opts.apply && !opts.dryRun && (opts.reply != "" || opts.resolve) {
+		followUpSuggestions(ctx, client, owner, repo, pr, results.Suggestions, opts)
+	}
+
+	for _, s := range results.Suggestions {
+		switch s.Status {
+		case domain.SuggestionApplicable, domain.SuggestionWouldApply:
+			results.ApplicableCount++
+		case domain.SuggestionApplied:
+			results.AppliedCount++
+		case domain.SuggestionConflict:
+			results.ConflictCount++
+		}
+		if s.Error != "" {
+			results.FailureCount++
+		}
+	}
+	return results, nil
+}
+
+// applyFileSuggestions plans the suggestions on one file and, with --apply,
+// writes the applicable ones in a single write.
+func applyFileSuggestions(root, path string, suggestions []domain.Suggestion, opts suggestionsOptions) []domain.Suggestion {
+	conflict := func(reason string) []domain.Suggestion {
+		for i := range suggestions {
+			if suggestions[i].Status != domain.SuggestionConflict {
+				suggestions[i].Status, suggestions[i].Reason = domain.SuggestionConflict, reason
+			}
+		}
+		return suggestions
+	}
+	if !filepath.IsLocal(filepath.FromSlash(path)) {
+		return conflict("path is outside the checkout")
+	}
+	full := filepath.Join(root, filepath.FromSlash(path))
+	content, err := os.ReadFile(full)
+	if errors.Is(err, fs.ErrNotExist) {
+		return conflict("file does not exist locally")
+	}
+	if err != nil {
+		return conflict(err.Error())
+	}
+
+	ghub.PlanSuggestions(content, suggestions)
+	if !opts.apply || !slices.ContainsFunc(suggestions, func(s domain.Suggestion) bool {
+		return s.Status == domain.SuggestionApplicable
+	}) {
+		return suggestions
+	}
+
+	status := domain.SuggestionApplied
+	var writeErr error
+	if opts.dryRun {
+		status = domain.SuggestionWouldApply
+	} else if info, err := os.Stat(full); err != nil {
+		writeErr = err
+	} else {
+		writeErr = os.WriteFile(full, ghub.ApplySuggestions(content, suggestions), info.Mode().Perm())
+	}
+	for i := range suggestions {
+		s := &suggestions[i]
+		if s.Status != domain.SuggestionApplicable {
+			continue
+		}
+		if writeErr != nil {
+			s.Status, s.Error = domain.SuggestionFailed, fmt.Sprintf("write %s: %v", path, writeErr)
+			continue
+		}
+		s.Status = status
+	}
+	return suggestions
+}
+
+// followUpSuggestions replies to and resolves the threads of applied
+// suggestions, once per thread. A thread is only resolved when none of its
+// suggestions conflicts or failed.
+func followUpSuggestions(
+	ctx context.Context,
+	client suggestionsClient,
+	owner, repo string,
+	pr int,
+	suggestions []domain.Suggestion,
+	opts suggestionsOptions,
+) {
+	settled := make(map[string]bool)
+	for _, s := range suggestions {
+		ok, seen := settled[s.ThreadID]
+		inPlace := s.Status == domain.SuggestionApplied || s.Status == domain.SuggestionAlreadyApplied
+		settled[s.ThreadID] = (ok || !seen) && inPlace
+	}
+
+	done := make(map[string]bool)
+	for i := range suggestions {
+		s := &suggestions[i]
+		if s.Status != domain.SuggestionApplied || done[s.ThreadID] {
+			continue
+		}
+		done[s.ThreadID] = true
+		if opts.reply != "" {
+			if _, err := client.ReplyToThread(ctx, owner, repo, pr, s.ThreadID, opts.reply); err != nil {
+				s.Error = fmt.Sprintf("reply: %v", err)
+				continue
+			}
+			s.Replied = true
+		}
+		if opts.resolve && settled[s.ThreadID] {
+			if _, err := client.ResolveThread(ctx, owner, repo, pr, s.ThreadID); err != nil {
+				s.Error = fmt.Sprintf("resolve: %v", err)
+				continue
+			}
+			s.Resolved = true
+		}
+	}
+}
+
+// suggestionsExitCode follows the mutation commands: 0 = all applied, 1 =
+// partial, 2 = nothing applied. Suggestions already in place count as
+// neither; a failed reply or resolve counts against an applied suggestion.
+func suggestionsExitCode(results *domain.SuggestionResults) int {
+	succeeded := results.AppliedCount + results.ApplicableCount
+	failed := results.ConflictCount + results.FailureCount
+	if failed > 0 && succeeded > 0 {
+		return 1
+	}
+	if failed > 0 {
+		return 2
+	}
+	return 0
+}
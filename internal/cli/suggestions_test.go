@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+type stubSuggestionsClient struct {
+	threads    []domain.ReviewThread
+	replyErr   error
+	replies    []string
+	resolution []string
+}
+
+func (s *stubSuggestionsClient) FetchThreads(_ context.Context, _, _ string, pr int) (*domain.CommentsResult, error) {
+	return &domain.CommentsResult{PRNumber: pr, Threads: s.threads}, nil
+}
+
+func (s *stubSuggestionsClient) ReplyToThread(_ context.Context, _, _ string, _ int, threadID, _ string) (*domain.ReplyResult, error) {
+	s.replies = append(s.replies, threadID)
+	if s.replyErr != nil {
+		return nil, s.replyErr
+	}
+	return &domain.ReplyResult{ThreadID: threadID}, nil
+}
+
+func (s *stubSuggestionsClient) ResolveThread(_ context.Context, _, _ string, _ int, threadID string) (*domain.ResolveResult, error) {
+	s.resolution = append(s.resolution, threadID)
+	return &domain.ResolveResult{ThreadID: threadID, IsResolved: true}, nil
+}
+
+func (s *stubSuggestionsClient) UnresolveThread(_ context.Context, _, _ string, _ int, threadID string) (*domain.ResolveResult, error) {
+	return &domain.ResolveResult{ThreadID: threadID}, nil
+}
+
+// suggestionFixture is a checkout holding app.go and three threads: two
+// applicable suggestions on app.go (one per thread) and one whose lines
+// have since changed, in the same thread as the second.
+func suggestionFixture(t *testing.T) (string, *stubSuggestionsClient) {
+	t.Helper()
+	root := t.TempDir()
+	src := "package app\n\nvar a = 1\nvar b = 2\nvar c = 3\n"
+	if err := os.WriteFile(filepath.Join(root, "app.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	comment := func(id, body, hunk string) domain.Comment {
+		return domain.Comment{ID: id, Author: "alice", Body: body, DiffHunk: hunk}
+	}
+	client := &stubSuggestionsClient{threads: []domain.ReviewThread{
+		{ID: "T1", Path: "app.go", Line: 3, Comments: []domain.Comment{
+			comment("C1", "```suggestion\nvar a = 10\n```", "@@ -1,3 +1,3 @@\n package app\n \n+var a = 1"),
+		}},
+		{ID: "T2", Path: "app.go", Line: 5, Comments: []domain.Comment{
+			comment("C2", "```suggestion\nvar c = 30\n```", "@@ -3,3 +3,3 @@\n var a = 1\n var b = 2\n+var c = 3"),
+			comment("C3", "Or rather:\n```suggestion\nvar b = 20\n```", "@@ -3,2 +3,2 @@\n var a = 1\n+var b = 5"),
+		}},
+		{ID: "T3", Path: "gone.go", Line: 1, Comments: []domain.Comment{
+			comment("C4", "```suggestion\nx\n```", "@@ -1 +1 @@\n+y"),
+		}},
+	}}
+	return root, client
+}
+
+func suggestionStatuses(results *domain.SuggestionResults) map[string]string {
+	got := make(map[string]string)
+	for _, s := range results.Suggestions {
+		got[s.CommentID] = s.Status
+	}
+	return got
+}
+
+func TestBuildSuggestionResults(t *testing.T) {
+	original := "package app\n\nvar a = 1\nvar b = 2\nvar c = 3\n"
+	tests := []struct {
+		name         string
+		opts         suggestionsOptions
+		wantStatuses map[string]string
+		wantFile     string
+		wantReplies  []string
+		wantResolved []string
+		wantExit     int
+	}{
+		{
+			name: "list only",
+			opts: suggestionsOptions{},
+			wantStatuses: map[string]string{
+				"C1": domain.SuggestionApplicable, "C2": domain.SuggestionApplicable,
+				"C3": domain.SuggestionConflict, "C4": domain.SuggestionConflict,
+			},
+			wantFile: original,
+		},
+		{
+			name: "dry run",
+			opts: suggestionsOptions{apply: true, dryRun: true, resolve: true},
+			wantStatuses: map[string]string{
+				"C1": domain.SuggestionWouldApply, "C2": domain.SuggestionWouldApply,
+				"C3": domain.SuggestionConflict, "C4": domain.SuggestionConflict,
+			},
+			wantFile: original,
+			wantExit: 1,
+		},
+		{
+			name: "apply, reply, and resolve",
+			opts: suggestionsOptions{apply: true, reply: "Applied", resolve: true},
+			wantStatuses: map[string]string{
+				"C1": domain.SuggestionApplied, "C2": domain.SuggestionApplied,
+				"C3": domain.SuggestionConflict, "C4": domain.SuggestionConflict,
+			},
+			wantFile:     "package app\n\nvar a = 10\nvar b = 2\nvar c = 30\n",
+			wantReplies:  []string{"T1", "T2"},
+			wantResolved: []string{"T1"}, // T2 still has a conflicting suggestion
+			wantExit:     1,
+		},
+		{
+			name:         "thread filter",
+			opts:         suggestionsOptions{threadID: "T1", apply: true},
+			wantStatuses: map[string]string{"C1": domain.SuggestionApplied},
+			wantFile:     "package app\n\nvar a = 10\nvar b = 2\nvar c = 3\n",
+		},
+		{
+			name:         "author filter",
+			opts:         suggestionsOptions{author: "bob"},
+			wantStatuses: map[string]string{},
+			wantFile:     original,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, client := suggestionFixture(t)
+			got, err := buildSuggestionResults(context.Background(), client, "owner", "repo", 42, root, tt.opts)
+			if err != nil {
+				t.Fatalf("buildSuggestionResults() error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantStatuses, suggestionStatuses(got)); diff != "" {
+				t.Errorf("statuses mismatch (-want +got):\n%s", diff)
+			}
+			data, err := os.ReadFile(filepath.Join(root, "app.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantFile, string(data)); diff != "" {
+				t.Errorf("app.go mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantReplies, client.replies); diff != "" {
+				t.Errorf("replies mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantResolved, client.resolution); diff != "" {
+				t.Errorf("resolved threads mismatch (-want +got):\n%s", diff)
+			}
+			if tt.opts.apply {
+				if code := suggestionsExitCode(got); code != tt.wantExit {
+					t.Errorf("suggestionsExitCode() = %d, want %d", code, tt.wantExit)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSuggestionResults_ReplyFailure(t *testing.T) {
+	root, client := suggestionFixture(t)
+	client.threads = client.threads[:1]
+	client.replyErr = errors.New("cannot reply")
+
+	got, err := buildSuggestionResults(context.Background(), client, "owner", "repo", 42, root, suggestionsOptions{apply: true, reply: "Done", resolve: true})
+	if err != nil {
+		t.Fatalf("buildSuggestionResults() error: %v", err)
+	}
+	s := got.Suggestions[0]
+	if s.Status != domain.SuggestionApplied || s.Error != "reply: cannot reply" {
+		t.Errorf("suggestion = %q (error %q), want applied with the reply error", s.Status, s.Error)
+	}
+	if len(client.resolution) != 0 {
+		t.Errorf("resolved %v after a failed reply", client.resolution)
+	}
+	if code := suggestionsExitCode(got); code != 1 {
+		t.Errorf("suggestionsExitCode() = %d, want 1", code)
+	}
+}
+
+func TestApplyFileSuggestions_PathOutsideCheckout(t *testing.T) {
+	got := applyFileSuggestions(t.TempDir(), "../etc/passwd", []domain.Suggestion{{CommentID: "C1"}}, suggestionsOptions{apply: true})
+	if got[0].Status != domain.SuggestionConflict || got[0].Reason != "path is outside the checkout" {
+		t.Errorf("got %q (%q), want a conflict", got[0].Status, got[0].Reason)
+	}
+}
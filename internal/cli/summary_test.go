@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -395,3 +397,55 @@ func TestComputeLastUpdate(t *testing.T) {
 		})
 	}
 }
+
+type stubMergeContextClient struct {
+	pr        *domain.PullRequestInfo
+	prErr     error
+	req       *domain.MergeRequirements
+	reqErr    error
+	reqBranch string
+}
+
+func (s *stubMergeContextClient) FetchPullRequest(context.Context, string, string, int) (*domain.PullRequestInfo, error) {
+	return s.pr, s.prErr
+}
+
+func (s *stubMergeContextClient) FetchMergeRequirements(_ context.Context, _, _, branch string) (*domain.MergeRequirements, error) {
+	s.reqBranch = branch
+	return s.req, s.reqErr
+}
+
+func TestFetchMergeContext(t *testing.T) {
+	info := &domain.PullRequestInfo{Number: 7, BaseRef: "release/1.x"}
+	req := &domain.MergeRequirements{BaseBranch: "release/1.x", Sources: []string{"branch_protection"}}
+
+	t.Run("uses PR base branch", func(t *testing.T) {
+		stub := &stubMergeContextClient{pr: info, req: req}
+		gotPR, gotReq := fetchMergeContext(context.Background(), stub, "o", "r", 7)
+		if gotPR != info || gotReq != req {
+			t.Fatalf("fetchMergeContext() = (%v, %v), want (%v, %v)", gotPR, gotReq, info, req)
+		}
+		if stub.reqBranch != "release/1.x" {
+			t.Errorf("requirements fetched for %q, want %q", stub.reqBranch, "release/1.x")
+		}
+	})
+
+	t.Run("requirements error falls back to defaults", func(t *testing.T) {
+		stub := &stubMergeContextClient{pr: info, reqErr: errors.New("boom")}
+		gotPR, gotReq := fetchMergeContext(context.Background(), stub, "o", "r", 7)
+		if gotPR != info || gotReq != nil {
+			t.Fatalf("fetchMergeContext() = (%v, %v), want (%v, nil)", gotPR, gotReq, info)
+		}
+	})
+
+	t.Run("PR error skips requirements", func(t *testing.T) {
+		stub := &stubMergeContextClient{prErr: errors.New("boom"), req: req}
+		gotPR, gotReq := fetchMergeContext(context.Background(), stub, "o", "r", 7)
+		if gotPR != nil || gotReq != nil {
+			t.Fatalf("fetchMergeContext() = (%v, %v), want (nil, nil)", gotPR, gotReq)
+		}
+		if stub.reqBranch != "" {
+			t.Errorf("requirements should not be fetched without PR metadata")
+		}
+	})
+}
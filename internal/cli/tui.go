@@ -23,6 +23,9 @@ func launchTUI(startView tui.View, opts ...tuiOption) error {
 	if cfg.solo {
 		app.SetSolo(true)
 	}
+	if cfg.policy != nil {
+		app.SetMergePolicy(cfg.policy)
+	}
 	if cfg.comments != nil {
 		app.SetComments(cfg.comments)
 	}
@@ -35,8 +38,8 @@ func launchTUI(startView tui.View, opts ...tuiOption) error {
 	if cfg.watchFetchFn != nil {
 		app.SetWatchFetch(cfg.watchFetchFn, cfg.watchInterval)
 	}
-	if cfg.asyncComments != nil || cfg.asyncChecks != nil || cfg.asyncReviews != nil {
-		app.SetAsyncFetch(cfg.asyncComments, cfg.asyncChecks, cfg.asyncReviews)
+	if cfg.statusFn != nil {
+		app.SetStatusFetch(cfg.statusFn)
 	}
 	if cfg.reviewFetchFn != nil {
 		app.SetReviewWatch(cfg.reviewFetchFn, cfg.reviewTimeout, cfg.reviewBaselineHash)
@@ -67,16 +70,15 @@ type tuiConfig struct {
 	repo          string
 	pr            int
 	solo          bool
+	policy        *domain.MergePolicy
 	comments      *domain.CommentsResult
 	checks        *domain.ChecksResult
 	resolveFunc   func(threadID string) error
 	watchFetchFn  func() (*domain.ChecksResult, error)
 	watchInterval time.Duration
 
-	// Async fetch functions — TUI launches immediately, data loads progressively.
-	asyncComments tui.FetchCommentsFunc
-	asyncChecks   tui.FetchChecksFunc
-	asyncReviews  tui.FetchReviewsFunc
+	// Async status fetch — TUI launches immediately, data loads when ready.
+	statusFn tui.FetchStatusFunc
 
 	// Review-await mode.
 	reviewFetchFn      tui.ReviewPollFunc
@@ -99,6 +101,10 @@ func withSolo(solo bool) tuiOption {
 	return func(c *tuiConfig) { c.solo = solo }
 }
 
+func withMergePolicy(p *domain.MergePolicy) tuiOption {
+	return func(c *tuiConfig) { c.policy = p }
+}
+
 func withComments(r *domain.CommentsResult) tuiOption {
 	return func(c *tuiConfig) { c.comments = r }
 }
@@ -118,12 +124,8 @@ func withWatchFetch(fn func() (*domain.ChecksResult, error), interval time.Durat
 	}
 }
 
-func withAsyncFetch(comments tui.FetchCommentsFunc, checks tui.FetchChecksFunc, reviews tui.FetchReviewsFunc) tuiOption {
-	return func(c *tuiConfig) {
-		c.asyncComments = comments
-		c.asyncChecks = checks
-		c.asyncReviews = reviews
-	}
+func withStatusFetch(fn tui.FetchStatusFunc) tuiOption {
+	return func(c *tuiConfig) { c.statusFn = fn }
 }
 
 func withAwaitReview(fn tui.ReviewPollFunc, timeout time.Duration, baselineHash string) tuiOption {
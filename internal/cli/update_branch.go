@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func newUpdateBranchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-branch",
+		Short: "Bring a PR branch up to date with its base",
+		Long: `Merge (or rebase) the base branch into a pull request's head branch.
+
+The update is pinned to the head SHA ghent just read: if someone pushes in
+the meantime, GitHub rejects it instead of updating a head nobody reviewed.
+Branches that are already up to date are left alone. PRs with merge
+conflicts cannot be updated server-side and must be fixed locally.
+
+Exit codes: 0 = updated or already up to date, 1 = not updated, 2 = error.`,
+		Example: `  # Merge the base branch into the PR head
+  gh ghent update-branch --pr 42
+
+  # Rebase instead of creating a merge commit
+  gh ghent update-branch --pr 42 --rebase
+
+  # Report whether an update is needed without changing anything
+  gh ghent update-branch --pr 42 --dry-run`,
+		RunE: runUpdateBranch,
+	}
+
+	cmd.Flags().Bool("rebase", false, "rebase the head onto the base instead of merging")
+	cmd.Flags().Bool("dry-run", false, "show whether the branch would be updated without executing")
+
+	return cmd
+}
+
+type updateBranchClient interface {
+	domain.PullRequestFetcher
+	domain.BranchUpdater
+}
+
+func runUpdateBranch(cmd *cobra.Command, _ []string) error {
+	rebase, err := cmd.Flags().GetBool("rebase")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	method := domain.UpdateMethodMerge
+	if rebase {
+		method = domain.UpdateMethodRebase
+	}
+
+	ctx := cmd.Context()
+	owner, repo, err := resolvePRTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := buildUpdateBranchResult(ctx, GitHubClient(), owner, repo, Flags.PR, method, dryRun)
+	if err != nil {
+		return err
+	}
+
+	f, err := newFormatter()
+	if err != nil {
+		return err
+	}
+	if err := f.FormatUpdateBranch(os.Stdout, result); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+	return nil
+}
+
+func buildUpdateBranchResult(
+	ctx context.Context,
+	client updateBranchClient,
+	owner, repo string,
+	pr int,
+	method string,
+	dryRun bool,
+) (*domain.UpdateBranchResult, error) {
+	info, err := client.FetchPullRequest(ctx, owner, repo, pr)
+	if err != nil {
+		return nil, fmt.Errorf("fetch pull request: %w", err)
+	}
+
+	result := &domain.UpdateBranchResult{
+		PRNumber:        pr,
+		BaseRef:         info.BaseRef,
+		Method:          method,
+		BehindBy:        info.BehindBy,
+		PreviousHeadSHA: info.HeadSHA,
+		DryRun:          dryRun,
+	}
+
+	if !info.IsBehind() {
+		result.Action = "up_to_date"
+		return result, nil
+	}
+	if info.HasConflicts() {
+		return nil, fmt.Errorf("PR #%d has merge conflicts with %s; resolve them locally and push", pr, info.BaseRef)
+	}
+	if dryRun {
+		result.Action = "would_update"
+		return result, nil
+	}
+
+	head, err := client.UpdateBranch(ctx, info, method)
+	if err != nil {
+		return nil, err
+	}
+	result.HeadSHA = head
+	result.Updated = true
+	result.Action = "updated"
+	return result, nil
+}
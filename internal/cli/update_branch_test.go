@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+type stubUpdateBranchClient struct {
+	pr           *domain.PullRequestInfo
+	newHead      string
+	updateCalls  int
+	updateMethod string
+}
+
+func (s *stubUpdateBranchClient) FetchPullRequest(context.Context, string, string, int) (*domain.PullRequestInfo, error) {
+	return s.pr, nil
+}
+
+func (s *stubUpdateBranchClient) UpdateBranch(_ context.Context, _ *domain.PullRequestInfo, method string) (string, error) {
+	s.updateCalls++
+	s.updateMethod = method
+	return s.newHead, nil
+}
+
+func TestBuildUpdateBranchResult(t *testing.T) {
+	behind := &domain.PullRequestInfo{
+		Number: 42, BaseRef: "main", HeadSHA: "aaa111", BehindBy: 2, Mergeable: "MERGEABLE",
+	}
+
+	tests := []struct {
+		name      string
+		pr        *domain.PullRequestInfo
+		method    string
+		dryRun    bool
+		want      *domain.UpdateBranchResult
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:   "up to date is a no-op",
+			pr:     &domain.PullRequestInfo{Number: 42, BaseRef: "main", HeadSHA: "aaa111", MergeStateStatus: "CLEAN"},
+			method: domain.UpdateMethodMerge,
+			want: &domain.UpdateBranchResult{
+				PRNumber: 42, BaseRef: "main", Method: "merge", PreviousHeadSHA: "aaa111", Action: "up_to_date",
+			},
+		},
+		{
+			name:   "dry run does not update",
+			pr:     behind,
+			method: domain.UpdateMethodMerge,
+			dryRun: true,
+			want: &domain.UpdateBranchResult{
+				PRNumber: 42, BaseRef: "main", Method: "merge", BehindBy: 2, PreviousHeadSHA: "aaa111",
+				Action: "would_update", DryRun: true,
+			},
+		},
+		{
+			name:   "rebase update",
+			pr:     behind,
+			method: domain.UpdateMethodRebase,
+			want: &domain.UpdateBranchResult{
+				PRNumber: 42, BaseRef: "main", Method: "rebase", BehindBy: 2, PreviousHeadSHA: "aaa111",
+				HeadSHA: "bbb222", Updated: true, Action: "updated",
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "conflicts refuse to update",
+			pr: &domain.PullRequestInfo{
+				Number: 42, BaseRef: "main", HeadSHA: "aaa111", BehindBy: 4, Mergeable: domain.MergeableConflicting,
+			},
+			method:  domain.UpdateMethodMerge,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &stubUpdateBranchClient{pr: tt.pr, newHead: "bbb222"}
+			got, err := buildUpdateBranchResult(context.Background(), client, "o", "r", 42, tt.method, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildUpdateBranchResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("result mismatch (-want +got):\n%s", diff)
+			}
+			if client.updateCalls != tt.wantCalls {
+				t.Errorf("UpdateBranch calls = %d, want %d", client.updateCalls, tt.wantCalls)
+			}
+			if tt.wantCalls > 0 && client.updateMethod != tt.method {
+				t.Errorf("UpdateBranch method = %q, want %q", client.updateMethod, tt.method)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fileLocationRegexp matches a file:line[:col] reference at the start of a
+// log line or after whitespace or a bracket, e.g. "    foo_test.go:42: got 1"
+// or "##[error]src/app.ts:10:5 - error TS2322". URLs such as
+// "https://example.com:443" do not match.
+var fileLocationRegexp = regexp.MustCompile(`(?:^|[\s(\[\]])((?:[\w.-]+/)*[\w.-]+\.[A-Za-z]\w*):(\d+)(?::(\d+))?:?\s*(.*)$`)
+
+// FileLocation is a file:line[:col] reference found in a log line, with the
+// text that follows it.
+type FileLocation struct {
+	Path    string
+	Line    int
+	Col     int // 0 when the reference has no column
+	Message string
+}
+
+// ParseFileLocation returns the file:line[:col] reference in a log line.
+func ParseFileLocation(line string) (FileLocation, bool) {
+	m := fileLocationRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return FileLocation{}, false
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil || n == 0 {
+		return FileLocation{}, false
+	}
+	col, _ := strconv.Atoi(m[3])
+	return FileLocation{Path: m[1], Line: n, Col: col, Message: strings.TrimSpace(m[4])}, true
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "testing"
+
+func TestParseFileLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		line   string
+		want   FileLocation
+		wantOK bool
+	}{
+		{"go test", "    foo_test.go:42: got 1", FileLocation{Path: "foo_test.go", Line: 42, Message: "got 1"}, true},
+		{"with column", "##[error]src/app.ts:10:5 - error TS2322", FileLocation{Path: "src/app.ts", Line: 10, Col: 5, Message: "- error TS2322"}, true},
+		{"in parens", "panic (internal/x/y.go:7)", FileLocation{Path: "internal/x/y.go", Line: 7, Message: ")"}, true},
+		{"url port", "see https://example.com:443/x", FileLocation{}, false},
+		{"line zero", "main.go:0: weird", FileLocation{}, false},
+		{"no location", "FAIL github.com/x/y 0.01s", FileLocation{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseFileLocation(tt.line)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseFileLocation(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
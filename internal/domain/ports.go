@@ -10,15 +10,48 @@ type ThreadFetcher interface {
 	FetchThreads(ctx context.Context, owner, repo string, pr int) (*CommentsResult, error)
 }
 
+// AllThreadFetcher fetches every review thread for a PR, resolved or not.
+type AllThreadFetcher interface {
+	FetchAllThreads(ctx context.Context, owner, repo string, pr int) (*CommentsResult, error)
+}
+
+// ConversationFetcher fetches top-level PR comments and review bodies.
+type ConversationFetcher interface {
+	FetchConversation(ctx context.Context, owner, repo string, pr int) ([]ConversationComment, error)
+}
+
+// CodeChangeAnalyzer compares the code unresolved review threads were left
+// on with the PR head, setting CodeChangedSinceComment and ChangedBy.
+type CodeChangeAnalyzer interface {
+	MarkCodeChanges(ctx context.Context, owner, repo string, pr int, result *CommentsResult) error
+}
+
 // CheckFetcher fetches CI check runs for a PR.
 type CheckFetcher interface {
 	FetchChecks(ctx context.Context, owner, repo string, pr int) (*ChecksResult, error)
 }
 
+// CheckHistoryFetcher fetches check runs, including earlier attempts, of a
+// PR's most recent commits, oldest first.
+type CheckHistoryFetcher interface {
+	FetchCheckHistory(ctx context.Context, owner, repo string, pr, commits int) ([]CommitCheckRuns, error)
+}
+
+// BaseCheckFetcher fetches the checks on the head of a PR's base branch.
+type BaseCheckFetcher interface {
+	FetchBaseChecks(ctx context.Context, owner, repo string, pr int) (*ChecksResult, error)
+}
+
+// BaseFileFetcher fetches a file from the head of a PR's base branch,
+// returning nil when it does not exist there.
+type BaseFileFetcher interface {
+	FetchBaseFile(ctx context.Context, owner, repo string, pr int, path string) ([]byte, error)
+}
+
 // ThreadResolver resolves or unresolves review threads.
 type ThreadResolver interface {
-	ResolveThread(ctx context.Context, threadID string) (*ResolveResult, error)
-	UnresolveThread(ctx context.Context, threadID string) (*ResolveResult, error)
+	ResolveThread(ctx context.Context, owner, repo string, pr int, threadID string) (*ResolveResult, error)
+	UnresolveThread(ctx context.Context, owner, repo string, pr int, threadID string) (*ResolveResult, error)
 }
 
 // ThreadReplier posts replies to review threads.
@@ -26,6 +59,12 @@ type ThreadReplier interface {
 	ReplyToThread(ctx context.Context, owner, repo string, pr int, threadID, body string) (*ReplyResult, error)
 }
 
+// CommentReplier replies to a known review comment without looking up its
+// thread first.
+type CommentReplier interface {
+	ReplyToComment(ctx context.Context, owner, repo string, pr int, threadID string, commentID int64, body string) (*ReplyResult, error)
+}
+
 // ReviewFetcher fetches PR reviews (approvals, change requests).
 type ReviewFetcher interface {
 	FetchReviews(ctx context.Context, owner, repo string, pr int) ([]Review, error)
@@ -41,6 +80,41 @@ type ActivityProber interface {
 	ProbeActivity(ctx context.Context, owner, repo string, pr int) (*ActivitySnapshot, error)
 }
 
+// PullRequestFetcher fetches pull request metadata (base/head refs, review decision).
+type PullRequestFetcher interface {
+	FetchPullRequest(ctx context.Context, owner, repo string, pr int) (*PullRequestInfo, error)
+}
+
+// MergeRequirementsFetcher fetches the branch protection and ruleset
+// requirements that apply to a base branch.
+type MergeRequirementsFetcher interface {
+	FetchMergeRequirements(ctx context.Context, owner, repo, branch string) (*MergeRequirements, error)
+}
+
+// BranchUpdater merges or rebases the base branch into a PR head, returning
+// the new head SHA. The update must be refused if the head is no longer
+// pr.HeadSHA.
+type BranchUpdater interface {
+	UpdateBranch(ctx context.Context, pr *PullRequestInfo, method string) (string, error)
+}
+
+// PullRequestMerger merges a pull request, or hands it to GitHub to merge
+// later. Every call must pass pr.HeadSHA as the expected head so GitHub
+// refuses if the PR changed after readiness was verified.
+type PullRequestMerger interface {
+	MergePullRequest(ctx context.Context, pr *PullRequestInfo, req MergeRequest) (string, error)
+	EnableAutoMerge(ctx context.Context, pr *PullRequestInfo, req MergeRequest) error
+	EnqueuePullRequest(ctx context.Context, pr *PullRequestInfo) error
+}
+
+// WorkflowRunner re-runs and cancels GitHub Actions workflow runs and jobs.
+type WorkflowRunner interface {
+	RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error
+	RerunJob(ctx context.Context, owner, repo string, jobID int64) error
+	CancelRun(ctx context.Context, owner, repo string, runID int64) error
+	FetchJobRunID(ctx context.Context, owner, repo string, jobID int64) (int64, error)
+}
+
 // Formatter formats output for pipe mode.
 type Formatter interface {
 	FormatComments(w io.Writer, result *CommentsResult) error
@@ -49,6 +123,13 @@ type Formatter interface {
 	FormatReply(w io.Writer, result *ReplyResult) error
 	FormatResolveResults(w io.Writer, result *ResolveResults) error
 	FormatDismissResults(w io.Writer, result *DismissResults) error
+	FormatUpdateBranch(w io.Writer, result *UpdateBranchResult) error
+	FormatMergeResult(w io.Writer, result *MergeResult) error
+	FormatJobLog(w io.Writer, result *JobLogResult) error
+	FormatRerunResults(w io.Writer, result *RerunResults) error
+	FormatSuggestions(w io.Writer, result *SuggestionResults) error
+	FormatPlanResults(w io.Writer, result *PlanResults) error
+	FormatAudit(w io.Writer, result *AuditResult) error
 	FormatStatus(w io.Writer, result *StatusResult) error
 	FormatCompactStatus(w io.Writer, result *StatusResult) error
 	FormatWatchStatus(w io.Writer, status *WatchStatus) error
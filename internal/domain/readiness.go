@@ -0,0 +1,558 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Blocker sources identify which rule set produced a merge blocker. Policy
+// blockers use "policy:<file>" so the offending file is visible.
+const (
+	BlockerSourceDefault     = "default"      // ghent's built-in heuristic (no branch rules found)
+	BlockerSourceBranchRules = "branch_rules" // branch protection and/or rulesets on the base branch
+	BlockerSourcePolicy      = "policy"       // prefix for merge policy file rules
+	BlockerSourceGitHub      = "github"       // GitHub's own mergeability (draft, conflicts)
+)
+
+// Mergeability values from GitHub's PullRequest.mergeable and
+// PullRequest.mergeStateStatus fields.
+const (
+	MergeableConflicting = "CONFLICTING"
+	MergeableUnknown     = "UNKNOWN"
+
+	MergeStateBehind   = "BEHIND"   // head is out of date and branch rules require it current
+	MergeStateBlocked  = "BLOCKED"  // a branch rule is not satisfied
+	MergeStateClean    = "CLEAN"    // mergeable with passing status
+	MergeStateDirty    = "DIRTY"    // merge commit cannot be cleanly created
+	MergeStateDraft    = "DRAFT"    // pull request is a draft
+	MergeStateUnstable = "UNSTABLE" // mergeable with failing non-required checks
+)
+
+// PullRequestInfo holds pull request metadata needed to judge merge readiness.
+type PullRequestInfo struct {
+	ID               string   `json:"id,omitempty"` // GraphQL node ID, needed for mutations
+	Number           int      `json:"number"`
+	BaseRef          string   `json:"base_ref"`
+	BaseSHA          string   `json:"base_sha,omitempty"`
+	HeadRef          string   `json:"head_ref"`
+	HeadSHA          string   `json:"head_sha"`
+	ReviewDecision   string   `json:"review_decision,omitempty"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED
+	Labels           []string `json:"labels,omitempty"`
+	IsDraft          bool     `json:"is_draft"`
+	Mergeable        string   `json:"mergeable,omitempty"` // MERGEABLE, CONFLICTING, UNKNOWN
+	MergeStateStatus string   `json:"merge_state_status,omitempty"`
+	BehindBy         int      `json:"behind_by"` // base commits missing from head; 0 if unknown
+}
+
+// HasConflicts reports whether the PR cannot be merged without resolving
+// conflicts with its base branch.
+func (p *PullRequestInfo) HasConflicts() bool {
+	return p != nil && (p.Mergeable == MergeableConflicting || p.MergeStateStatus == MergeStateDirty)
+}
+
+// IsBehind reports whether the base branch has commits the head lacks.
+func (p *PullRequestInfo) IsBehind() bool {
+	return p != nil && (p.BehindBy > 0 || p.MergeStateStatus == MergeStateBehind)
+}
+
+// MergeRequirements is the effective set of merge rules for a base branch,
+// combined from classic branch protection and every active ruleset. When
+// several sources set the same rule the strictest value wins.
+type MergeRequirements struct {
+	BaseBranch                    string   `json:"base_branch"`
+	RequiredApprovals             int      `json:"required_approvals"`
+	RequireCodeOwnerReview        bool     `json:"require_code_owner_review,omitempty"`
+	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals,omitempty"`
+	RequireConversationResolution bool     `json:"require_conversation_resolution,omitempty"`
+	RequireLinearHistory          bool     `json:"require_linear_history,omitempty"`
+	RequiredChecks                []string `json:"required_checks,omitempty"`
+	Sources                       []string `json:"sources"` // e.g. "branch_protection", "ruleset:42"
+	// ReviewRulesUnknown is set when branch protection applies but its review
+	// settings are unreadable (reading them requires admin). The default
+	// approval rule then stands in for them.
+	ReviewRulesUnknown bool `json:"review_rules_unknown,omitempty"`
+}
+
+// HasRules reports whether any branch protection or ruleset applies.
+func (r *MergeRequirements) HasRules() bool {
+	return r != nil && len(r.Sources) > 0
+}
+
+// MergePolicy holds team merge conventions loaded from policy files. The zero
+// value changes nothing. Branch rules enforced by GitHub cannot be relaxed by a
+// policy; ignore_checks and outdated-thread exemptions only apply when ghent
+// falls back to its default heuristic.
+type MergePolicy struct {
+	IgnoreChecks               []string `json:"ignore_checks,omitempty"`   // glob patterns
+	RequiredChecks             []string `json:"required_checks,omitempty"` // must exist and pass
+	NeutralIsFailure           bool     `json:"neutral_is_failure,omitempty"`
+	SkippedIsFailure           bool     `json:"skipped_is_failure,omitempty"`
+	MinApprovals               *int     `json:"min_approvals,omitempty"`
+	IgnoreBotApprovals         bool     `json:"ignore_bot_approvals,omitempty"`
+	BlockingLabels             []string `json:"blocking_labels,omitempty"`
+	AllowLabels                []string `json:"allow_labels,omitempty"` // PR needs at least one
+	OutdatedThreadsNonBlocking bool     `json:"outdated_threads_non_blocking,omitempty"`
+	// IgnorePreExistingFailures exempts checks that fail on the base branch
+	// too (BaseComparison pre_existing) and whose failed tests all failed
+	// there as well, which requires a base comparison of checks and logs.
+	IgnorePreExistingFailures bool `json:"ignore_pre_existing_failures,omitempty"`
+
+	// Origins maps each rule key (e.g. "min_approvals") to the file that set it.
+	Origins map[string]string `json:"origins,omitempty"`
+}
+
+// Source returns the blocker source for a policy rule key.
+func (p *MergePolicy) Source(rule string) string {
+	if p != nil && p.Origins[rule] != "" {
+		return BlockerSourcePolicy + ":" + p.Origins[rule]
+	}
+	return BlockerSourcePolicy
+}
+
+// IsIgnoredCheck reports whether a check name matches an ignore_checks pattern.
+func (p *MergePolicy) IsIgnoredCheck(name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, pattern := range p.IgnoreChecks {
+		if ok, _ := path.Match(pattern, name); ok || pattern == name {
+			return true
+		}
+	}
+	return false
+}
+
+// affectsChecks reports whether check classification differs from GitHub's,
+// in which case the pre-computed ChecksResult aggregate cannot be reused.
+func (p *MergePolicy) affectsChecks() bool {
+	return p != nil && (len(p.IgnoreChecks) > 0 || p.NeutralIsFailure || p.SkippedIsFailure || p.IgnorePreExistingFailures)
+}
+
+// MergeBlocker is a single unmet merge-readiness condition.
+type MergeBlocker struct {
+	Rule    string `json:"rule"`   // machine-readable rule ID, e.g. "required_approvals"
+	Source  string `json:"source"` // default, branch_rules, github, or policy:<file>
+	Message string `json:"message"`
+}
+
+// ReadinessInput bundles everything EvaluateReadiness looks at.
+type ReadinessInput struct {
+	Threads *CommentsResult
+	Checks  *ChecksResult
+	// Reviews is nil when the review fetch failed; review rules are then skipped.
+	Reviews      []Review
+	PR           *PullRequestInfo
+	Requirements *MergeRequirements
+	Policy       *MergePolicy
+	Solo         bool // skip approval requirements; CHANGES_REQUESTED still blocks
+}
+
+// EvaluateReadiness returns every unmet merge condition. An empty result means
+// the PR is merge-ready.
+//
+// When the base branch has protection rules or rulesets, only those rules are
+// enforced so the verdict matches GitHub's merge button. Otherwise ghent falls
+// back to its default: no unresolved threads, all checks passing, and at least
+// one approval with no changes requested. Policy rules apply in both modes, as
+// do draft, conflict, and out-of-date states that GitHub itself refuses.
+func EvaluateReadiness(in ReadinessInput) []MergeBlocker {
+	blockers := mergeStateBlockers(in.PR)
+	if in.Requirements.HasRules() {
+		blockers = append(blockers, branchRuleBlockers(in)...)
+	} else {
+		blockers = append(blockers, defaultBlockers(in)...)
+	}
+	return append(blockers, policyBlockers(in)...)
+}
+
+// mergeStateBlockers reports PR states that make GitHub refuse the merge no
+// matter which rules apply. Being behind only blocks when GitHub says so
+// (branch rules require an up-to-date head).
+func mergeStateBlockers(pr *PullRequestInfo) []MergeBlocker {
+	blockers := []MergeBlocker{}
+	if pr == nil {
+		return blockers
+	}
+	if pr.IsDraft {
+		blockers = append(blockers, MergeBlocker{
+			Rule: "draft", Source: BlockerSourceGitHub, Message: "pull request is a draft",
+		})
+	}
+	if pr.HasConflicts() {
+		blockers = append(blockers, MergeBlocker{
+			Rule: "merge_conflicts", Source: BlockerSourceGitHub,
+			Message: fmt.Sprintf("merge conflicts with `%s`", pr.BaseRef),
+		})
+	}
+	if pr.MergeStateStatus == MergeStateBehind {
+		msg := fmt.Sprintf("branch is behind `%s`", pr.BaseRef)
+		if pr.BehindBy > 0 {
+			msg = fmt.Sprintf("branch is %s behind `%s`", Plural(pr.BehindBy, "commit", "commits"), pr.BaseRef)
+		}
+		blockers = append(blockers, MergeBlocker{Rule: "behind_base", Source: BlockerSourceBranchRules, Message: msg})
+	}
+	return blockers
+}
+
+func defaultBlockers(in ReadinessInput) []MergeBlocker {
+	blockers := []MergeBlocker{}
+	add := func(rule, source, msg string) {
+		blockers = append(blockers, MergeBlocker{Rule: rule, Source: source, Message: msg})
+	}
+
+	if n := blockingThreadCount(in.Threads, in.Policy); n > 0 {
+		add("unresolved_threads", BlockerSourceDefault, Plural(n, "unresolved review thread", "unresolved review threads"))
+	}
+
+	if in.Checks != nil {
+		status, fail, pending := in.Checks.OverallStatus, in.Checks.FailCount, in.Checks.PendingCount
+		if in.Policy.affectsChecks() {
+			status, fail, pending = aggregateChecks(in.Checks.Checks, in.Policy)
+		}
+		switch status {
+		case StatusPass:
+		case StatusFail:
+			add("checks", BlockerSourceDefault, fmt.Sprintf("%s failing", Plural(fail, "check", "checks")))
+		default:
+			add("checks", BlockerSourceDefault, fmt.Sprintf("%s pending", Plural(pending, "check", "checks")))
+		}
+	}
+
+	if in.Reviews != nil {
+		if authors := changesRequestedBy(in.Reviews, false); len(authors) > 0 {
+			add("changes_requested", BlockerSourceDefault, "changes requested by "+strings.Join(authors, ", "))
+		}
+		if !in.Solo {
+			required, source := 1, BlockerSourceDefault
+			if in.Policy != nil && in.Policy.MinApprovals != nil {
+				required, source = *in.Policy.MinApprovals, in.Policy.Source("min_approvals")
+			}
+			if approvals := countApprovers(in.Reviews, in.Policy); approvals < required {
+				add("required_approvals", source, fmt.Sprintf("needs %s, has %d",
+					Plural(required, "approval", "approvals"), approvals))
+			}
+		}
+	}
+
+	return blockers
+}
+
+func branchRuleBlockers(in ReadinessInput) []MergeBlocker {
+	req := in.Requirements
+	blockers := []MergeBlocker{}
+	add := func(rule, source, msg string) {
+		blockers = append(blockers, MergeBlocker{Rule: rule, Source: source, Message: msg})
+	}
+
+	// GitHub counts outdated threads too, so the policy cannot exempt them here.
+	if req.RequireConversationResolution && in.Threads != nil && in.Threads.UnresolvedCount > 0 {
+		add("conversation_resolution", BlockerSourceBranchRules,
+			Plural(in.Threads.UnresolvedCount, "unresolved review thread", "unresolved review threads"))
+	}
+
+	if in.Checks != nil {
+		for _, name := range req.RequiredChecks {
+			if msg := requiredCheckMessage(name, requiredCheckState(in.Checks.Checks, name, in.Policy)); msg != "" {
+				add("required_checks", BlockerSourceBranchRules, msg)
+			}
+		}
+	}
+
+	required, source := req.RequiredApprovals, BlockerSourceBranchRules
+	if req.ReviewRulesUnknown && required == 0 {
+		required, source = 1, BlockerSourceDefault
+		if in.Policy != nil && in.Policy.MinApprovals != nil {
+			required, source = *in.Policy.MinApprovals, in.Policy.Source("min_approvals")
+		}
+	}
+	if in.Policy != nil && in.Policy.MinApprovals != nil && *in.Policy.MinApprovals > required {
+		required, source = *in.Policy.MinApprovals, in.Policy.Source("min_approvals")
+	}
+
+	if in.Reviews != nil && (required > 0 || req.RequireCodeOwnerReview) {
+		if authors := changesRequestedBy(in.Reviews, true); len(authors) > 0 {
+			add("changes_requested", BlockerSourceBranchRules, "changes requested by "+strings.Join(authors, ", "))
+		}
+		if !in.Solo {
+			approvals := countApprovals(in.Reviews, req.DismissStaleApprovals, in.Policy)
+			if approvals < required {
+				add("required_approvals", source, fmt.Sprintf("needs %s, has %d",
+					Plural(required, "approval", "approvals"), approvals))
+			} else if in.PR != nil && in.PR.ReviewDecision == "REVIEW_REQUIRED" {
+				switch {
+				case req.RequireCodeOwnerReview:
+					add("code_owner_review", BlockerSourceBranchRules, "code owner review required")
+				case req.ReviewRulesUnknown:
+					// GitHub knows the real review settings even when we cannot read them.
+					add("review_required", BlockerSourceBranchRules, "GitHub reports a review is still required")
+				}
+			}
+		}
+	}
+
+	return blockers
+}
+
+// policyBlockers evaluates policy rules that have no branch-rule or default
+// counterpart: extra required checks and labels.
+func policyBlockers(in ReadinessInput) []MergeBlocker {
+	p := in.Policy
+	if p == nil {
+		return nil
+	}
+	var blockers []MergeBlocker
+	add := func(rule, msg string) {
+		blockers = append(blockers, MergeBlocker{Rule: rule, Source: p.Source(rule), Message: msg})
+	}
+
+	if in.Checks != nil {
+		for _, name := range p.RequiredChecks {
+			if in.Requirements.HasRules() && slices.Contains(in.Requirements.RequiredChecks, name) {
+				continue // already reported under branch rules
+			}
+			state := requiredCheckState(in.Checks.Checks, name, p)
+			// Without branch rules every failing or pending check is already
+			// reported under "checks"; only a missing check is news.
+			if !in.Requirements.HasRules() && state != requiredCheckMissing {
+				continue
+			}
+			if msg := requiredCheckMessage(name, state); msg != "" {
+				add("required_checks", msg)
+			}
+		}
+	}
+
+	if len(p.BlockingLabels) == 0 && len(p.AllowLabels) == 0 {
+		return blockers
+	}
+	if in.PR == nil {
+		key := "blocking_labels"
+		if len(p.BlockingLabels) == 0 {
+			key = "allow_labels"
+		}
+		blockers = append(blockers, MergeBlocker{
+			Rule: "labels", Source: p.Source(key), Message: "pull request labels unavailable",
+		})
+		return blockers
+	}
+	for _, label := range p.BlockingLabels {
+		if hasLabel(in.PR.Labels, label) {
+			add("blocking_labels", fmt.Sprintf("label `%s` blocks merging", label))
+		}
+	}
+	if len(p.AllowLabels) > 0 && !slices.ContainsFunc(p.AllowLabels, func(l string) bool {
+		return hasLabel(in.PR.Labels, l)
+	}) {
+		add("allow_labels", "needs one of labels: "+strings.Join(p.AllowLabels, ", "))
+	}
+
+	return blockers
+}
+
+// blockingThreadCount returns the number of unresolved threads that block
+// merging, leaving out outdated threads when the policy allows it.
+func blockingThreadCount(threads *CommentsResult, p *MergePolicy) int {
+	if threads == nil {
+		return 0
+	}
+	if p == nil || !p.OutdatedThreadsNonBlocking {
+		return threads.UnresolvedCount
+	}
+	n := 0
+	for _, t := range threads.Threads {
+		if !t.IsResolved && !t.IsOutdated {
+			n++
+		}
+	}
+	return n
+}
+
+// classifyCheck mirrors the GitHub client's check classification, with the
+// policy deciding whether neutral and skipped conclusions pass.
+func classifyCheck(ch CheckRun, p *MergePolicy) OverallStatus {
+	if ch.Status != "completed" {
+		return StatusPending
+	}
+	switch ch.Conclusion {
+	case "success":
+		return StatusPass
+	case "neutral":
+		if p != nil && p.NeutralIsFailure {
+			return StatusFail
+		}
+		return StatusPass
+	case "skipped":
+		if p != nil && p.SkippedIsFailure {
+			return StatusFail
+		}
+		return StatusPass
+	}
+	if IsFailConclusion(ch.Conclusion) {
+		return StatusFail
+	}
+	return StatusPending
+}
+
+// aggregateChecks recomputes the overall status and fail/pending counts under
+// the policy, skipping ignored checks and, when the policy says so, failures
+// the base branch already has.
+func aggregateChecks(checks []CheckRun, p *MergePolicy) (OverallStatus, int, int) {
+	statuses := make([]OverallStatus, 0, len(checks))
+	fail, pending := 0, 0
+	for _, ch := range checks {
+		if p.IsIgnoredCheck(ch.Name) || (p.IgnorePreExistingFailures && preExistingOnly(ch)) {
+			continue
+		}
+		s := classifyCheck(ch, p)
+		switch s {
+		case StatusFail:
+			fail++
+		case StatusPending:
+			pending++
+		}
+		statuses = append(statuses, s)
+	}
+	return AggregateStatus(statuses), fail, pending
+}
+
+// preExistingOnly reports whether a check fails only the way the base branch
+// does: the same check fails there and none of its failed tests is new. A
+// test that was never compared against the base counts as new.
+func preExistingOnly(ch CheckRun) bool {
+	if ch.BaseComparison != BasePreExisting {
+		return false
+	}
+	for _, t := range ch.FailedTests {
+		if t.BaseComparison != BasePreExisting {
+			return false
+		}
+	}
+	return true
+}
+
+type requiredCheckResult int
+
+const (
+	requiredCheckPassed requiredCheckResult = iota
+	requiredCheckMissing
+	requiredCheckPending
+	requiredCheckFailed
+)
+
+// requiredCheckState resolves a required context against check runs and commit
+// statuses by name. GitHub treats neutral and skipped as satisfying the rule
+// unless the policy says otherwise.
+func requiredCheckState(checks []CheckRun, name string, p *MergePolicy) requiredCheckResult {
+	found := false
+	state := requiredCheckPassed
+	for _, ch := range checks {
+		if ch.Name != name {
+			continue
+		}
+		found = true
+		switch classifyCheck(ch, p) {
+		case StatusPending:
+			if state != requiredCheckFailed {
+				state = requiredCheckPending
+			}
+		case StatusFail:
+			state = requiredCheckFailed
+		}
+	}
+	if !found {
+		return requiredCheckMissing
+	}
+	return state
+}
+
+func requiredCheckMessage(name string, state requiredCheckResult) string {
+	switch state {
+	case requiredCheckMissing:
+		return fmt.Sprintf("required check `%s` missing", name)
+	case requiredCheckPending:
+		return fmt.Sprintf("required check `%s` pending", name)
+	case requiredCheckFailed:
+		return fmt.Sprintf("required check `%s` failed", name)
+	}
+	return ""
+}
+
+// latestReviewStates returns each author's most recent decisive review
+// (APPROVED, CHANGES_REQUESTED, or DISMISSED), which is what GitHub counts.
+// Reviews are expected in submission order.
+func latestReviewStates(reviews []Review) map[string]Review {
+	latest := make(map[string]Review)
+	for _, r := range reviews {
+		switch r.State {
+		case ReviewApproved, ReviewChangesRequested, ReviewDismissed:
+			latest[r.Author] = r
+		}
+	}
+	return latest
+}
+
+// countApprovals counts authors whose latest decisive review is an approval.
+// With excludeStale, approvals of an older head commit are not counted.
+func countApprovals(reviews []Review, excludeStale bool, p *MergePolicy) int {
+	n := 0
+	for _, r := range latestReviewStates(reviews) {
+		if r.State != ReviewApproved || (excludeStale && r.IsStale) || ignoresApproval(p, r) {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// countApprovers counts distinct authors with any approving review.
+func countApprovers(reviews []Review, p *MergePolicy) int {
+	seen := make(map[string]bool)
+	for _, r := range reviews {
+		if r.State == ReviewApproved && !ignoresApproval(p, r) {
+			seen[r.Author] = true
+		}
+	}
+	return len(seen)
+}
+
+func ignoresApproval(p *MergePolicy, r Review) bool {
+	return p != nil && p.IgnoreBotApprovals && r.IsBot
+}
+
+// changesRequestedBy lists authors with a CHANGES_REQUESTED review, in first
+// appearance order. With latestOnly, a later approval or dismissal by the same
+// author clears their request, mirroring GitHub's review decision.
+func changesRequestedBy(reviews []Review, latestOnly bool) []string {
+	var latest map[string]Review
+	if latestOnly {
+		latest = latestReviewStates(reviews)
+	}
+	seen := make(map[string]bool)
+	var authors []string
+	for _, r := range reviews {
+		if r.State != ReviewChangesRequested || seen[r.Author] {
+			continue
+		}
+		if latestOnly && latest[r.Author].State != ReviewChangesRequested {
+			continue
+		}
+		seen[r.Author] = true
+		authors = append(authors, r.Author)
+	}
+	return authors
+}
+
+func hasLabel(labels []string, want string) bool {
+	return slices.ContainsFunc(labels, func(l string) bool { return strings.EqualFold(l, want) })
+}
+
+// Plural renders n with the singular or plural form of a noun, e.g.
+// "1 reply" or "3 replies".
+func Plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,421 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvaluateReadiness(t *testing.T) {
+	t.Parallel()
+
+	mixedChecks := &ChecksResult{
+		OverallStatus: StatusFail,
+		FailCount:     1,
+		Checks: []CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success"},
+			{Name: "lint", Status: "completed", Conclusion: "skipped"},
+			{Name: "flaky-optional", Status: "completed", Conclusion: "failure"},
+			{Name: "ci/jenkins", Kind: CheckKindStatus, Status: "pending"},
+		},
+	}
+	unresolved := &CommentsResult{UnresolvedCount: 2}
+	approvedBy := func(authors ...string) []Review {
+		var out []Review
+		for _, a := range authors {
+			out = append(out, Review{Author: a, State: ReviewApproved})
+		}
+		return out
+	}
+	rules := func(mod func(*MergeRequirements)) *MergeRequirements {
+		r := &MergeRequirements{BaseBranch: "main", Sources: []string{"branch_protection"}}
+		mod(r)
+		return r
+	}
+
+	tests := []struct {
+		name string
+		in   ReadinessInput
+		want []MergeBlocker
+	}{
+		{
+			name: "default rules list every blocker",
+			in: ReadinessInput{
+				Threads: unresolved,
+				Checks:  mixedChecks,
+				Reviews: []Review{{Author: "alice", State: ReviewChangesRequested}},
+			},
+			want: []MergeBlocker{
+				{Rule: "unresolved_threads", Source: BlockerSourceDefault, Message: "2 unresolved review threads"},
+				{Rule: "checks", Source: BlockerSourceDefault, Message: "1 check failing"},
+				{Rule: "changes_requested", Source: BlockerSourceDefault, Message: "changes requested by alice"},
+				{Rule: "required_approvals", Source: BlockerSourceDefault, Message: "needs 1 approval, has 0"},
+			},
+		},
+		{
+			name: "rules with no sources fall back to defaults",
+			in: ReadinessInput{
+				Reviews:      approvedBy("alice"),
+				Requirements: &MergeRequirements{BaseBranch: "main"},
+				Threads:      unresolved,
+			},
+			want: []MergeBlocker{
+				{Rule: "unresolved_threads", Source: BlockerSourceDefault, Message: "2 unresolved review threads"},
+			},
+		},
+		{
+			name: "optional failing check and threads do not block under branch rules",
+			in: ReadinessInput{
+				Threads: unresolved,
+				Checks:  mixedChecks,
+				Reviews: []Review{},
+				Requirements: rules(func(r *MergeRequirements) {
+					r.RequiredChecks = []string{"build", "lint"}
+				}),
+			},
+			want: []MergeBlocker{},
+		},
+		{
+			name: "required checks missing, pending, failed",
+			in: ReadinessInput{
+				Checks: mixedChecks,
+				Requirements: rules(func(r *MergeRequirements) {
+					r.RequiredChecks = []string{"deploy", "ci/jenkins", "flaky-optional"}
+				}),
+			},
+			want: []MergeBlocker{
+				{Rule: "required_checks", Source: BlockerSourceBranchRules, Message: "required check `deploy` missing"},
+				{Rule: "required_checks", Source: BlockerSourceBranchRules, Message: "required check `ci/jenkins` pending"},
+				{Rule: "required_checks", Source: BlockerSourceBranchRules, Message: "required check `flaky-optional` failed"},
+			},
+		},
+		{
+			name: "approval count uses latest review per author",
+			in: ReadinessInput{
+				Reviews: []Review{
+					{Author: "alice", State: ReviewApproved},
+					{Author: "alice", State: ReviewApproved},
+					{Author: "bob", State: ReviewApproved},
+					{Author: "bob", State: ReviewDismissed},
+					{Author: "carol", State: ReviewCommented},
+				},
+				Requirements: rules(func(r *MergeRequirements) { r.RequiredApprovals = 2 }),
+			},
+			want: []MergeBlocker{
+				{Rule: "required_approvals", Source: BlockerSourceBranchRules, Message: "needs 2 approvals, has 1"},
+			},
+		},
+		{
+			name: "stale approvals excluded when dismissal on push is enabled",
+			in: ReadinessInput{
+				Reviews: []Review{
+					{Author: "alice", State: ReviewApproved, IsStale: true},
+					{Author: "bob", State: ReviewApproved},
+				},
+				Requirements: rules(func(r *MergeRequirements) {
+					r.RequiredApprovals = 2
+					r.DismissStaleApprovals = true
+				}),
+			},
+			want: []MergeBlocker{
+				{Rule: "required_approvals", Source: BlockerSourceBranchRules, Message: "needs 2 approvals, has 1"},
+			},
+		},
+		{
+			name: "later approval clears a change request",
+			in: ReadinessInput{
+				Reviews: []Review{
+					{Author: "alice", State: ReviewChangesRequested},
+					{Author: "alice", State: ReviewApproved},
+				},
+				Requirements: rules(func(r *MergeRequirements) { r.RequiredApprovals = 1 }),
+			},
+			want: []MergeBlocker{},
+		},
+		{
+			name: "code owner review pending",
+			in: ReadinessInput{
+				Reviews: approvedBy("alice"),
+				PR:      &PullRequestInfo{ReviewDecision: "REVIEW_REQUIRED"},
+				Requirements: rules(func(r *MergeRequirements) {
+					r.RequiredApprovals = 1
+					r.RequireCodeOwnerReview = true
+				}),
+			},
+			want: []MergeBlocker{
+				{Rule: "code_owner_review", Source: BlockerSourceBranchRules, Message: "code owner review required"},
+			},
+		},
+		{
+			name: "unreadable review settings keep the default approval rule",
+			in: ReadinessInput{
+				Reviews:      []Review{},
+				Requirements: rules(func(r *MergeRequirements) { r.ReviewRulesUnknown = true }),
+			},
+			want: []MergeBlocker{
+				{Rule: "required_approvals", Source: BlockerSourceDefault, Message: "needs 1 approval, has 0"},
+			},
+		},
+		{
+			name: "unreadable review settings defer to GitHub's review decision",
+			in: ReadinessInput{
+				Reviews:      approvedBy("alice"),
+				PR:           &PullRequestInfo{ReviewDecision: "REVIEW_REQUIRED"},
+				Requirements: rules(func(r *MergeRequirements) { r.ReviewRulesUnknown = true }),
+			},
+			want: []MergeBlocker{
+				{Rule: "review_required", Source: BlockerSourceBranchRules, Message: "GitHub reports a review is still required"},
+			},
+		},
+		{
+			name: "conversation resolution required",
+			in: ReadinessInput{
+				Threads: &CommentsResult{UnresolvedCount: 1},
+				Requirements: rules(func(r *MergeRequirements) {
+					r.RequireConversationResolution = true
+				}),
+			},
+			want: []MergeBlocker{
+				{Rule: "conversation_resolution", Source: BlockerSourceBranchRules, Message: "1 unresolved review thread"},
+			},
+		},
+		{
+			name: "draft and conflicts block under any rules",
+			in: ReadinessInput{
+				Reviews: approvedBy("alice"),
+				PR: &PullRequestInfo{
+					BaseRef: "main", IsDraft: true, Mergeable: MergeableConflicting, MergeStateStatus: MergeStateDirty,
+				},
+			},
+			want: []MergeBlocker{
+				{Rule: "draft", Source: BlockerSourceGitHub, Message: "pull request is a draft"},
+				{Rule: "merge_conflicts", Source: BlockerSourceGitHub, Message: "merge conflicts with `main`"},
+			},
+		},
+		{
+			name: "behind base blocks only when GitHub requires an up-to-date head",
+			in: ReadinessInput{
+				Reviews:      []Review{},
+				PR:           &PullRequestInfo{BaseRef: "main", MergeStateStatus: MergeStateBehind, BehindBy: 3},
+				Requirements: rules(func(*MergeRequirements) {}),
+			},
+			want: []MergeBlocker{
+				{Rule: "behind_base", Source: BlockerSourceBranchRules, Message: "branch is 3 commits behind `main`"},
+			},
+		},
+		{
+			name: "behind base without up-to-date rule does not block",
+			in: ReadinessInput{
+				Reviews: approvedBy("alice"),
+				PR:      &PullRequestInfo{BaseRef: "main", MergeStateStatus: MergeStateClean, BehindBy: 5},
+			},
+			want: []MergeBlocker{},
+		},
+		{
+			name: "solo skips approvals but not change requests",
+			in: ReadinessInput{
+				Solo:    true,
+				Reviews: []Review{{Author: "bot", State: ReviewChangesRequested}},
+				Requirements: rules(func(r *MergeRequirements) {
+					r.RequiredApprovals = 2
+				}),
+			},
+			want: []MergeBlocker{
+				{Rule: "changes_requested", Source: BlockerSourceBranchRules, Message: "changes requested by bot"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := EvaluateReadiness(tt.in)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("EvaluateReadiness mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluateReadiness_Policy(t *testing.T) {
+	t.Parallel()
+
+	two := 2
+	checks := &ChecksResult{
+		OverallStatus: StatusFail,
+		FailCount:     1,
+		Checks: []CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success"},
+			{Name: "docs", Status: "completed", Conclusion: "skipped"},
+			{Name: "preview/deploy", Status: "completed", Conclusion: "failure"},
+		},
+	}
+	threads := &CommentsResult{
+		UnresolvedCount: 1,
+		Threads:         []ReviewThread{{ID: "t1", IsOutdated: true}},
+	}
+	reviews := []Review{
+		{Author: "alice", State: ReviewApproved},
+		{Author: "renovate[bot]", IsBot: true, State: ReviewApproved},
+	}
+	origins := func(keys ...string) map[string]string {
+		m := make(map[string]string)
+		for _, k := range keys {
+			m[k] = ".github/ghent.yml"
+		}
+		return m
+	}
+	const src = "policy:.github/ghent.yml"
+
+	tests := []struct {
+		name string
+		in   ReadinessInput
+		want []MergeBlocker
+	}{
+		{
+			name: "ignored checks and outdated threads do not block",
+			in: ReadinessInput{
+				Threads: threads,
+				Checks:  checks,
+				Reviews: reviews,
+				Policy: &MergePolicy{
+					IgnoreChecks:               []string{"preview/*"},
+					OutdatedThreadsNonBlocking: true,
+				},
+			},
+			want: []MergeBlocker{},
+		},
+		{
+			name: "pre-existing failures do not block",
+			in: ReadinessInput{
+				Checks: &ChecksResult{OverallStatus: StatusFail, FailCount: 2, Checks: []CheckRun{
+					{Name: "lint", Status: "completed", Conclusion: "failure", BaseComparison: BasePreExisting},
+					{Name: "test", Status: "completed", Conclusion: "failure", BaseComparison: BaseNewFailure},
+				}},
+				Reviews: reviews,
+				Policy:  &MergePolicy{IgnorePreExistingFailures: true},
+			},
+			want: []MergeBlocker{
+				{Rule: "checks", Source: BlockerSourceDefault, Message: "1 check failing"},
+			},
+		},
+		{
+			name: "pre-existing check with new or uncompared tests blocks",
+			in: ReadinessInput{
+				Checks: &ChecksResult{OverallStatus: StatusFail, FailCount: 3, Checks: []CheckRun{
+					{Name: "unit", Status: "completed", Conclusion: "failure", BaseComparison: BasePreExisting,
+						FailedTests: []FailedTest{{Name: "TestOld", BaseComparison: BasePreExisting}}},
+					{Name: "integration", Status: "completed", Conclusion: "failure", BaseComparison: BasePreExisting,
+						FailedTests: []FailedTest{{Name: "TestOld", BaseComparison: BasePreExisting}, {Name: "TestNew", BaseComparison: BaseNewFailure}}},
+					{Name: "e2e", Status: "completed", Conclusion: "failure", BaseComparison: BasePreExisting,
+						FailedTests: []FailedTest{{Name: "TestUncompared"}}},
+				}},
+				Reviews: reviews,
+				Policy:  &MergePolicy{IgnorePreExistingFailures: true},
+			},
+			want: []MergeBlocker{
+				{Rule: "checks", Source: BlockerSourceDefault, Message: "2 checks failing"},
+			},
+		},
+		{
+			name: "skipped counts as failure",
+			in: ReadinessInput{
+				Checks:  checks,
+				Reviews: reviews,
+				Policy:  &MergePolicy{IgnoreChecks: []string{"preview/*"}, SkippedIsFailure: true},
+			},
+			want: []MergeBlocker{
+				{Rule: "checks", Source: BlockerSourceDefault, Message: "1 check failing"},
+			},
+		},
+		{
+			name: "min approvals excludes bots",
+			in: ReadinessInput{
+				Reviews: reviews,
+				Policy: &MergePolicy{
+					MinApprovals: &two, IgnoreBotApprovals: true,
+					Origins: origins("min_approvals", "ignore_bot_approvals"),
+				},
+			},
+			want: []MergeBlocker{
+				{Rule: "required_approvals", Source: src, Message: "needs 2 approvals, has 1"},
+			},
+		},
+		{
+			name: "policy min approvals raises branch rule count",
+			in: ReadinessInput{
+				Reviews: reviews,
+				Requirements: &MergeRequirements{
+					BaseBranch: "main", RequiredApprovals: 1, Sources: []string{"branch_protection"},
+				},
+				Policy: &MergePolicy{MinApprovals: &two, IgnoreBotApprovals: true, Origins: origins("min_approvals")},
+			},
+			want: []MergeBlocker{
+				{Rule: "required_approvals", Source: src, Message: "needs 2 approvals, has 1"},
+			},
+		},
+		{
+			name: "extra required checks under branch rules",
+			in: ReadinessInput{
+				Checks:  checks,
+				Reviews: reviews,
+				Requirements: &MergeRequirements{
+					BaseBranch: "main", RequiredChecks: []string{"build"}, Sources: []string{"ruleset:7"},
+				},
+				Policy: &MergePolicy{
+					RequiredChecks: []string{"build", "preview/deploy", "e2e"},
+					Origins:        origins("required_checks"),
+				},
+			},
+			want: []MergeBlocker{
+				{Rule: "required_checks", Source: src, Message: "required check `preview/deploy` failed"},
+				{Rule: "required_checks", Source: src, Message: "required check `e2e` missing"},
+			},
+		},
+		{
+			name: "labels block or permit merging",
+			in: ReadinessInput{
+				Reviews: reviews,
+				PR:      &PullRequestInfo{Number: 1, Labels: []string{"Do-Not-Merge"}},
+				Policy: &MergePolicy{
+					BlockingLabels: []string{"do-not-merge", "wip"},
+					AllowLabels:    []string{"ready", "automerge"},
+					Origins:        origins("blocking_labels", "allow_labels"),
+				},
+			},
+			want: []MergeBlocker{
+				{Rule: "blocking_labels", Source: src, Message: "label `do-not-merge` blocks merging"},
+				{Rule: "allow_labels", Source: src, Message: "needs one of labels: ready, automerge"},
+			},
+		},
+		{
+			name: "label rules without PR metadata block",
+			in: ReadinessInput{
+				Reviews: reviews,
+				Policy:  &MergePolicy{AllowLabels: []string{"ready"}},
+			},
+			want: []MergeBlocker{
+				{Rule: "labels", Source: BlockerSourcePolicy, Message: "pull request labels unavailable"},
+			},
+		},
+		{
+			name: "solo skips policy min approvals",
+			in: ReadinessInput{
+				Solo:    true,
+				Reviews: []Review{},
+				Policy:  &MergePolicy{MinApprovals: &two},
+			},
+			want: []MergeBlocker{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := EvaluateReadiness(tt.in)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("EvaluateReadiness mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ type ReviewThread struct {
 	Path               string    `json:"path"`
 	Line               int       `json:"line"`
 	StartLine          int       `json:"start_line,omitempty"`
+	OriginalLine       int       `json:"original_line,omitempty"`       // line when the thread was started
+	OriginalStartLine  int       `json:"original_start_line,omitempty"` // set for multi-line threads
 	DiffSide           string    `json:"diff_side,omitempty"`
 	IsResolved         bool      `json:"is_resolved"`
 	IsOutdated         bool      `json:"is_outdated"`
@@ -15,6 +17,19 @@ type ReviewThread struct {
 	ViewerCanUnresolve bool      `json:"viewer_can_unresolve"`
 	ViewerCanReply     bool      `json:"viewer_can_reply"`
 	Comments           []Comment `json:"comments"`
+	Truncated          bool      `json:"truncated,omitempty"` // comments were capped; the newest are missing
+
+	// Set by code change analysis: whether the commented lines differ at the
+	// PR head, and the commits since the comment that touched them.
+	CodeChangedSinceComment *bool       `json:"code_changed_since_comment,omitempty"`
+	ChangedBy               []CommitRef `json:"changed_by,omitempty"`
+}
+
+// CommitRef identifies a commit by SHA, headline, and commit time.
+type CommitRef struct {
+	SHA         string    `json:"sha"`
+	Message     string    `json:"message"`
+	CommittedAt time.Time `json:"committed_at"`
 }
 
 // Comment represents a single comment within a review thread.
@@ -30,6 +45,56 @@ type Comment struct {
 	Path       string    `json:"path,omitempty"`
 }
 
+// Conversation entry kinds.
+const (
+	ConversationKindComment = "comment" // top-level PR (issue) comment
+	ConversationKindReview  = "review"  // summary body of a submitted review
+)
+
+// ConversationComment is PR feedback that is not attached to a line: a
+// top-level conversation comment or the body of a review.
+type ConversationComment struct {
+	ID              string      `json:"id"`
+	DatabaseID      int64       `json:"database_id,omitempty"`
+	Kind            string      `json:"kind"`
+	Author          string      `json:"author"`
+	IsBot           bool        `json:"is_bot"`
+	Body            string      `json:"body"`
+	ReviewState     ReviewState `json:"review_state,omitempty"` // set for Kind == "review"
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	URL             string      `json:"url"`
+	IsMinimized     bool        `json:"is_minimized"`
+	MinimizedReason string      `json:"minimized_reason,omitempty"` // e.g. OUTDATED, RESOLVED, SPAM
+	HasReply        bool        `json:"has_reply"`                  // a different author posted afterwards
+}
+
+// LastActivity returns when the entry was last written. Bots such as
+// CodeRabbit edit their summary comment in place on every push.
+func (c ConversationComment) LastActivity() time.Time {
+	if c.UpdatedAt.After(c.CreatedAt) {
+		return c.UpdatedAt
+	}
+	return c.CreatedAt
+}
+
+// IsUnanswered reports whether the entry is visible and nobody has
+// responded to it yet.
+func (c ConversationComment) IsUnanswered() bool {
+	return !c.HasReply && !c.IsMinimized
+}
+
+// MarkConversationReplies sets HasReply on each entry that is followed by an
+// entry from a different author. entries must be sorted by CreatedAt.
+func MarkConversationReplies(entries []ConversationComment) {
+	later := map[string]bool{} // authors seen after the current entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		author := entries[i].Author
+		entries[i].HasReply = len(later) > 1 || (len(later) == 1 && !later[author])
+		later[author] = true
+	}
+}
+
 // IsBotOriginated reports whether the thread was started by a bot.
 func (t ReviewThread) IsBotOriginated() bool {
 	return len(t.Comments) > 0 && t.Comments[0].IsBot
@@ -50,6 +115,11 @@ type CommentsResult struct {
 	BotThreadCount  int            `json:"bot_thread_count"`
 	UnansweredCount int            `json:"unanswered_count"`
 	Since           string         `json:"since,omitempty"`
+
+	// Conversation holds top-level PR comments and review bodies, oldest first.
+	Conversation                []ConversationComment `json:"conversation"`
+	ConversationCount           int                   `json:"conversation_count"`
+	UnansweredConversationCount int                   `json:"unanswered_conversation_count"`
 }
 
 // CommentGroup represents a group of threads under a common key.
@@ -78,11 +148,12 @@ const (
 )
 
 // IsFailConclusion returns true if a check run conclusion is classified as a failure.
-// This includes: failure, timed_out, action_required, startup_failure, stale, cancelled.
+// This includes: failure, timed_out, action_required, startup_failure, stale, cancelled,
+// and error (the commit status state for a crashed external CI).
 // Matches the classification in github.classifyCheckStatus.
 func IsFailConclusion(conclusion string) bool {
 	switch conclusion {
-	case "failure", "timed_out", "action_required", "startup_failure", "stale", "cancelled":
+	case "failure", "timed_out", "action_required", "startup_failure", "stale", "cancelled", "error":
 		return true
 	default:
 		return false
@@ -103,17 +174,111 @@ func AggregateStatus(statuses []OverallStatus) OverallStatus {
 	return result
 }
 
-// CheckRun represents a CI check run.
+// CheckKind distinguishes Checks API runs from legacy commit statuses.
+type CheckKind string
+
+const (
+	CheckKindCheckRun CheckKind = "check_run" // Checks API (GitHub Actions, GitHub Apps)
+	CheckKindStatus   CheckKind = "status"    // Statuses API (Jenkins, Buildkite, CircleCI, ...)
+)
+
+// CheckRun represents a CI check run or a commit status context.
+// Commit statuses are mapped onto the check-run vocabulary: state "pending"
+// becomes Status "pending", and success/failure/error become a "completed"
+// Status with the state as Conclusion.
 type CheckRun struct {
 	ID          int64        `json:"id"`
+	Kind        CheckKind    `json:"kind"`
 	Name        string       `json:"name"`
-	Status      string       `json:"status"`     // queued, in_progress, completed
-	Conclusion  string       `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	Status      string       `json:"status"`     // queued, in_progress, pending, completed
+	Conclusion  string       `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required, error
+	Description string       `json:"description,omitempty"`
 	StartedAt   time.Time    `json:"started_at"`
 	CompletedAt time.Time    `json:"completed_at,omitzero"`
 	HTMLURL     string       `json:"html_url"`
 	Annotations []Annotation `json:"annotations,omitempty"`
 	LogExcerpt  string       `json:"log_excerpt,omitempty"`
+	FailedTests []FailedTest `json:"failed_tests,omitempty"`
+	FailedSteps []JobStep    `json:"failed_steps,omitempty"`
+	Flaky       *FlakyCheck  `json:"flaky,omitempty"` // set by flakiness analysis
+
+	// Set by base-branch comparison: how this check relates to the same
+	// check on the base branch head, and that check's conclusion.
+	BaseComparison string `json:"base_comparison,omitempty"` // new_failure, pre_existing, fixed_by_pr
+	BaseConclusion string `json:"base_conclusion,omitempty"`
+}
+
+// IsCommitStatus reports whether the entry came from the Statuses API.
+// Commit statuses have no job logs, annotations, or Actions re-run support.
+func (c CheckRun) IsCommitStatus() bool {
+	return c.Kind == CheckKindStatus
+}
+
+// Base-branch comparison classifications of checks and failed tests.
+const (
+	BaseNewFailure  = "new_failure"  // fails on the PR, passes (or is absent) on the base branch
+	BasePreExisting = "pre_existing" // fails on both the PR and the base branch
+	BaseFixedByPR   = "fixed_by_pr"  // passes on the PR, fails on the base branch
+)
+
+// Flakiness reasons.
+const (
+	// FlakyReasonSameCode: the check both passed and failed on identical
+	// code, through re-run attempts or commits with the same tree.
+	FlakyReasonSameCode = "same_code"
+	// FlakyReasonFlipFlop: the check kept flipping between pass and fail
+	// across the PR's recent commits.
+	FlakyReasonFlipFlop = "flip_flop"
+)
+
+// FlakyCheck is the evidence that a check is flaky: its pass/fail outcomes
+// over the PR's recent commits and run attempts.
+type FlakyCheck struct {
+	Reason  string         `json:"reason"`  // same_code or flip_flop
+	Flips   int            `json:"flips"`   // pass↔fail changes along History
+	Passes  int            `json:"passes"`  // passing outcomes in History
+	Fails   int            `json:"fails"`   // failing outcomes in History
+	History []CheckOutcome `json:"history"` // oldest first
+}
+
+// CheckOutcome is one completed run of a check on a PR commit.
+type CheckOutcome struct {
+	SHA         string    `json:"sha"`
+	CheckRunID  int64     `json:"check_run_id"`
+	Conclusion  string    `json:"conclusion"`
+	CompletedAt time.Time `json:"completed_at,omitzero"`
+}
+
+// CommitCheckRuns is every check run attempt on one PR commit. TreeSHA
+// identifies the code: commits sharing it ran on identical files.
+type CommitCheckRuns struct {
+	SHA     string
+	TreeSHA string
+	Checks  []CheckRun
+}
+
+// JobStep is one step of a GitHub Actions job, as listed by the jobs API.
+type JobStep struct {
+	Number          int       `json:"number"`
+	Name            string    `json:"name"`
+	Status          string    `json:"status"`
+	Conclusion      string    `json:"conclusion"`
+	StartedAt       time.Time `json:"started_at,omitzero"`
+	CompletedAt     time.Time `json:"completed_at,omitzero"`
+	DurationSeconds int       `json:"duration_seconds"` // whole seconds from start to completion
+}
+
+// FailedTest is one failing test recognized in a job log.
+type FailedTest struct {
+	Name      string `json:"name"`
+	Package   string `json:"package,omitempty"` // Go package, Python/JS test file, Rust crate, or Java class
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Output    string `json:"output,omitempty"` // the test's own log lines, capped
+	Framework string `json:"framework"`        // go, pytest, jest, cargo, junit
+
+	BaseComparison string `json:"base_comparison,omitempty"` // new_failure or pre_existing, when compared
 }
 
 // Annotation represents a check run annotation (lint error, test failure, etc.).
@@ -136,6 +301,8 @@ type ChecksResult struct {
 	FailCount     int           `json:"fail_count"`
 	PendingCount  int           `json:"pending_count"`
 	Since         string        `json:"since,omitempty"`
+	BaseRef       string        `json:"base_ref,omitempty"` // set by base-branch comparison
+	BaseSHA       string        `json:"base_sha,omitempty"`
 }
 
 // ReviewState represents the state of a PR review.
@@ -172,6 +339,9 @@ type ReplyResult struct {
 	CreatedAt    time.Time      `json:"created_at"`
 	Resolved     *ResolveResult `json:"resolved,omitempty"`
 	ResolveError string         `json:"resolve_error,omitempty"`
+	// Set when the thread already had a comment with the reply's
+	// idempotency key, which is returned instead of posting again.
+	AlreadyPosted bool `json:"already_posted,omitempty"`
 }
 
 // ResolveResult represents the result of resolving/unresolving a single thread.
@@ -229,6 +399,241 @@ type DismissResults struct {
 	DryRun       bool            `json:"dry_run,omitempty"`
 }
 
+// Branch update methods accepted by UpdateBranch.
+const (
+	UpdateMethodMerge  = "merge"
+	UpdateMethodRebase = "rebase"
+)
+
+// UpdateBranchResult represents the outcome of bringing a PR head up to date
+// with its base branch.
+type UpdateBranchResult struct {
+	PRNumber        int    `json:"pr_number"`
+	BaseRef         string `json:"base_ref"`
+	Method          string `json:"method"`
+	BehindBy        int    `json:"behind_by"`
+	PreviousHeadSHA string `json:"previous_head_sha"`
+	HeadSHA         string `json:"head_sha,omitempty"`
+	Updated         bool   `json:"updated"`
+	Action          string `json:"action"` // updated, up_to_date, would_update
+	DryRun          bool   `json:"dry_run,omitempty"`
+}
+
+// Merge methods accepted by the merge command.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// Merge modes: merge now, enable auto-merge, or add to the merge queue.
+const (
+	MergeModeDirect = "direct"
+	MergeModeAuto   = "auto"
+	MergeModeQueue  = "queue"
+)
+
+// MergeRequest describes how to merge a pull request.
+type MergeRequest struct {
+	Method         string // MergeMethodMerge, MergeMethodSquash, or MergeMethodRebase
+	CommitHeadline string // optional; GitHub's default when empty
+	CommitBody     string // optional; GitHub's default when empty
+}
+
+// MergeResult represents the outcome of the merge command.
+type MergeResult struct {
+	PRNumber       int            `json:"pr_number"`
+	Method         string         `json:"method"`
+	Mode           string         `json:"mode"` // direct, auto, queue
+	HeadSHA        string         `json:"head_sha"`
+	Action         string         `json:"action"` // merged, auto_merge_enabled, enqueued, would_merge, blocked, head_changed, failed
+	Merged         bool           `json:"merged"`
+	MergeCommitSHA string         `json:"merge_commit_sha,omitempty"`
+	Blockers       []MergeBlocker `json:"blockers"`
+	Deferred       []MergeBlocker `json:"deferred,omitempty"` // branch rules GitHub enforces before an auto/queued merge
+	Error          string         `json:"error,omitempty"`
+	DryRun         bool           `json:"dry_run,omitempty"`
+}
+
+// Rerun actions reported per workflow run or job.
+const (
+	RerunActionRerunFailed = "rerun_failed" // failed jobs of a run re-run
+	RerunActionRerunJob    = "rerun_job"    // one job re-run
+	RerunActionCancel      = "cancel"       // run cancelled
+)
+
+// RerunResult is the outcome of re-running or cancelling one workflow run or
+// job. In a dry run, Action is prefixed with "would_" and Success is false.
+type RerunResult struct {
+	RunID   int64    `json:"run_id"`
+	JobID   int64    `json:"job_id,omitempty"` // single-job re-runs
+	Checks  []string `json:"checks"`           // names of the check runs covered
+	Action  string   `json:"action"`
+	Success bool     `json:"success"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// RerunResults is the outcome of the rerun command.
+type RerunResults struct {
+	PRNumber     int           `json:"pr_number"`
+	Results      []RerunResult `json:"results"`
+	SuccessCount int           `json:"success_count"`
+	FailureCount int           `json:"failure_count"`
+	DryRun       bool          `json:"dry_run,omitempty"`
+}
+
+// Suggestion statuses. In a dry run, applicable suggestions are reported as
+// would_apply.
+const (
+	SuggestionApplicable     = "applicable"      // the lines still read as the reviewer saw them
+	SuggestionAlreadyApplied = "already_applied" // the lines already read as suggested
+	SuggestionConflict       = "conflict"        // the lines changed, or another suggestion touches them
+	SuggestionApplied        = "applied"
+	SuggestionWouldApply     = "would_apply"
+	SuggestionFailed         = "failed" // the file could not be written
+)
+
+// Suggestion is one ```suggestion block of a review comment, located in the
+// local working tree. StartLine and EndLine are the local lines it replaces;
+// they differ from the thread's lines when the code has moved since.
+type Suggestion struct {
+	ThreadID    string   `json:"thread_id"`
+	CommentID   string   `json:"comment_id"`
+	Author      string   `json:"author"`
+	IsBot       bool     `json:"is_bot"`
+	URL         string   `json:"url"`
+	Path        string   `json:"path"`
+	StartLine   int      `json:"start_line,omitempty"`
+	EndLine     int      `json:"end_line,omitempty"`
+	Original    []string `json:"original"`    // lines the comment was made on
+	Replacement []string `json:"replacement"` // empty deletes the lines
+	Status      string   `json:"status"`
+	Reason      string   `json:"reason,omitempty"` // why it conflicts, or where it moved
+	Diff        string   `json:"diff,omitempty"`   // unified diff against the local file
+	Replied     bool     `json:"replied,omitempty"`
+	Resolved    bool     `json:"resolved,omitempty"`
+	Error       string   `json:"error,omitempty"` // write, reply, or resolve failure
+}
+
+// SuggestionResults is the outcome of the suggestions command.
+type SuggestionResults struct {
+	PRNumber        int          `json:"pr_number"`
+	Suggestions     []Suggestion `json:"suggestions"`
+	ApplicableCount int          `json:"applicable_count"` // applicable or would_apply
+	AppliedCount    int          `json:"applied_count"`
+	ConflictCount   int          `json:"conflict_count"`
+	FailureCount    int          `json:"failure_count"`
+	DryRun          bool         `json:"dry_run,omitempty"`
+}
+
+// Plan action kinds accepted by the apply command.
+const (
+	PlanReply     = "reply"
+	PlanResolve   = "resolve"
+	PlanUnresolve = "unresolve"
+	PlanDismiss   = "dismiss"
+)
+
+// Plan action statuses.
+const (
+	PlanStatusDone     = "done"
+	PlanStatusWouldRun = "would_run" // dry run: valid and would be executed
+	PlanStatusNoop     = "noop"      // the thread is already in the requested state, or the reply was already posted
+	PlanStatusInvalid  = "invalid"   // failed validation, so nothing in the plan ran
+	PlanStatusFailed   = "failed"
+	PlanStatusSkipped  = "skipped" // not run because of another action's failure
+)
+
+// PlanAction is one entry of an apply plan: a reply, resolve, or unresolve
+// on a review thread, or the dismissal of a stale blocking review.
+type PlanAction struct {
+	Action   string `json:"action"`
+	ThreadID string `json:"thread_id,omitempty"` // reply, resolve, unresolve
+	ReviewID string `json:"review_id,omitempty"` // dismiss: node ID or numeric ID
+	Body     string `json:"body,omitempty"`      // reply
+	Message  string `json:"message,omitempty"`   // dismiss
+
+	// IdempotencyKey makes a reply post at most once per thread.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// PlanActionResult is the outcome of one plan action.
+type PlanActionResult struct {
+	Index int `json:"index"` // position in the plan, from 0
+	PlanAction
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	URL    string `json:"url,omitempty"` // the posted reply
+}
+
+// PlanResults is the outcome of the apply command, in plan order.
+type PlanResults struct {
+	PRNumber     int                `json:"pr_number"`
+	Results      []PlanActionResult `json:"results"`
+	SuccessCount int                `json:"success_count"` // done, noop, or would_run
+	FailureCount int                `json:"failure_count"` // failed or invalid
+	SkippedCount int                `json:"skipped_count"`
+	DryRun       bool               `json:"dry_run,omitempty"`
+}
+
+// Audited mutation actions.
+const (
+	AuditResolve   = "resolve"
+	AuditUnresolve = "unresolve"
+	AuditReply     = "reply"
+	AuditDismiss   = "dismiss"
+)
+
+// Audit entry outcomes.
+const (
+	AuditSuccess       = "success"
+	AuditFailure       = "failure"
+	AuditAlreadyPosted = "already_posted" // idempotent reply found in the thread; nothing posted
+)
+
+// AuditEntry is one line of the local mutation audit log: a single resolve,
+// unresolve, reply, or dismiss call made on GitHub.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Repo      string    `json:"repo,omitempty"` // OWNER/REPO; empty for a failed resolve or unresolve
+	PR        int       `json:"pr,omitempty"`
+	Target    string    `json:"target"` // thread or review node ID
+	Action    string    `json:"action"`
+	BodyHash  string    `json:"body_hash,omitempty"` // sha256 of the reply body or dismissal message
+	CommentID int64     `json:"comment_id,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+	Command   string    `json:"command"` // the invoking command line
+}
+
+// AuditResult is the output of the audit command: the matching entries of
+// the log at Path, oldest first.
+type AuditResult struct {
+	Path    string       `json:"path"`
+	Entries []AuditEntry `json:"entries"`
+}
+
+// JobLogResult is the cleaned, optionally filtered log of the Actions job
+// behind one check run, returned by the logs command.
+type JobLogResult struct {
+	PRNumber   int       `json:"pr_number"`
+	CheckID    int64     `json:"check_id"`
+	CheckName  string    `json:"check_name"`
+	Conclusion string    `json:"conclusion"`
+	HTMLURL    string    `json:"html_url"`
+	Step       *JobStep  `json:"step,omitempty"`  // set by --step
+	Steps      []JobStep `json:"steps,omitempty"` // all steps of the job
+	TotalLines int       `json:"total_lines"`     // lines in the job (or step) before filtering
+	Lines      []LogLine `json:"lines"`
+}
+
+// LogLine is one cleaned job log line. Number is its 1-based position in the
+// full job log, so gaps left by filtering stay visible.
+type LogLine struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
 // ReviewWatchPhase represents the current phase of the review-await watch mode.
 type ReviewWatchPhase string
 
@@ -367,15 +772,25 @@ type WatchStatus struct {
 
 // StatusResult combines all PR data for the status command.
 type StatusResult struct {
-	PRNumber      int               `json:"pr_number"`
-	Comments      CommentsResult    `json:"comments"`
-	Checks        ChecksResult      `json:"checks"`
-	Reviews       []Review          `json:"reviews"`
-	StaleReviews  []Review          `json:"stale_reviews"`
-	IsMergeReady  bool              `json:"is_merge_ready"`
-	PRAge         string            `json:"pr_age,omitempty"`
-	LastUpdate    string            `json:"last_update,omitempty"`
-	ReviewCycles  int               `json:"review_cycles,omitempty"`
-	ReviewMonitor *ReviewMonitor    `json:"review_monitor,omitempty"`
-	ReviewSettled *ReviewSettlement `json:"review_settled,omitempty"`
+	PRNumber          int                `json:"pr_number"`
+	Comments          CommentsResult     `json:"comments"`
+	Checks            ChecksResult       `json:"checks"`
+	Reviews           []Review           `json:"reviews"`
+	StaleReviews      []Review           `json:"stale_reviews"`
+	IsMergeReady      bool               `json:"is_merge_ready"`
+	Blockers          []MergeBlocker     `json:"blockers"`
+	MergeRequirements *MergeRequirements `json:"merge_requirements,omitempty"`
+	MergePolicy       *MergePolicy       `json:"merge_policy,omitempty"`
+	BaseRef           string             `json:"base_ref,omitempty"`
+	IsDraft           bool               `json:"is_draft"`
+	Mergeable         string             `json:"mergeable,omitempty"` // MERGEABLE, CONFLICTING, UNKNOWN
+	MergeStateStatus  string             `json:"merge_state_status,omitempty"`
+	HasConflicts      bool               `json:"has_conflicts"`
+	IsBehind          bool               `json:"is_behind"`
+	BehindBy          int                `json:"behind_by,omitempty"`
+	PRAge             string             `json:"pr_age,omitempty"`
+	LastUpdate        string             `json:"last_update,omitempty"`
+	ReviewCycles      int                `json:"review_cycles,omitempty"`
+	ReviewMonitor     *ReviewMonitor     `json:"review_monitor,omitempty"`
+	ReviewSettled     *ReviewSettlement  `json:"review_settled,omitempty"`
 }
@@ -210,3 +210,45 @@ func keys(m map[string]json.RawMessage) []string {
 	}
 	return ks
 }
+
+func TestMarkConversationReplies(t *testing.T) {
+	t.Parallel()
+
+	entries := []ConversationComment{
+		{ID: "1", Author: "coderabbitai"},
+		{ID: "2", Author: "copilot"},
+		{ID: "3", Author: "alice"},
+		{ID: "4", Author: "alice"},
+		{ID: "5", Author: "bob", IsMinimized: true},
+		{ID: "6", Author: "bob"},
+	}
+	MarkConversationReplies(entries)
+
+	// An entry is answered once someone else posts after it; bob only follows himself.
+	want := map[string]bool{"1": true, "2": true, "3": true, "4": true, "5": false, "6": false}
+	for _, e := range entries {
+		if e.HasReply != want[e.ID] {
+			t.Errorf("entry %s HasReply = %v, want %v", e.ID, e.HasReply, want[e.ID])
+		}
+	}
+	if entries[4].IsUnanswered() {
+		t.Error("minimized entry reported as unanswered")
+	}
+	if !entries[5].IsUnanswered() {
+		t.Error("last entry should be unanswered")
+	}
+}
+
+func TestConversationCommentLastActivity(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC)
+	edited := created.Add(3 * time.Hour)
+
+	if got := (ConversationComment{CreatedAt: created}).LastActivity(); !got.Equal(created) {
+		t.Errorf("LastActivity() = %v, want %v", got, created)
+	}
+	if got := (ConversationComment{CreatedAt: created, UpdatedAt: edited}).LastActivity(); !got.Equal(edited) {
+		t.Errorf("LastActivity() = %v, want %v", got, edited)
+	}
+}
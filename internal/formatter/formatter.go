@@ -1,4 +1,5 @@
-// Package formatter provides pipe-mode output formatters (JSON, XML, Markdown).
+// Package formatter provides pipe-mode output formatters (JSON, XML, Markdown,
+// quickfix, SARIF, JUnit).
 package formatter
 
 import (
@@ -16,6 +17,12 @@ func New(format string) (domain.Formatter, error) {
 		return &MarkdownFormatter{}, nil
 	case "xml":
 		return &XMLFormatter{}, nil
+	case "quickfix":
+		return &QuickfixFormatter{}, nil
+	case "sarif":
+		return &SARIFFormatter{}, nil
+	case "junit":
+		return &JUnitFormatter{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %q", format)
 	}
@@ -34,6 +34,34 @@ func (f *JSONFormatter) FormatDismissResults(w io.Writer, result *domain.Dismiss
 	return encodeJSON(w, result)
 }
 
+func (f *JSONFormatter) FormatUpdateBranch(w io.Writer, result *domain.UpdateBranchResult) error {
+	return encodeJSON(w, result)
+}
+
+func (f *JSONFormatter) FormatMergeResult(w io.Writer, result *domain.MergeResult) error {
+	return encodeJSON(w, result)
+}
+
+func (f *JSONFormatter) FormatRerunResults(w io.Writer, result *domain.RerunResults) error {
+	return encodeJSON(w, result)
+}
+
+func (f *JSONFormatter) FormatSuggestions(w io.Writer, result *domain.SuggestionResults) error {
+	return encodeJSON(w, result)
+}
+
+func (f *JSONFormatter) FormatPlanResults(w io.Writer, result *domain.PlanResults) error {
+	return encodeJSON(w, result)
+}
+
+func (f *JSONFormatter) FormatAudit(w io.Writer, result *domain.AuditResult) error {
+	return encodeJSON(w, result)
+}
+
+func (f *JSONFormatter) FormatJobLog(w io.Writer, result *domain.JobLogResult) error {
+	return encodeJSON(w, result)
+}
+
 func (f *JSONFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) error {
 	return encodeJSON(w, result)
 }
@@ -51,10 +79,28 @@ func (f *JSONFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRe
 		Level   string `json:"level"`
 		Message string `json:"message"`
 	}
+	type compactFailedTest struct {
+		Name           string `json:"name"`
+		File           string `json:"file,omitempty"`
+		Line           int    `json:"line,omitempty"`
+		Message        string `json:"message,omitempty"`
+		BaseComparison string `json:"base_comparison,omitempty"`
+	}
 	type compactFailedCheck struct {
-		Name        string              `json:"name"`
-		Annotations []compactAnnotation `json:"annotations,omitempty"`
-		LogExcerpt  string              `json:"log_excerpt,omitempty"`
+		Name           string              `json:"name"`
+		Flaky          string              `json:"flaky,omitempty"` // flakiness reason
+		BaseComparison string              `json:"base_comparison,omitempty"`
+		FailedSteps    []string            `json:"failed_steps,omitempty"`
+		Annotations    []compactAnnotation `json:"annotations,omitempty"`
+		FailedTests    []compactFailedTest `json:"failed_tests,omitempty"`
+		LogExcerpt     string              `json:"log_excerpt,omitempty"`
+	}
+	type compactConversation struct {
+		Kind        string `json:"kind"`
+		Author      string `json:"author"`
+		IsBot       bool   `json:"is_bot,omitempty"`
+		URL         string `json:"url"`
+		BodyPreview string `json:"body_preview"`
 	}
 	type compactReview struct {
 		ID         string `json:"id"`
@@ -71,11 +117,18 @@ func (f *JSONFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRe
 		PRAge         string                   `json:"pr_age,omitempty"`
 		LastUpdate    string                   `json:"last_update,omitempty"`
 		ReviewCycles  int                      `json:"review_cycles,omitempty"`
+		Blockers      []domain.MergeBlocker    `json:"blockers,omitempty"`
+		IsDraft       bool                     `json:"is_draft,omitempty"`
+		HasConflicts  bool                     `json:"has_conflicts,omitempty"`
+		IsBehind      bool                     `json:"is_behind,omitempty"`
+		MergeState    string                   `json:"merge_state_status,omitempty"`
 		Unresolved    int                      `json:"unresolved"`
 		CheckStatus   string                   `json:"check_status"`
 		PassCount     int                      `json:"pass_count"`
 		FailCount     int                      `json:"fail_count"`
 		Threads       []compactThread          `json:"threads,omitempty"`
+		Unanswered    int                      `json:"unanswered_conversation,omitempty"`
+		Conversation  []compactConversation    `json:"conversation,omitempty"` // unanswered entries only
 		FailedChecks  []compactFailedCheck     `json:"failed_checks,omitempty"`
 		StaleReviews  []compactReview          `json:"stale_reviews,omitempty"`
 		ReviewMonitor *domain.ReviewMonitor    `json:"review_monitor,omitempty"`
@@ -88,7 +141,13 @@ func (f *JSONFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRe
 		PRAge:         result.PRAge,
 		LastUpdate:    result.LastUpdate,
 		ReviewCycles:  result.ReviewCycles,
+		Blockers:      result.Blockers,
+		IsDraft:       result.IsDraft,
+		HasConflicts:  result.HasConflicts,
+		IsBehind:      result.IsBehind,
+		MergeState:    result.MergeStateStatus,
 		Unresolved:    result.Comments.UnresolvedCount,
+		Unanswered:    result.Comments.UnansweredConversationCount,
 		CheckStatus:   string(result.Checks.OverallStatus),
 		PassCount:     result.Checks.PassCount,
 		FailCount:     result.Checks.FailCount,
@@ -113,6 +172,23 @@ func (f *JSONFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRe
 		})
 	}
 
+	for _, c := range result.Comments.Conversation {
+		if !c.IsUnanswered() {
+			continue
+		}
+		preview := c.Body
+		if len(preview) > 80 {
+			preview = preview[:80] + "..."
+		}
+		compact.Conversation = append(compact.Conversation, compactConversation{
+			Kind:        c.Kind,
+			Author:      c.Author,
+			IsBot:       c.IsBot,
+			URL:         c.URL,
+			BodyPreview: preview,
+		})
+	}
+
 	for _, ch := range result.Checks.Checks {
 		if !domain.IsFailConclusion(ch.Conclusion) {
 			continue
@@ -121,6 +197,13 @@ func (f *JSONFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRe
 			Name:       ch.Name,
 			LogExcerpt: ch.LogExcerpt,
 		}
+		if ch.Flaky != nil {
+			fc.Flaky = ch.Flaky.Reason
+		}
+		fc.BaseComparison = ch.BaseComparison
+		for _, st := range ch.FailedSteps {
+			fc.FailedSteps = append(fc.FailedSteps, st.Name)
+		}
 		for _, a := range ch.Annotations {
 			fc.Annotations = append(fc.Annotations, compactAnnotation{
 				Path:    a.Path,
@@ -129,6 +212,15 @@ func (f *JSONFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRe
 				Message: a.Message,
 			})
 		}
+		for _, t := range ch.FailedTests {
+			fc.FailedTests = append(fc.FailedTests, compactFailedTest{
+				Name:           t.Name,
+				File:           t.File,
+				Line:           t.Line,
+				Message:        t.Message,
+				BaseComparison: t.BaseComparison,
+			})
+		}
 		compact.FailedChecks = append(compact.FailedChecks, fc)
 	}
 
@@ -277,6 +277,18 @@ func sampleDismissResults() *domain.DismissResults {
 	}
 }
 
+func sampleRerunResults() *domain.RerunResults {
+	return &domain.RerunResults{
+		PRNumber: 42,
+		Results: []domain.RerunResult{
+			{RunID: 100, Checks: []string{"lint", "test"}, Action: domain.RerunActionRerunFailed, Success: true},
+			{JobID: 21, Checks: []string{"e2e"}, Action: domain.RerunActionRerunFailed, Error: "resolve workflow run of e2e: not found"},
+		},
+		SuccessCount: 1,
+		FailureCount: 1,
+	}
+}
+
 func TestJSONStatusValid(t *testing.T) {
 	var buf bytes.Buffer
 	f := &JSONFormatter{}
@@ -818,3 +830,55 @@ func TestJSONDismissResultsValid(t *testing.T) {
 		t.Errorf("results[0].commit_id = %v, want deadbeefcafebabe", first["commit_id"])
 	}
 }
+
+func TestJSONRerunResultsValid(t *testing.T) {
+	var buf bytes.Buffer
+	f := &JSONFormatter{}
+
+	if err := f.FormatRerunResults(&buf, sampleRerunResults()); err != nil {
+		t.Fatalf("FormatRerunResults: %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput:\n%s", err, buf.String())
+	}
+
+	results, ok := parsed["results"].([]any)
+	if !ok || len(results) != 2 {
+		t.Fatalf("results = %v, want 2 entries", parsed["results"])
+	}
+	first := results[0].(map[string]any)
+	if first["run_id"] != float64(100) || first["success"] != true {
+		t.Errorf("results[0] = %v, want successful run 100", first)
+	}
+	if _, ok := first["job_id"]; ok {
+		t.Errorf("results[0].job_id present, want omitted for run-level re-runs")
+	}
+	if second := results[1].(map[string]any); second["error"] == nil {
+		t.Errorf("results[1].error missing: %v", second)
+	}
+}
+
+func TestJSONCompactStatusMergeState(t *testing.T) {
+	var buf bytes.Buffer
+	f := &JSONFormatter{}
+
+	result := sampleStatusResult()
+	result.IsDraft = true
+	result.MergeStateStatus = "DRAFT"
+	if err := f.FormatCompactStatus(&buf, result); err != nil {
+		t.Fatalf("FormatCompactStatus: %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if parsed["is_draft"] != true {
+		t.Errorf("is_draft = %v, want true", parsed["is_draft"])
+	}
+	if parsed["merge_state_status"] != "DRAFT" {
+		t.Errorf("merge_state_status = %v, want DRAFT", parsed["merge_state_status"])
+	}
+}
@@ -0,0 +1,231 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// JUnitFormatter outputs check runs as a JUnit XML report for CI dashboards
+// and test-report viewers. Each check run is a <testsuite>. Failed tests
+// extracted from the job log (--logs) become failing <testcase>s; a check
+// without recognized tests is a single <testcase> named after the check
+// that fails, is skipped (pending, skipped, neutral), or passes with it.
+type JUnitFormatter struct{}
+
+var errJUnitUnsupported = errors.New("junit format is only supported by checks")
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr,omitempty"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+func toJUnitSuite(ch domain.CheckRun) junitTestSuite {
+	suite := junitTestSuite{Name: ch.Name}
+	if !ch.StartedAt.IsZero() {
+		suite.Timestamp = ch.StartedAt.UTC().Format("2006-01-02T15:04:05")
+		if !ch.CompletedAt.IsZero() {
+			suite.Time = strconv.FormatFloat(ch.CompletedAt.Sub(ch.StartedAt).Seconds(), 'f', 3, 64)
+		}
+	}
+	if ch.HTMLURL != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "html_url", Value: ch.HTMLURL})
+	}
+	if ch.Conclusion != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "conclusion", Value: ch.Conclusion})
+	}
+	if ch.BaseComparison != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "base_comparison", Value: ch.BaseComparison})
+	}
+
+	for _, t := range ch.FailedTests {
+		className := t.Package
+		if className == "" {
+			className = ch.Name
+		}
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      t.Name,
+			ClassName: className,
+			File:      t.File,
+			Line:      t.Line,
+			Failure:   &junitFailure{Message: junitTestMessage(t), Type: t.Framework, Body: t.Output},
+		})
+	}
+	if len(suite.Cases) == 0 {
+		tc := junitTestCase{Name: ch.Name, ClassName: ch.Name}
+		switch {
+		case domain.IsFailConclusion(ch.Conclusion):
+			message := ch.Description
+			if message == "" {
+				message = ch.Conclusion
+			}
+			tc.Failure = &junitFailure{Message: message, Type: ch.Conclusion, Body: junitCheckBody(ch)}
+		case ch.Status != "completed":
+			tc.Skipped = &junitSkipped{Message: ch.Status}
+		case ch.Conclusion == "skipped" || ch.Conclusion == "neutral":
+			tc.Skipped = &junitSkipped{Message: ch.Conclusion}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	for _, tc := range suite.Cases {
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+	return suite
+}
+
+// junitTestMessage is a failed test's message, prefixed with its base-branch
+// comparison when there is one, since test cases carry no properties.
+func junitTestMessage(t domain.FailedTest) string {
+	if t.BaseComparison == "" {
+		return t.Message
+	}
+	return "[" + t.BaseComparison + "] " + t.Message
+}
+
+// junitCheckBody lists a failing check's annotations followed by its log
+// excerpt.
+func junitCheckBody(ch domain.CheckRun) string {
+	var b strings.Builder
+	for _, a := range ch.Annotations {
+		b.WriteString(a.Path + ":" + strconv.Itoa(a.StartLine) + ": " + a.AnnotationLevel + ": " + a.Message + "\n")
+	}
+	if ch.LogExcerpt != "" {
+		b.WriteString(ch.LogExcerpt)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (f *JUnitFormatter) FormatChecks(w io.Writer, result *domain.ChecksResult) error {
+	out := junitTestSuites{Name: "ghent"}
+	if result.PRNumber > 0 {
+		out.Name = "ghent PR #" + strconv.Itoa(result.PRNumber)
+	}
+	for _, ch := range result.Checks {
+		suite := toJUnitSuite(ch)
+		out.Tests += suite.Tests
+		out.Failures += suite.Failures
+		out.Skipped += suite.Skipped
+		out.Suites = append(out.Suites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// FormatWatchStatus writes nothing; the report follows once watching ends.
+func (f *JUnitFormatter) FormatWatchStatus(io.Writer, *domain.WatchStatus) error {
+	return nil
+}
+
+func (f *JUnitFormatter) FormatComments(io.Writer, *domain.CommentsResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatGroupedComments(io.Writer, *domain.GroupedCommentsResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatStatus(io.Writer, *domain.StatusResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatCompactStatus(io.Writer, *domain.StatusResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatReply(io.Writer, *domain.ReplyResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatResolveResults(io.Writer, *domain.ResolveResults) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatDismissResults(io.Writer, *domain.DismissResults) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatUpdateBranch(io.Writer, *domain.UpdateBranchResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatMergeResult(io.Writer, *domain.MergeResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatJobLog(io.Writer, *domain.JobLogResult) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatRerunResults(io.Writer, *domain.RerunResults) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatSuggestions(io.Writer, *domain.SuggestionResults) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatPlanResults(io.Writer, *domain.PlanResults) error {
+	return errJUnitUnsupported
+}
+
+func (f *JUnitFormatter) FormatAudit(io.Writer, *domain.AuditResult) error {
+	return errJUnitUnsupported
+}
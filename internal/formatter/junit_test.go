@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestJUnitFormatterChecks(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := &domain.ChecksResult{PRNumber: 42, Checks: []domain.CheckRun{
+		{
+			Name: "test", Status: "completed", Conclusion: "failure", StartedAt: start, CompletedAt: start.Add(90 * time.Second),
+			FailedTests: []domain.FailedTest{{
+				Name: "TestSum", Package: "example.com/calc", File: "sum_test.go", Line: 20,
+				Message: "got 1, want 2", Output: "sum_test.go:20: got 1, want 2", Framework: "go",
+			}},
+		},
+		{Name: "lint", Status: "completed", Conclusion: "failure", LogExcerpt: "main.go:3: unused"},
+		{Name: "build", Status: "completed", Conclusion: "success"},
+		{Name: "deploy", Status: "in_progress"},
+	}}
+
+	var buf bytes.Buffer
+	if err := (&JUnitFormatter{}).FormatChecks(&buf, result); err != nil {
+		t.Fatalf("FormatChecks: %v", err)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if got.Name != "ghent PR #42" || got.Tests != 4 || got.Failures != 2 || got.Skipped != 1 {
+		t.Errorf("totals = %s tests=%d failures=%d skipped=%d; want ghent PR #42 4/2/1", got.Name, got.Tests, got.Failures, got.Skipped)
+	}
+
+	type row struct {
+		Suite, Case, Class, File string
+		Line                     int
+		Failure, Skipped         string
+	}
+	var rows []row
+	for _, s := range got.Suites {
+		for _, c := range s.Cases {
+			r := row{Suite: s.Name, Case: c.Name, Class: c.ClassName, File: c.File, Line: c.Line}
+			if c.Failure != nil {
+				r.Failure = c.Failure.Message + " | " + c.Failure.Body
+			}
+			if c.Skipped != nil {
+				r.Skipped = c.Skipped.Message
+			}
+			rows = append(rows, r)
+		}
+	}
+	want := []row{
+		{Suite: "test", Case: "TestSum", Class: "example.com/calc", File: "sum_test.go", Line: 20, Failure: "got 1, want 2 | sum_test.go:20: got 1, want 2"},
+		{Suite: "lint", Case: "lint", Class: "lint", Failure: "failure | main.go:3: unused"},
+		{Suite: "build", Case: "build", Class: "build"},
+		{Suite: "deploy", Case: "deploy", Class: "deploy", Skipped: "in_progress"},
+	}
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Errorf("test cases mismatch (-want +got):\n%s", diff)
+	}
+	if got.Suites[0].Time != "90.000" || got.Suites[0].Timestamp != "2026-01-02T03:04:05" {
+		t.Errorf("suite timing = %q at %q; want 90.000 at 2026-01-02T03:04:05", got.Suites[0].Time, got.Suites[0].Timestamp)
+	}
+}
+
+func TestJUnitFormatterRejectsOtherResults(t *testing.T) {
+	if err := (&JUnitFormatter{}).FormatStatus(&bytes.Buffer{}, &domain.StatusResult{}); err == nil {
+		t.Error("FormatStatus succeeded; want an unsupported-format error")
+	}
+}
@@ -3,6 +3,9 @@ package formatter
 import (
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/indrasvat/gh-ghent/internal/domain"
 )
@@ -21,11 +24,16 @@ func (f *MarkdownFormatter) FormatComments(w io.Writer, result *domain.CommentsR
 		fmt.Fprintf(w, " | **Bot:** %d | **Unanswered:** %d",
 			result.BotThreadCount, result.UnansweredCount)
 	}
+	if result.ConversationCount > 0 {
+		fmt.Fprintf(w, " | **Conversation:** %d (%d unanswered)",
+			result.ConversationCount, result.UnansweredConversationCount)
+	}
 	fmt.Fprintln(w)
 
 	for _, t := range result.Threads {
 		fmt.Fprintf(w, "\n---\n\n")
 		fmt.Fprintf(w, "## %s:%d\n\n", t.Path, t.Line)
+		writeCodeChange(w, t)
 
 		for _, c := range t.Comments {
 			botBadge := ""
@@ -40,10 +48,114 @@ func (f *MarkdownFormatter) FormatComments(w io.Writer, result *domain.CommentsR
 			}
 			fmt.Fprintln(w)
 		}
+		if t.Truncated {
+			fmt.Fprintf(w, "_Thread truncated — later comments not shown._\n\n")
+		}
+	}
+
+	if len(result.Conversation) > 0 {
+		fmt.Fprintf(w, "\n---\n\n")
+		fmt.Fprintf(w, "## Conversation\n\n")
+		for _, c := range result.Conversation {
+			writeConversationEntry(w, c)
+		}
 	}
 	return nil
 }
 
+// writeFailedSteps lists failing job steps as "- Failed step N: **name** (conclusion, 12s)".
+func writeFailedSteps(w io.Writer, steps []domain.JobStep) {
+	for _, st := range steps {
+		fmt.Fprintf(w, "- Failed step %d: **%s** (%s, %ds)\n", st.Number, st.Name, st.Conclusion, st.DurationSeconds)
+	}
+}
+
+// writeBaseRef names the base branch commit checks were compared against and
+// lists the checks the PR fixes.
+func writeBaseRef(w io.Writer, result *domain.ChecksResult) {
+	if result.BaseSHA == "" {
+		return
+	}
+	fmt.Fprintf(w, "**Compared with:** `%s` @ %.7s\n\n", result.BaseRef, result.BaseSHA)
+	var fixed []string
+	for _, ch := range result.Checks {
+		if ch.BaseComparison == domain.BaseFixedByPR {
+			fixed = append(fixed, ch.Name)
+		}
+	}
+	if len(fixed) > 0 {
+		fmt.Fprintf(w, "**Fixed by PR:** %s\n\n", strings.Join(fixed, ", "))
+	}
+}
+
+// writeFlaky summarizes flakiness evidence as "- **Flaky** (reason): N pass / M fail, K flips"
+// followed by the outcome history.
+func writeFlaky(w io.Writer, fl *domain.FlakyCheck) {
+	if fl == nil {
+		return
+	}
+	fmt.Fprintf(w, "- **Flaky** (%s): %d pass / %d fail, %d flips\n", fl.Reason, fl.Passes, fl.Fails, fl.Flips)
+	for _, o := range fl.History {
+		fmt.Fprintf(w, "  - `%.7s` %s (run %d)\n", o.SHA, o.Conclusion, o.CheckRunID)
+	}
+}
+
+// writeFailedTests lists failing tests as "- `name` (`file:line`) — message".
+func writeFailedTests(w io.Writer, tests []domain.FailedTest) {
+	for _, t := range tests {
+		fmt.Fprintf(w, "- `%s`", t.Name)
+		if t.File != "" {
+			fmt.Fprintf(w, " (`%s:%d`)", t.File, t.Line)
+		}
+		if t.BaseComparison != "" {
+			fmt.Fprintf(w, " [%s]", t.BaseComparison)
+		}
+		if t.Message != "" {
+			fmt.Fprintf(w, " — %s", t.Message)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// writeConversationEntry renders one top-level comment or review body.
+func writeConversationEntry(w io.Writer, c domain.ConversationComment) {
+	botBadge := ""
+	if c.IsBot {
+		botBadge = " [bot]"
+	}
+	fmt.Fprintf(w, "**@%s%s** — %s", c.Author, botBadge, c.CreatedAt.Format("2006-01-02 15:04"))
+	if c.Kind == domain.ConversationKindReview {
+		fmt.Fprintf(w, " · review %s", c.ReviewState)
+	}
+	if c.IsMinimized {
+		fmt.Fprintf(w, " · hidden (%s)", strings.ToLower(c.MinimizedReason))
+	} else if !c.HasReply {
+		fmt.Fprintf(w, " · unanswered")
+	}
+	fmt.Fprintf(w, "\n\n> %s\n\n", c.Body)
+}
+
+// writeCodeChange notes whether the commented code changed at the PR head,
+// when the thread was analyzed.
+func writeCodeChange(w io.Writer, t domain.ReviewThread) {
+	if t.CodeChangedSinceComment == nil {
+		return
+	}
+	if !*t.CodeChangedSinceComment {
+		fmt.Fprintf(w, "_Code unchanged since the comment._\n\n")
+		return
+	}
+	fmt.Fprintf(w, "_Code changed since the comment")
+	for i, c := range t.ChangedBy {
+		sep := ", "
+		if i == 0 {
+			sep = " by "
+		}
+		fmt.Fprintf(w, "%s`%.7s` %s", sep, c.SHA, c.Message)
+	}
+	fmt.Fprintf(w, "._\n\n")
+}
+
 func (f *MarkdownFormatter) FormatGroupedComments(w io.Writer, result *domain.GroupedCommentsResult) error {
 	fmt.Fprintf(w, "# PR #%d — Review Comments (by %s)\n\n", result.PRNumber, result.GroupBy)
 	fmt.Fprintf(w, "**Unresolved:** %d | **Resolved:** %d | **Total:** %d\n",
@@ -55,6 +167,7 @@ func (f *MarkdownFormatter) FormatGroupedComments(w io.Writer, result *domain.Gr
 
 		for _, t := range g.Threads {
 			fmt.Fprintf(w, "### %s:%d\n\n", t.Path, t.Line)
+			writeCodeChange(w, t)
 			for _, c := range t.Comments {
 				botBadge := ""
 				if c.IsBot {
@@ -67,6 +180,9 @@ func (f *MarkdownFormatter) FormatGroupedComments(w io.Writer, result *domain.Gr
 				}
 				fmt.Fprintln(w)
 			}
+			if t.Truncated {
+				fmt.Fprintf(w, "_Thread truncated — later comments not shown._\n\n")
+			}
 		}
 	}
 	return nil
@@ -79,14 +195,25 @@ func (f *MarkdownFormatter) FormatChecks(w io.Writer, result *domain.ChecksResul
 	}
 	fmt.Fprintf(w, "**Status:** %s | **Pass:** %d | **Fail:** %d | **Pending:** %d\n\n",
 		result.OverallStatus, result.PassCount, result.FailCount, result.PendingCount)
-	fmt.Fprintf(w, "| Check | Status | Conclusion |\n")
-	fmt.Fprintf(w, "|-------|--------|------------|\n")
+	writeBaseRef(w, result)
+	fmt.Fprintf(w, "| Check | Kind | Status | Conclusion |\n")
+	fmt.Fprintf(w, "|-------|------|--------|------------|\n")
 	for _, ch := range result.Checks {
 		conclusion := ch.Conclusion
 		if conclusion == "" {
 			conclusion = "-"
 		}
-		fmt.Fprintf(w, "| %s | %s | %s |\n", ch.Name, ch.Status, conclusion)
+		if ch.BaseComparison != "" {
+			conclusion += " (" + ch.BaseComparison + ")"
+		}
+		if ch.Flaky != nil {
+			conclusion += " (flaky)"
+		}
+		kind := string(ch.Kind)
+		if kind == "" {
+			kind = "-"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", ch.Name, kind, ch.Status, conclusion)
 	}
 
 	// Annotations and log excerpts for failed checks
@@ -97,6 +224,21 @@ func (f *MarkdownFormatter) FormatChecks(w io.Writer, result *domain.ChecksResul
 				fmt.Fprintf(w, "- **%s** `%s:%d` — %s\n", a.AnnotationLevel, a.Path, a.StartLine, a.Message)
 			}
 		}
+		if ch.Description != "" && domain.IsFailConclusion(ch.Conclusion) {
+			fmt.Fprintf(w, "\n### %s — Description\n\n%s\n", ch.Name, ch.Description)
+		}
+		if ch.Flaky != nil {
+			fmt.Fprintf(w, "\n### %s — Flakiness\n\n", ch.Name)
+			writeFlaky(w, ch.Flaky)
+		}
+		if len(ch.FailedSteps) > 0 {
+			fmt.Fprintf(w, "\n### %s — Failed Steps\n\n", ch.Name)
+			writeFailedSteps(w, ch.FailedSteps)
+		}
+		if len(ch.FailedTests) > 0 {
+			fmt.Fprintf(w, "\n### %s — Failed Tests\n\n", ch.Name)
+			writeFailedTests(w, ch.FailedTests)
+		}
 		if ch.LogExcerpt != "" {
 			fmt.Fprintf(w, "\n### %s — Log Excerpt\n\n```\n%s\n```\n", ch.Name, ch.LogExcerpt)
 		}
@@ -105,7 +247,11 @@ func (f *MarkdownFormatter) FormatChecks(w io.Writer, result *domain.ChecksResul
 }
 
 func (f *MarkdownFormatter) FormatReply(w io.Writer, result *domain.ReplyResult) error {
-	fmt.Fprintf(w, "# Reply Posted\n\n")
+	if result.AlreadyPosted {
+		fmt.Fprintf(w, "# Reply Already Posted\n\n")
+	} else {
+		fmt.Fprintf(w, "# Reply Posted\n\n")
+	}
 	fmt.Fprintf(w, "**Thread:** %s\n", result.ThreadID)
 	fmt.Fprintf(w, "**URL:** %s\n\n", result.URL)
 	fmt.Fprintf(w, "> %s\n", result.Body)
@@ -174,6 +320,222 @@ func (f *MarkdownFormatter) FormatDismissResults(w io.Writer, result *domain.Dis
 	return nil
 }
 
+func (f *MarkdownFormatter) FormatRerunResults(w io.Writer, result *domain.RerunResults) error {
+	fmt.Fprintf(w, "# Rerun Results — PR #%d\n\n", result.PRNumber)
+	fmt.Fprintf(w, "**Success:** %d | **Failed:** %d", result.SuccessCount, result.FailureCount)
+	if result.DryRun {
+		fmt.Fprintf(w, " | **Dry Run:** true")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+
+	if len(result.Results) > 0 {
+		fmt.Fprintf(w, "| Run | Job | Checks | Action | Result |\n")
+		fmt.Fprintf(w, "|-----|-----|--------|--------|--------|\n")
+		for _, r := range result.Results {
+			run, job := "-", "-"
+			if r.RunID != 0 {
+				run = strconv.FormatInt(r.RunID, 10)
+			}
+			if r.JobID != 0 {
+				job = strconv.FormatInt(r.JobID, 10)
+			}
+			outcome := "ok"
+			switch {
+			case r.Error != "":
+				outcome = "error: " + r.Error
+			case !r.Success:
+				outcome = "-"
+			}
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n", run, job, strings.Join(r.Checks, ", "), r.Action, outcome)
+		}
+	}
+	return nil
+}
+
+func (f *MarkdownFormatter) FormatSuggestions(w io.Writer, result *domain.SuggestionResults) error {
+	fmt.Fprintf(w, "# Suggestions — PR #%d\n\n", result.PRNumber)
+	fmt.Fprintf(w, "**Applicable:** %d | **Applied:** %d | **Conflicts:** %d | **Failed:** %d",
+		result.ApplicableCount, result.AppliedCount, result.ConflictCount, result.FailureCount)
+	if result.DryRun {
+		fmt.Fprintf(w, " | **Dry Run:** true")
+	}
+	fmt.Fprintln(w)
+
+	if len(result.Suggestions) == 0 {
+		fmt.Fprintf(w, "\nNo suggestions in unresolved threads.\n")
+		return nil
+	}
+	for _, s := range result.Suggestions {
+		loc := s.Path
+		if s.StartLine > 0 {
+			loc = fmt.Sprintf("%s:%d", s.Path, s.StartLine)
+			if s.EndLine > s.StartLine {
+				loc += fmt.Sprintf("-%d", s.EndLine)
+			}
+		}
+		fmt.Fprintf(w, "\n## %s — %s\n\n", loc, s.Status)
+		fmt.Fprintf(w, "**@%s** · thread `%s` · [comment](%s)\n", s.Author, s.ThreadID, s.URL)
+		if s.Reason != "" {
+			fmt.Fprintf(w, "- **Reason:** %s\n", s.Reason)
+		}
+		if s.Replied || s.Resolved {
+			fmt.Fprintf(w, "- **Replied:** %t | **Resolved:** %t\n", s.Replied, s.Resolved)
+		}
+		if s.Error != "" {
+			fmt.Fprintf(w, "- **Error:** %s\n", s.Error)
+		}
+		if s.Diff != "" {
+			fmt.Fprintf(w, "\n```diff\n%s```\n", s.Diff)
+		} else if s.Status == domain.SuggestionConflict {
+			fmt.Fprintf(w, "\n```suggestion\n%s```\n", joinLines(s.Replacement))
+		}
+	}
+	return nil
+}
+
+func (f *MarkdownFormatter) FormatPlanResults(w io.Writer, result *domain.PlanResults) error {
+	fmt.Fprintf(w, "# Plan Results — PR #%d\n\n", result.PRNumber)
+	fmt.Fprintf(w, "**Success:** %d | **Failed:** %d | **Skipped:** %d",
+		result.SuccessCount, result.FailureCount, result.SkippedCount)
+	if result.DryRun {
+		fmt.Fprintf(w, " | **Dry Run:** true")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+
+	if len(result.Results) > 0 {
+		fmt.Fprintf(w, "| # | Action | Target | Status | Detail |\n")
+		fmt.Fprintf(w, "|---|--------|--------|--------|--------|\n")
+		for _, r := range result.Results {
+			target := r.ThreadID
+			if r.Action == domain.PlanDismiss {
+				target = r.ReviewID
+			}
+			detail := r.URL
+			if r.Error != "" {
+				detail = r.Error
+			}
+			if detail == "" {
+				detail = "-"
+			}
+			fmt.Fprintf(w, "| %d | %s | `%s` | %s | %s |\n", r.Index, r.Action, target, r.Status, detail)
+		}
+	}
+	return nil
+}
+
+func (f *MarkdownFormatter) FormatAudit(w io.Writer, result *domain.AuditResult) error {
+	fmt.Fprintf(w, "# Audit Log\n\n")
+	fmt.Fprintf(w, "**Entries:** %d | **Log:** `%s`\n\n", len(result.Entries), result.Path)
+	if len(result.Entries) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "| Time | PR | Action | Target | Outcome | Command |\n")
+	fmt.Fprintf(w, "|------|----|--------|--------|---------|---------|\n")
+	for _, e := range result.Entries {
+		pr := "-"
+		if e.PR != 0 {
+			pr = fmt.Sprintf("%s#%d", e.Repo, e.PR)
+		}
+		outcome := e.Outcome
+		if e.Error != "" {
+			outcome += ": " + e.Error
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | `%s` | %s | `%s` |\n",
+			e.Time.Format(time.RFC3339), pr, e.Action, e.Target, escapeTableCell(outcome), escapeTableCell(e.Command))
+	}
+	return nil
+}
+
+// escapeTableCell keeps free text from breaking a markdown table row.
+func escapeTableCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// joinLines joins lines, ending each with a newline.
+func joinLines(lines []string) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l + "\n")
+	}
+	return b.String()
+}
+
+func (f *MarkdownFormatter) FormatUpdateBranch(w io.Writer, result *domain.UpdateBranchResult) error {
+	fmt.Fprintf(w, "# Update Branch — PR #%d\n\n", result.PRNumber)
+	fmt.Fprintf(w, "**Action:** %s | **Method:** %s | **Behind `%s`:** %d",
+		result.Action, result.Method, result.BaseRef, result.BehindBy)
+	if result.DryRun {
+		fmt.Fprintf(w, " | **Dry Run:** true")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Previous head:** %s\n", result.PreviousHeadSHA)
+	if result.HeadSHA != "" {
+		fmt.Fprintf(w, "- **New head:** %s\n", result.HeadSHA)
+	}
+	return nil
+}
+
+func (f *MarkdownFormatter) FormatJobLog(w io.Writer, result *domain.JobLogResult) error {
+	fmt.Fprintf(w, "# Job Log — %s\n\n", result.CheckName)
+	fmt.Fprintf(w, "**Check:** %d | **Conclusion:** %s | **Lines:** %d of %d\n",
+		result.CheckID, result.Conclusion, len(result.Lines), result.TotalLines)
+	if result.Step != nil {
+		fmt.Fprintf(w, "\n**Step %d:** %s (%s, %ds)\n", result.Step.Number, result.Step.Name, result.Step.Conclusion, result.Step.DurationSeconds)
+	}
+	if result.HTMLURL != "" {
+		fmt.Fprintf(w, "\n%s\n", result.HTMLURL)
+	}
+	fmt.Fprintf(w, "\n```\n")
+	prev := 0
+	for _, l := range result.Lines {
+		if prev > 0 && l.Number > prev+1 {
+			fmt.Fprintln(w, "...")
+		}
+		fmt.Fprintf(w, "%5d | %s\n", l.Number, l.Text)
+		prev = l.Number
+	}
+	fmt.Fprintf(w, "```\n")
+	return nil
+}
+
+func (f *MarkdownFormatter) FormatMergeResult(w io.Writer, result *domain.MergeResult) error {
+	fmt.Fprintf(w, "# Merge — PR #%d\n\n", result.PRNumber)
+	fmt.Fprintf(w, "**Action:** %s | **Method:** %s | **Mode:** %s", result.Action, result.Method, result.Mode)
+	if result.DryRun {
+		fmt.Fprintf(w, " | **Dry Run:** true")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Verified head:** %s\n", result.HeadSHA)
+	if result.MergeCommitSHA != "" {
+		fmt.Fprintf(w, "- **Merge commit:** %s\n", result.MergeCommitSHA)
+	}
+	if result.Error != "" {
+		fmt.Fprintf(w, "- **Error:** %s\n", result.Error)
+	}
+	fmt.Fprintln(w)
+
+	if len(result.Blockers) > 0 {
+		fmt.Fprintf(w, "## Merge Blockers\n\n")
+		for _, b := range result.Blockers {
+			fmt.Fprintf(w, "- %s _(%s)_\n", b.Message, b.Source)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(result.Deferred) > 0 {
+		fmt.Fprintf(w, "## Deferred to GitHub\n\n")
+		for _, b := range result.Deferred {
+			fmt.Fprintf(w, "- %s _(%s)_\n", b.Message, b.Source)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
 func (f *MarkdownFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusResult) error {
 	mergeStatus := "NOT READY"
 	if result.IsMergeReady {
@@ -199,8 +561,15 @@ func (f *MarkdownFormatter) FormatCompactStatus(w io.Writer, result *domain.Stat
 	if len(result.StaleReviews) > 0 {
 		fmt.Fprintf(w, " stale:%d", len(result.StaleReviews))
 	}
+	if result.MergeStateStatus != "" {
+		fmt.Fprintf(w, " merge:%s", strings.ToLower(result.MergeStateStatus))
+	}
 	fmt.Fprintln(w)
 
+	for _, b := range result.Blockers {
+		fmt.Fprintf(w, "BLOCKED: %s\n", b.Message)
+	}
+
 	// Thread digest table.
 	if len(result.Comments.Threads) > 0 {
 		fmt.Fprintln(w)
@@ -264,6 +633,34 @@ func (f *MarkdownFormatter) FormatStatus(w io.Writer, result *domain.StatusResul
 	}
 	fmt.Fprintf(w, "# PR #%d — Status [%s]\n\n", result.PRNumber, mergeStatus)
 
+	if state := mergeStateSummary(result); state != "" {
+		fmt.Fprintf(w, "**Merge state:** %s\n\n", state)
+	}
+
+	if len(result.Blockers) > 0 {
+		fmt.Fprintf(w, "## Merge Blockers\n\n")
+		for _, b := range result.Blockers {
+			fmt.Fprintf(w, "- %s _(%s)_\n", b.Message, b.Source)
+		}
+		fmt.Fprintln(w)
+	}
+	if req := result.MergeRequirements; req.HasRules() {
+		fmt.Fprintf(w, "**Branch rules (%s):** %d approval(s)", req.BaseBranch, req.RequiredApprovals)
+		if req.RequireCodeOwnerReview {
+			fmt.Fprintf(w, ", code owner review")
+		}
+		if req.RequireConversationResolution {
+			fmt.Fprintf(w, ", conversation resolution")
+		}
+		if req.RequireLinearHistory {
+			fmt.Fprintf(w, ", linear history")
+		}
+		if len(req.RequiredChecks) > 0 {
+			fmt.Fprintf(w, ", required checks: %s", strings.Join(req.RequiredChecks, ", "))
+		}
+		fmt.Fprintf(w, "\n\n")
+	}
+
 	// Comments section.
 	fmt.Fprintf(w, "## Review Comments\n\n")
 	fmt.Fprintf(w, "**Unresolved:** %d | **Resolved:** %d | **Total:** %d\n\n",
@@ -285,20 +682,47 @@ func (f *MarkdownFormatter) FormatStatus(w io.Writer, result *domain.StatusResul
 		fmt.Fprintln(w)
 	}
 
+	// Conversation: only entries still waiting for a response.
+	if result.Comments.ConversationCount > 0 {
+		fmt.Fprintf(w, "**Conversation:** %d (%d unanswered)\n\n",
+			result.Comments.ConversationCount, result.Comments.UnansweredConversationCount)
+		for _, c := range result.Comments.Conversation {
+			if !c.IsUnanswered() {
+				continue
+			}
+			preview := c.Body
+			if len(preview) > 80 {
+				preview = preview[:80] + "..."
+			}
+			fmt.Fprintf(w, "- **%s** @%s — %s\n", c.Kind, c.Author, preview)
+		}
+		if result.Comments.UnansweredConversationCount > 0 {
+			fmt.Fprintln(w)
+		}
+	}
+
 	// Checks section.
 	fmt.Fprintf(w, "## CI Checks\n\n")
 	fmt.Fprintf(w, "**Status:** %s | **Pass:** %d | **Fail:** %d | **Pending:** %d\n\n",
 		result.Checks.OverallStatus, result.Checks.PassCount, result.Checks.FailCount, result.Checks.PendingCount)
+	writeBaseRef(w, &result.Checks)
 
 	// Failed check details (annotations + log excerpts).
 	for _, ch := range result.Checks.Checks {
 		if !domain.IsFailConclusion(ch.Conclusion) {
 			continue
 		}
-		fmt.Fprintf(w, "### FAIL: %s\n\n", ch.Name)
+		if ch.BaseComparison != "" {
+			fmt.Fprintf(w, "### FAIL: %s (%s)\n\n", ch.Name, ch.BaseComparison)
+		} else {
+			fmt.Fprintf(w, "### FAIL: %s\n\n", ch.Name)
+		}
+		writeFlaky(w, ch.Flaky)
+		writeFailedSteps(w, ch.FailedSteps)
 		for _, a := range ch.Annotations {
 			fmt.Fprintf(w, "- **%s** `%s:%d` — %s\n", a.AnnotationLevel, a.Path, a.StartLine, a.Message)
 		}
+		writeFailedTests(w, ch.FailedTests)
 		if ch.LogExcerpt != "" {
 			fmt.Fprintf(w, "\n```\n%s\n```\n", ch.LogExcerpt)
 		}
@@ -353,6 +777,10 @@ func (f *MarkdownFormatter) FormatStatus(w io.Writer, result *domain.StatusResul
 		fmt.Fprintf(w, "Suggested: `gh ghent dismiss --pr %d --message \"superseded by current HEAD\"`\n", result.PRNumber)
 	}
 
+	if result.IsBehind && !result.HasConflicts {
+		fmt.Fprintf(w, "\nBranch is behind `%s`. Suggested: `gh ghent update-branch --pr %d`\n", result.BaseRef, result.PRNumber)
+	}
+
 	return nil
 }
 
@@ -368,3 +796,32 @@ func formatSettlementDuration(secs int) string {
 	}
 	return fmt.Sprintf("%dm%ds", m, s)
 }
+
+// mergeStateSummary describes draft, conflict, and up-to-date state, e.g.
+// "draft, conflicts, 3 commits behind `main`". Empty when PR metadata was
+// not available.
+func mergeStateSummary(result *domain.StatusResult) string {
+	if result.MergeStateStatus == "" && result.Mergeable == "" {
+		return ""
+	}
+	var parts []string
+	if result.IsDraft {
+		parts = append(parts, "draft")
+	}
+	if result.HasConflicts {
+		parts = append(parts, "conflicts")
+	}
+	switch {
+	case result.BehindBy > 0:
+		parts = append(parts, fmt.Sprintf("%d commit(s) behind `%s`", result.BehindBy, result.BaseRef))
+	case result.IsBehind:
+		parts = append(parts, fmt.Sprintf("behind `%s`", result.BaseRef))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "up to date")
+	}
+	if result.MergeStateStatus != "" {
+		parts = append(parts, "GitHub state "+result.MergeStateStatus)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
 )
 
 func TestMarkdownFormatterStructure(t *testing.T) {
@@ -299,6 +301,154 @@ func TestMarkdownDismissResultsStructure(t *testing.T) {
 	}
 }
 
+func TestMarkdownChecksFlaky(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	result := &domain.ChecksResult{PRNumber: 42, Checks: []domain.CheckRun{{
+		Name: "test", Status: "completed", Conclusion: "failure",
+		Flaky: &domain.FlakyCheck{
+			Reason: domain.FlakyReasonSameCode, Flips: 1, Passes: 1, Fails: 1,
+			History: []domain.CheckOutcome{
+				{SHA: "deadbeefcafe", CheckRunID: 1, Conclusion: "success"},
+				{SHA: "deadbeefcafe", CheckRunID: 2, Conclusion: "failure"},
+			},
+		},
+	}}}
+	if err := f.FormatChecks(&buf, result); err != nil {
+		t.Fatalf("FormatChecks: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"| test | - | completed | failure (flaky) |",
+		"### test — Flakiness",
+		"- **Flaky** (same_code): 1 pass / 1 fail, 1 flips",
+		"  - `deadbee` failure (run 2)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownChecksBaseComparison(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	result := &domain.ChecksResult{PRNumber: 42, BaseRef: "main", BaseSHA: "0123456789ab", Checks: []domain.CheckRun{
+		{
+			Name: "test", Status: "completed", Conclusion: "failure", BaseComparison: domain.BasePreExisting,
+			FailedTests: []domain.FailedTest{{Name: "TestA", BaseComparison: domain.BasePreExisting, Message: "boom"}},
+		},
+		{Name: "build", Status: "completed", Conclusion: "success", BaseComparison: domain.BaseFixedByPR},
+	}}
+	if err := f.FormatChecks(&buf, result); err != nil {
+		t.Fatalf("FormatChecks: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"**Compared with:** `main` @ 0123456",
+		"**Fixed by PR:** build",
+		"| test | - | completed | failure (pre_existing) |",
+		"- `TestA` [pre_existing] — boom",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownCommentsCodeChanges(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	changed, unchanged := true, false
+	result := &domain.CommentsResult{PRNumber: 42, Threads: []domain.ReviewThread{
+		{
+			ID: "PRRT_1", Path: "main.go", Line: 8, CodeChangedSinceComment: &changed,
+			ChangedBy: []domain.CommitRef{{SHA: "abcdef1234567", Message: "Use slog"}},
+		},
+		{ID: "PRRT_2", Path: "util.go", Line: 3, CodeChangedSinceComment: &unchanged},
+		{ID: "PRRT_3", Path: "other.go", Line: 1},
+	}}
+	if err := f.FormatComments(&buf, result); err != nil {
+		t.Fatalf("FormatComments: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"_Code changed since the comment by `abcdef1` Use slog._",
+		"_Code unchanged since the comment._",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "since the comment") != 2 {
+		t.Errorf("threads without analysis should carry no note\noutput:\n%s", out)
+	}
+}
+
+func TestMarkdownSuggestions(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	result := &domain.SuggestionResults{PRNumber: 42, ApplicableCount: 1, ConflictCount: 1, Suggestions: []domain.Suggestion{
+		{
+			ThreadID: "PRRT_1", CommentID: "C1", Author: "alice", URL: "https://github.com/o/r/pull/42#r1", Path: "main.go",
+			StartLine: 8, EndLine: 9, Status: domain.SuggestionApplicable, Reason: "moved from line 6",
+			Diff: "--- a/main.go\n+++ b/main.go\n@@ -8 +8 @@\n-old\n+new\n",
+		},
+		{
+			ThreadID: "PRRT_2", CommentID: "C2", Author: "bob", Path: "util.go", Status: domain.SuggestionConflict,
+			Reason: "file does not exist locally", Replacement: []string{"x := 1"},
+		},
+	}}
+	if err := f.FormatSuggestions(&buf, result); err != nil {
+		t.Fatalf("FormatSuggestions: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"# Suggestions — PR #42",
+		"**Applicable:** 1 | **Applied:** 0 | **Conflicts:** 1 | **Failed:** 0",
+		"## main.go:8-9 — applicable",
+		"- **Reason:** moved from line 6",
+		"```diff\n--- a/main.go\n",
+		"## util.go — conflict",
+		"```suggestion\nx := 1\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownRerunResultsStructure(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	if err := f.FormatRerunResults(&buf, sampleRerunResults()); err != nil {
+		t.Fatalf("FormatRerunResults: %v", err)
+	}
+
+	out := buf.String()
+	checks := []string{
+		"# Rerun Results — PR #42",
+		"**Success:** 1 | **Failed:** 1",
+		"| Run | Job | Checks | Action | Result |",
+		"| 100 | - | lint, test | rerun_failed | ok |",
+		"| - | 21 | e2e | rerun_failed | error: resolve workflow run of e2e: not found |",
+	}
+	for _, want := range checks {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
 func TestMarkdownFormatterEmpty(t *testing.T) {
 	var buf bytes.Buffer
 	f := &MarkdownFormatter{}
@@ -321,3 +471,141 @@ func TestMarkdownFormatterEmpty(t *testing.T) {
 		t.Error("empty result should not have thread separators")
 	}
 }
+
+func TestMarkdownStatusMergeState(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	result := sampleStatusResult()
+	result.BaseRef = "main"
+	result.Mergeable = "MERGEABLE"
+	result.MergeStateStatus = "BEHIND"
+	result.IsBehind = true
+	result.BehindBy = 3
+	if err := f.FormatStatus(&buf, result); err != nil {
+		t.Fatalf("FormatStatus: %v", err)
+	}
+
+	out := buf.String()
+	checks := []string{
+		"**Merge state:** 3 commit(s) behind `main`, GitHub state BEHIND",
+		"gh ghent update-branch --pr 42",
+	}
+	for _, want := range checks {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownUpdateBranch(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	err := f.FormatUpdateBranch(&buf, &domain.UpdateBranchResult{
+		PRNumber: 42, BaseRef: "main", Method: "merge", BehindBy: 2,
+		PreviousHeadSHA: "aaa111", HeadSHA: "bbb222", Updated: true, Action: "updated",
+	})
+	if err != nil {
+		t.Fatalf("FormatUpdateBranch: %v", err)
+	}
+
+	out := buf.String()
+	checks := []string{
+		"# Update Branch — PR #42",
+		"**Action:** updated | **Method:** merge | **Behind `main`:** 2",
+		"**New head:** bbb222",
+	}
+	for _, want := range checks {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownMergeResult(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	err := f.FormatMergeResult(&buf, &domain.MergeResult{
+		PRNumber: 42, Method: "squash", Mode: "auto", HeadSHA: "aaa111", Action: "auto_merge_enabled",
+		Blockers: []domain.MergeBlocker{},
+		Deferred: []domain.MergeBlocker{{Rule: "required_approvals", Source: "branch_rules", Message: "needs 1 approval, has 0"}},
+	})
+	if err != nil {
+		t.Fatalf("FormatMergeResult: %v", err)
+	}
+
+	out := buf.String()
+	checks := []string{
+		"# Merge — PR #42",
+		"**Action:** auto_merge_enabled | **Method:** squash | **Mode:** auto",
+		"**Verified head:** aaa111",
+		"## Deferred to GitHub",
+		"- needs 1 approval, has 0 _(branch_rules)_",
+	}
+	for _, want := range checks {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "## Merge Blockers") {
+		t.Errorf("unexpected blockers section\noutput:\n%s", out)
+	}
+}
+
+func TestMarkdownCommentsConversation(t *testing.T) {
+	var buf bytes.Buffer
+	f := &MarkdownFormatter{}
+
+	err := f.FormatComments(&buf, &domain.CommentsResult{
+		PRNumber: 42,
+		Conversation: []domain.ConversationComment{
+			{Kind: "comment", Author: "coderabbitai", IsBot: true, Body: "Walkthrough", HasReply: true},
+			{Kind: "review", Author: "bob", ReviewState: domain.ReviewChangesRequested, Body: "Please split this PR."},
+			{Kind: "comment", Author: "alice", Body: "old", IsMinimized: true, MinimizedReason: "OUTDATED"},
+		},
+		ConversationCount:           3,
+		UnansweredConversationCount: 1,
+	})
+	if err != nil {
+		t.Fatalf("FormatComments: %v", err)
+	}
+
+	out := buf.String()
+	checks := []string{
+		"**Conversation:** 3 (1 unanswered)",
+		"## Conversation",
+		"**@coderabbitai [bot]**",
+		"· review CHANGES_REQUESTED · unanswered",
+		"> Please split this PR.",
+		"· hidden (outdated)",
+	}
+	for _, want := range checks {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownChecksFailedStepsAndTests(t *testing.T) {
+	result := &domain.ChecksResult{PRNumber: 42, Checks: []domain.CheckRun{{
+		Name: "test", Status: "completed", Conclusion: "failure",
+		FailedSteps: []domain.JobStep{{Number: 4, Name: "Run tests", Conclusion: "failure", DurationSeconds: 12}},
+		FailedTests: []domain.FailedTest{{Name: "TestSum", File: "sum_test.go", Line: 20, Message: "got 1", Framework: "go"}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := (&MarkdownFormatter{}).FormatChecks(&buf, result); err != nil {
+		t.Fatalf("FormatChecks: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"### test — Failed Steps\n\n- Failed step 4: **Run tests** (failure, 12s)",
+		"### test — Failed Tests\n\n- `TestSum` (`sum_test.go:20`) — got 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\noutput:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// QuickfixFormatter outputs one "path:line:col: message" line per unresolved
+// review thread, failing-check annotation, and file:line reference in a
+// failing job's log excerpt — the gcc-style errorformat Vim, Neovim, and
+// Emacs compilation mode parse out of the box. Findings without a file
+// location (PR conversation, checks without annotations) are omitted.
+type QuickfixFormatter struct{}
+
+// errQuickfixUnsupported is returned for results that have no file locations.
+var errQuickfixUnsupported = errors.New("quickfix format is only supported by comments, checks, and status")
+
+// qfEntry is one quickfix line.
+type qfEntry struct {
+	path    string
+	line    int
+	col     int
+	kind    string // error, warning, or note
+	message string
+}
+
+func (e qfEntry) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", e.path, max(e.line, 1), max(e.col, 1), e.kind, e.message)
+}
+
+func (f *QuickfixFormatter) FormatComments(w io.Writer, result *domain.CommentsResult) error {
+	return writeQuickfix(w, threadEntries(result.Threads))
+}
+
+func (f *QuickfixFormatter) FormatGroupedComments(w io.Writer, result *domain.GroupedCommentsResult) error {
+	var entries []qfEntry
+	for _, g := range result.Groups {
+		entries = append(entries, threadEntries(g.Threads)...)
+	}
+	return writeQuickfix(w, entries)
+}
+
+func (f *QuickfixFormatter) FormatChecks(w io.Writer, result *domain.ChecksResult) error {
+	return writeQuickfix(w, checkEntries(result.Checks))
+}
+
+func (f *QuickfixFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) error {
+	entries := threadEntries(result.Comments.Threads)
+	entries = append(entries, checkEntries(result.Checks.Checks)...)
+	return writeQuickfix(w, entries)
+}
+
+func (f *QuickfixFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusResult) error {
+	return f.FormatStatus(w, result)
+}
+
+// FormatWatchStatus writes nothing: watch progress has no locations, and
+// the final status that follows carries every entry.
+func (f *QuickfixFormatter) FormatWatchStatus(io.Writer, *domain.WatchStatus) error {
+	return nil
+}
+
+func (f *QuickfixFormatter) FormatReply(io.Writer, *domain.ReplyResult) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatResolveResults(io.Writer, *domain.ResolveResults) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatDismissResults(io.Writer, *domain.DismissResults) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatUpdateBranch(io.Writer, *domain.UpdateBranchResult) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatMergeResult(io.Writer, *domain.MergeResult) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatJobLog(io.Writer, *domain.JobLogResult) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatRerunResults(io.Writer, *domain.RerunResults) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatSuggestions(io.Writer, *domain.SuggestionResults) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatPlanResults(io.Writer, *domain.PlanResults) error {
+	return errQuickfixUnsupported
+}
+
+func (f *QuickfixFormatter) FormatAudit(io.Writer, *domain.AuditResult) error {
+	return errQuickfixUnsupported
+}
+
+func writeQuickfix(w io.Writer, entries []qfEntry) error {
+	for _, e := range entries {
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// threadEntries returns a warning per unresolved thread, located at the
+// first line it comments on. The message is the opening comment's first
+// line, plus the thread ID so the entry can be fed to resolve or reply.
+func threadEntries(threads []domain.ReviewThread) []qfEntry {
+	var entries []qfEntry
+	for _, t := range threads {
+		if t.IsResolved || t.Path == "" || len(t.Comments) == 0 {
+			continue
+		}
+		first := t.Comments[0]
+		msg := "@" + first.Author + ": " + firstLine(first.Body)
+		if replies := len(t.Comments) - 1; replies > 0 {
+			msg += fmt.Sprintf(" (+%d)", replies)
+		}
+		if t.IsOutdated {
+			msg += " (outdated)"
+		}
+		if t.CodeChangedSinceComment != nil && *t.CodeChangedSinceComment {
+			msg += " (code changed)"
+		}
+		line := t.Line
+		if t.StartLine > 0 {
+			line = t.StartLine
+		}
+		entries = append(entries, qfEntry{
+			path:    t.Path,
+			line:    line,
+			kind:    "warning",
+			message: msg + " [" + t.ID + "]",
+		})
+	}
+	return entries
+}
+
+// checkEntries returns the annotations of every check, then file:line
+// references from failing checks' log excerpts that no annotation already
+// covers.
+func checkEntries(checks []domain.CheckRun) []qfEntry {
+	var entries []qfEntry
+	seen := make(map[string]bool)
+	for _, ch := range checks {
+		for _, a := range ch.Annotations {
+			if a.Path == "" {
+				continue
+			}
+			msg := firstLine(a.Message)
+			if a.Title != "" && a.Title != msg {
+				msg = a.Title + ": " + msg
+			}
+			kind := "error"
+			switch a.AnnotationLevel {
+			case "warning":
+				kind = "warning"
+			case "notice":
+				kind = "note"
+			}
+			entries = append(entries, qfEntry{path: a.Path, line: a.StartLine, kind: kind, message: checkLabel(ch) + ": " + msg})
+			seen[a.Path+":"+strconv.Itoa(a.StartLine)] = true
+		}
+	}
+	for _, ch := range checks {
+		if ch.LogExcerpt == "" {
+			continue
+		}
+		for _, e := range logEntries(checkLabel(ch), ch.LogExcerpt) {
+			key := e.path + ":" + strconv.Itoa(e.line)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// checkLabel names a check in entry messages, tagged with its base-branch
+// comparison when there is one, e.g. "lint [pre_existing]".
+func checkLabel(ch domain.CheckRun) string {
+	if ch.BaseComparison == "" {
+		return ch.Name
+	}
+	return ch.Name + " [" + ch.BaseComparison + "]"
+}
+
+// logEntries extracts file:line references from a log excerpt.
+func logEntries(check, excerpt string) []qfEntry {
+	var entries []qfEntry
+	for _, raw := range strings.Split(excerpt, "\n") {
+		loc, ok := domain.ParseFileLocation(raw)
+		if !ok {
+			continue
+		}
+		msg := loc.Message
+		if msg == "" {
+			msg = strings.TrimSpace(raw)
+		}
+		entries = append(entries, qfEntry{path: loc.Path, line: loc.Line, col: loc.Col, kind: "error", message: check + ": " + msg})
+	}
+	return entries
+}
+
+// firstLine returns the first non-blank line of s, marking dropped lines
+// with an ellipsis. Quickfix entries must stay on one line.
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	head, rest, found := strings.Cut(s, "\n")
+	head = strings.TrimSpace(head)
+	if found && strings.TrimSpace(rest) != "" {
+		head += " …"
+	}
+	return head
+}
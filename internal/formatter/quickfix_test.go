@@ -0,0 +1,58 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestQuickfixFormatterStatus(t *testing.T) {
+	result := &domain.StatusResult{
+		PRNumber: 42,
+		Comments: domain.CommentsResult{Threads: []domain.ReviewThread{
+			{
+				ID: "PRRT_1", Path: "main.go", StartLine: 8, Line: 10,
+				Comments: []domain.Comment{
+					{Author: "alice", Body: "Please fix this\n\nIt leaks."},
+					{Author: "bob", Body: "On it"},
+				},
+			},
+			{ID: "PRRT_2", Path: "", Comments: []domain.Comment{{Author: "carol", Body: "no location"}}},
+		}},
+		Checks: domain.ChecksResult{Checks: []domain.CheckRun{
+			{
+				Name: "lint", Conclusion: "failure",
+				Annotations: []domain.Annotation{
+					{Path: "pkg/a.go", StartLine: 3, EndLine: 3, AnnotationLevel: "failure", Title: "errcheck", Message: "unchecked error"},
+					{Path: "pkg/b.go", StartLine: 1, AnnotationLevel: "notice", Message: "consider x"},
+				},
+			},
+			{
+				Name: "test", Conclusion: "failure",
+				LogExcerpt: "--- FAIL: TestA (0.00s)\n    a_test.go:12: got 1, want 2\n...\n##[error]pkg/a.go:3:7: duplicate of annotation\nsee https://example.com:443/x",
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := (&QuickfixFormatter{}).FormatStatus(&buf, result); err != nil {
+		t.Fatalf("FormatStatus: %v", err)
+	}
+
+	want := "main.go:8:1: warning: @alice: Please fix this … (+1) [PRRT_1]\n" +
+		"pkg/a.go:3:1: error: lint: errcheck: unchecked error\n" +
+		"pkg/b.go:1:1: note: lint: consider x\n" +
+		"a_test.go:12:1: error: test: got 1, want 2\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("quickfix output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQuickfixFormatterRejectsMutationResults(t *testing.T) {
+	if err := (&QuickfixFormatter{}).FormatReply(&bytes.Buffer{}, &domain.ReplyResult{}); err == nil {
+		t.Error("FormatReply succeeded; want an unsupported-format error")
+	}
+}
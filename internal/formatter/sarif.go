@@ -0,0 +1,349 @@
+package formatter
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+	"github.com/indrasvat/gh-ghent/internal/version"
+)
+
+// SARIFFormatter outputs unresolved review threads and check annotations as
+// a SARIF 2.1.0 log with one run. Rules are named per check ("check/<name>")
+// and per bot reviewer ("review/<login>"); human review threads share the
+// "review" rule. Comment and check run URLs are attached as related
+// locations. Results without a file path are omitted.
+type SARIFFormatter struct{}
+
+var errSARIFUnsupported = errors.New("sarif format is only supported by comments, checks, and status")
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolURI = "https://github.com/indrasvat/gh-ghent"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool              `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtURI `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult          `json:"results"`
+	AutomationDetails  *sarifAutomation       `json:"automationDetails,omitempty"`
+	Properties         map[string]any         `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifAutomation struct {
+	ID string `json:"id"`
+}
+
+type sarifArtURI struct {
+	URI       string `json:"uri,omitempty"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtURI  `json:"artifactLocation"`
+	Region           *sarifRegion `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// sarifBuilder accumulates rules and results for one run.
+type sarifBuilder struct {
+	rules     []sarifRule
+	ruleIndex map[string]int
+	results   []sarifResult
+	headSHA   string
+	pr        int
+}
+
+func newSARIFBuilder(pr int, headSHA string) *sarifBuilder {
+	return &sarifBuilder{ruleIndex: make(map[string]int), pr: pr, headSHA: headSHA}
+}
+
+func (b *sarifBuilder) rule(id, description, helpURI string) int {
+	if i, ok := b.ruleIndex[id]; ok {
+		return i
+	}
+	b.rules = append(b.rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: description}, HelpURI: helpURI})
+	b.ruleIndex[id] = len(b.rules) - 1
+	return len(b.rules) - 1
+}
+
+func (b *sarifBuilder) addThreads(threads []domain.ReviewThread) {
+	for _, t := range threads {
+		if t.IsResolved || t.Path == "" || len(t.Comments) == 0 {
+			continue
+		}
+		first := t.Comments[0]
+
+		ruleID, desc, level := "review", "Unresolved review thread", "warning"
+		if first.IsBot {
+			ruleID = "review/" + first.Author
+			desc = "Unresolved review thread from " + first.Author
+			level = "note"
+		}
+		idx := b.rule(ruleID, desc, "")
+
+		res := sarifResult{
+			RuleID:    ruleID,
+			RuleIndex: idx,
+			Level:     level,
+			Message: sarifMessage{
+				Text:     "@" + first.Author + ": " + strings.TrimSpace(first.Body),
+				Markdown: strings.TrimSpace(first.Body),
+			},
+			Locations:           []sarifLocation{repoLocation(t.Path, t.StartLine, t.Line)},
+			PartialFingerprints: map[string]string{"ghentThread/v1": t.ID},
+			Properties: map[string]any{
+				"threadId":    t.ID,
+				"author":      first.Author,
+				"isOutdated":  t.IsOutdated,
+				"replyCount":  len(t.Comments) - 1,
+				"isTruncated": t.Truncated,
+			},
+		}
+		if t.CodeChangedSinceComment != nil {
+			res.Properties["codeChangedSinceComment"] = *t.CodeChangedSinceComment
+		}
+		for _, c := range t.Comments {
+			if c.URL == "" {
+				continue
+			}
+			res.RelatedLocations = append(res.RelatedLocations, urlLocation(len(res.RelatedLocations)+1, c.URL, "@"+c.Author))
+		}
+		if prURL, _, ok := strings.Cut(first.URL, "#"); ok {
+			res.RelatedLocations = append(res.RelatedLocations, urlLocation(len(res.RelatedLocations)+1, prURL, "pull request"))
+		}
+		b.results = append(b.results, res)
+	}
+}
+
+func (b *sarifBuilder) addChecks(checks []domain.CheckRun) {
+	for _, ch := range checks {
+		if len(ch.Annotations) == 0 {
+			continue
+		}
+		ruleID := "check/" + ch.Name
+		idx := b.rule(ruleID, "Annotation from check "+ch.Name, ch.HTMLURL)
+		for _, a := range ch.Annotations {
+			if a.Path == "" {
+				continue
+			}
+			text := strings.TrimSpace(a.Message)
+			if a.Title != "" && a.Title != text {
+				text = a.Title + ": " + text
+			}
+			res := sarifResult{
+				RuleID:    ruleID,
+				RuleIndex: idx,
+				Level:     sarifLevel(a.AnnotationLevel),
+				Message:   sarifMessage{Text: text},
+				Locations: []sarifLocation{repoLocation(a.Path, a.StartLine, a.EndLine)},
+				PartialFingerprints: map[string]string{
+					"ghentAnnotation/v1": ch.Name + ":" + a.Path + ":" + strconv.Itoa(a.StartLine) + ":" + a.Title,
+				},
+				Properties: map[string]any{"check": ch.Name, "conclusion": ch.Conclusion},
+			}
+			if ch.BaseComparison != "" {
+				res.Properties["baseComparison"] = ch.BaseComparison
+			}
+			if ch.HTMLURL != "" {
+				res.RelatedLocations = []sarifLocation{urlLocation(1, ch.HTMLURL, "check run "+ch.Name)}
+			}
+			b.results = append(b.results, res)
+		}
+	}
+}
+
+func (b *sarifBuilder) write(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "ghent",
+			Version:        version.Version,
+			InformationURI: sarifToolURI,
+			Rules:          b.rules,
+		}},
+		OriginalURIBaseIDs: map[string]sarifArtURI{"%SRCROOT%": {}},
+		Results:            b.results,
+	}
+	if run.Tool.Driver.Rules == nil {
+		run.Tool.Driver.Rules = []sarifRule{}
+	}
+	if run.Results == nil {
+		run.Results = []sarifResult{}
+	}
+	// versionControlProvenance would need the repository URI, which the
+	// results do not carry; the PR and head commit go in properties instead.
+	if b.pr > 0 {
+		run.AutomationDetails = &sarifAutomation{ID: "ghent/pr-" + strconv.Itoa(b.pr) + "/"}
+		run.Properties = map[string]any{"pullRequest": b.pr}
+	}
+	if b.headSHA != "" {
+		if run.Properties == nil {
+			run.Properties = map[string]any{}
+		}
+		run.Properties["headSha"] = b.headSHA
+	}
+	return encodeJSON(w, sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+func repoLocation(path string, start, end int) sarifLocation {
+	if end <= 0 {
+		end = start
+	}
+	if start <= 0 || start > end {
+		start = end
+	}
+	loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtURI{URI: path, URIBaseID: "%SRCROOT%"},
+	}}
+	if start > 0 {
+		loc.PhysicalLocation.Region = &sarifRegion{StartLine: start, EndLine: end}
+	}
+	return loc
+}
+
+func urlLocation(id int, url, label string) sarifLocation {
+	return sarifLocation{
+		ID:               id,
+		PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtURI{URI: url}},
+		Message:          &sarifMessage{Text: label},
+	}
+}
+
+func sarifLevel(annotationLevel string) string {
+	switch annotationLevel {
+	case "warning":
+		return "warning"
+	case "notice":
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+func (f *SARIFFormatter) FormatComments(w io.Writer, result *domain.CommentsResult) error {
+	b := newSARIFBuilder(result.PRNumber, "")
+	b.addThreads(result.Threads)
+	return b.write(w)
+}
+
+func (f *SARIFFormatter) FormatGroupedComments(w io.Writer, result *domain.GroupedCommentsResult) error {
+	b := newSARIFBuilder(result.PRNumber, "")
+	for _, g := range result.Groups {
+		b.addThreads(g.Threads)
+	}
+	return b.write(w)
+}
+
+func (f *SARIFFormatter) FormatChecks(w io.Writer, result *domain.ChecksResult) error {
+	b := newSARIFBuilder(result.PRNumber, result.HeadSHA)
+	b.addChecks(result.Checks)
+	return b.write(w)
+}
+
+func (f *SARIFFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) error {
+	b := newSARIFBuilder(result.PRNumber, result.Checks.HeadSHA)
+	b.addThreads(result.Comments.Threads)
+	b.addChecks(result.Checks.Checks)
+	return b.write(w)
+}
+
+func (f *SARIFFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusResult) error {
+	return f.FormatStatus(w, result)
+}
+
+// FormatWatchStatus writes nothing; the SARIF log follows once watching ends.
+func (f *SARIFFormatter) FormatWatchStatus(io.Writer, *domain.WatchStatus) error {
+	return nil
+}
+
+func (f *SARIFFormatter) FormatReply(io.Writer, *domain.ReplyResult) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatResolveResults(io.Writer, *domain.ResolveResults) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatDismissResults(io.Writer, *domain.DismissResults) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatUpdateBranch(io.Writer, *domain.UpdateBranchResult) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatMergeResult(io.Writer, *domain.MergeResult) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatJobLog(io.Writer, *domain.JobLogResult) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatRerunResults(io.Writer, *domain.RerunResults) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatSuggestions(io.Writer, *domain.SuggestionResults) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatPlanResults(io.Writer, *domain.PlanResults) error {
+	return errSARIFUnsupported
+}
+
+func (f *SARIFFormatter) FormatAudit(io.Writer, *domain.AuditResult) error {
+	return errSARIFUnsupported
+}
@@ -0,0 +1,96 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestSARIFFormatterStatus(t *testing.T) {
+	result := &domain.StatusResult{
+		PRNumber: 42,
+		Comments: domain.CommentsResult{Threads: []domain.ReviewThread{
+			{
+				ID: "PRRT_1", Path: "main.go", Line: 10,
+				Comments: []domain.Comment{{Author: "alice", Body: "Please fix this", URL: "https://github.com/o/r/pull/42#discussion_r100"}},
+			},
+			{
+				ID: "PRRT_2", Path: "util.go", StartLine: 3, Line: 5,
+				Comments: []domain.Comment{{Author: "coderabbitai[bot]", IsBot: true, Body: "nit"}},
+			},
+		}},
+		Checks: domain.ChecksResult{HeadSHA: "abc123", Checks: []domain.CheckRun{{
+			Name: "lint", Conclusion: "failure", HTMLURL: "https://github.com/o/r/runs/1",
+			Annotations: []domain.Annotation{
+				{Path: "main.go", StartLine: 4, EndLine: 4, AnnotationLevel: "warning", Message: "unused"},
+				{Path: "", StartLine: 1, AnnotationLevel: "failure", Message: "no file"},
+			},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	if err := (&SARIFFormatter{}).FormatStatus(&buf, result); err != nil {
+		t.Fatalf("FormatStatus: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version %q with %d runs; want 2.1.0 with one run", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	var rules []string
+	for _, r := range run.Tool.Driver.Rules {
+		rules = append(rules, r.ID)
+	}
+	if diff := cmp.Diff([]string{"review", "review/coderabbitai[bot]", "check/lint"}, rules); diff != "" {
+		t.Errorf("rules mismatch (-want +got):\n%s", diff)
+	}
+
+	type row struct {
+		Rule, Level, URI string
+		Start, End       int
+		Related          int
+	}
+	var got []row
+	for _, r := range run.Results {
+		loc := r.Locations[0].PhysicalLocation
+		if run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID {
+			t.Errorf("result %s has ruleIndex %d pointing at %s", r.RuleID, r.RuleIndex, run.Tool.Driver.Rules[r.RuleIndex].ID)
+		}
+		got = append(got, row{r.RuleID, r.Level, loc.ArtifactLocation.URI, loc.Region.StartLine, loc.Region.EndLine, len(r.RelatedLocations)})
+	}
+	want := []row{
+		{"review", "warning", "main.go", 10, 10, 2}, // comment + pull request
+		{"review/coderabbitai[bot]", "note", "util.go", 3, 5, 0},
+		{"check/lint", "warning", "main.go", 4, 4, 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("results mismatch (-want +got):\n%s", diff)
+	}
+	if run.Properties["headSha"] != "abc123" {
+		t.Errorf("run properties = %v, want headSha abc123", run.Properties)
+	}
+}
+
+func TestSARIFFormatterEmptyRun(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&SARIFFormatter{}).FormatComments(&buf, &domain.CommentsResult{PRNumber: 1}); err != nil {
+		t.Fatalf("FormatComments: %v", err)
+	}
+	var log map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := log["runs"].([]any)[0].(map[string]any)
+	if results, ok := run["results"].([]any); !ok || len(results) != 0 {
+		t.Errorf("results = %v, want an empty array", run["results"])
+	}
+}
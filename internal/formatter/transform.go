@@ -0,0 +1,142 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/template"
+	"github.com/itchyny/gojq"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// TransformFormatter renders results as JSONFormatter would and then feeds
+// that JSON through a jq expression or a Go template, so --jq and --template
+// work on exactly the documented JSON schema without an external jq binary.
+type TransformFormatter struct {
+	json  JSONFormatter
+	apply func(w io.Writer, in io.Reader) error
+}
+
+// NewTransform creates a formatter for --jq or --template; exactly one of the
+// two must be set. The expression is checked here so mistakes fail before
+// any API call is made.
+func NewTransform(jqExpr, tmpl string) (*TransformFormatter, error) {
+	switch {
+	case jqExpr != "" && tmpl != "":
+		return nil, errors.New("--jq and --template cannot be used together")
+	case jqExpr != "":
+		if err := checkJQ(jqExpr); err != nil {
+			return nil, fmt.Errorf("invalid --jq expression: %w", err)
+		}
+		return &TransformFormatter{apply: func(w io.Writer, in io.Reader) error {
+			return jq.Evaluate(in, w, jqExpr)
+		}}, nil
+	case tmpl != "":
+		if err := template.New(io.Discard, 0, false).Parse(tmpl); err != nil {
+			return nil, fmt.Errorf("invalid --template: %w", err)
+		}
+		return &TransformFormatter{apply: func(w io.Writer, in io.Reader) error {
+			t := template.New(w, 0, false)
+			if err := t.Parse(tmpl); err != nil {
+				return err
+			}
+			if err := t.Execute(in); err != nil {
+				return err
+			}
+			return t.Flush()
+		}}, nil
+	default:
+		return nil, errors.New("--jq or --template is required")
+	}
+}
+
+// checkJQ parses and compiles expr without running it. jq.Evaluate does the
+// same work again per result; that cost is negligible next to an API call.
+func checkJQ(expr string) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return err
+	}
+	_, err = gojq.Compile(query, gojq.WithEnvironLoader(os.Environ))
+	return err
+}
+
+// transform renders via render into a buffer and applies the expression.
+func (f *TransformFormatter) transform(w io.Writer, render func(io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		return err
+	}
+	return f.apply(w, &buf)
+}
+
+func (f *TransformFormatter) FormatComments(w io.Writer, result *domain.CommentsResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatComments(b, result) })
+}
+
+func (f *TransformFormatter) FormatGroupedComments(w io.Writer, result *domain.GroupedCommentsResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatGroupedComments(b, result) })
+}
+
+func (f *TransformFormatter) FormatChecks(w io.Writer, result *domain.ChecksResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatChecks(b, result) })
+}
+
+func (f *TransformFormatter) FormatReply(w io.Writer, result *domain.ReplyResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatReply(b, result) })
+}
+
+func (f *TransformFormatter) FormatResolveResults(w io.Writer, result *domain.ResolveResults) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatResolveResults(b, result) })
+}
+
+func (f *TransformFormatter) FormatDismissResults(w io.Writer, result *domain.DismissResults) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatDismissResults(b, result) })
+}
+
+func (f *TransformFormatter) FormatUpdateBranch(w io.Writer, result *domain.UpdateBranchResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatUpdateBranch(b, result) })
+}
+
+func (f *TransformFormatter) FormatMergeResult(w io.Writer, result *domain.MergeResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatMergeResult(b, result) })
+}
+
+func (f *TransformFormatter) FormatRerunResults(w io.Writer, result *domain.RerunResults) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatRerunResults(b, result) })
+}
+
+func (f *TransformFormatter) FormatSuggestions(w io.Writer, result *domain.SuggestionResults) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatSuggestions(b, result) })
+}
+
+func (f *TransformFormatter) FormatPlanResults(w io.Writer, result *domain.PlanResults) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatPlanResults(b, result) })
+}
+
+func (f *TransformFormatter) FormatAudit(w io.Writer, result *domain.AuditResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatAudit(b, result) })
+}
+
+func (f *TransformFormatter) FormatJobLog(w io.Writer, result *domain.JobLogResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatJobLog(b, result) })
+}
+
+func (f *TransformFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatStatus(b, result) })
+}
+
+func (f *TransformFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusResult) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatCompactStatus(b, result) })
+}
+
+// FormatWatchStatus applies the expression to each watch event on its own,
+// just like jq reading the NDJSON stream line by line.
+func (f *TransformFormatter) FormatWatchStatus(w io.Writer, status *domain.WatchStatus) error {
+	return f.transform(w, func(b io.Writer) error { return f.json.FormatWatchStatus(b, status) })
+}
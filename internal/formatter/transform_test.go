@@ -0,0 +1,96 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestTransformFormatterJQ(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{name: "scalar is raw", expr: ".pr_number", want: "42\n"},
+		{name: "strings are unquoted", expr: ".threads[].comments[0].author", want: "alice\n"},
+		{name: "objects stay compact JSON", expr: "{n: .unresolved_count}", want: "{\"n\":2}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewTransform(tt.expr, "")
+			if err != nil {
+				t.Fatalf("NewTransform() error: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := f.FormatComments(&buf, sampleCommentsResult()); err != nil {
+				t.Fatalf("FormatComments() error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformFormatterTemplate(t *testing.T) {
+	f, err := NewTransform("", `{{range .checks}}{{.name}}={{.conclusion}}{{"\n"}}{{end}}`)
+	if err != nil {
+		t.Fatalf("NewTransform() error: %v", err)
+	}
+	result := &domain.ChecksResult{
+		PRNumber: 42,
+		Checks: []domain.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success"},
+			{Name: "lint", Status: "completed", Conclusion: "failure"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := f.FormatChecks(&buf, result); err != nil {
+		t.Fatalf("FormatChecks() error: %v", err)
+	}
+	if want := "build=success\nlint=failure\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTransformFormatterWatchStatusPerEvent(t *testing.T) {
+	f, err := NewTransform(".completed", "")
+	if err != nil {
+		t.Fatalf("NewTransform() error: %v", err)
+	}
+	var buf bytes.Buffer
+	for _, completed := range []int{1, 3} {
+		if err := f.FormatWatchStatus(&buf, &domain.WatchStatus{Completed: completed}); err != nil {
+			t.Fatalf("FormatWatchStatus() error: %v", err)
+		}
+	}
+	if want := "1\n3\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNewTransformRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		jq      string
+		tmpl    string
+		wantErr string
+	}{
+		{name: "both set", jq: ".", tmpl: "{{.}}", wantErr: "cannot be used together"},
+		{name: "neither set", wantErr: "required"},
+		{name: "jq syntax", jq: ".threads | ", wantErr: "invalid --jq expression"},
+		{name: "jq unknown function", jq: "nosuchfn(1)", wantErr: "invalid --jq expression"},
+		{name: "template syntax", tmpl: "{{.pr_number", wantErr: "invalid --template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTransform(tt.jq, tt.tmpl)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewTransform() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,14 +20,21 @@ func (f *XMLFormatter) FormatComments(w io.Writer, result *domain.CommentsResult
 		BotThreadCount:  result.BotThreadCount,
 		UnansweredCount: result.UnansweredCount,
 		Since:           result.Since,
+
+		ConversationCount:           result.ConversationCount,
+		UnansweredConversationCount: result.UnansweredConversationCount,
+		Conversation:                toXMLConversation(result.Conversation),
 	}
 	for _, t := range result.Threads {
 		xt := xmlThread{
-			ID:         t.ID,
-			Path:       t.Path,
-			Line:       t.Line,
-			IsResolved: t.IsResolved,
-			IsOutdated: t.IsOutdated,
+			ID:          t.ID,
+			Path:        t.Path,
+			Line:        t.Line,
+			IsResolved:  t.IsResolved,
+			IsOutdated:  t.IsOutdated,
+			Truncated:   t.Truncated,
+			CodeChanged: t.CodeChangedSinceComment,
+			ChangedBy:   toXMLCommitRefs(t.ChangedBy),
 		}
 		for _, c := range t.Comments {
 			xt.Comments = append(xt.Comments, xmlComment{
@@ -66,11 +73,14 @@ func (f *XMLFormatter) FormatGroupedComments(w io.Writer, result *domain.Grouped
 		xg := xmlCommentGroup{Key: g.Key}
 		for _, t := range g.Threads {
 			xt := xmlThread{
-				ID:         t.ID,
-				Path:       t.Path,
-				Line:       t.Line,
-				IsResolved: t.IsResolved,
-				IsOutdated: t.IsOutdated,
+				ID:          t.ID,
+				Path:        t.Path,
+				Line:        t.Line,
+				IsResolved:  t.IsResolved,
+				IsOutdated:  t.IsOutdated,
+				Truncated:   t.Truncated,
+				CodeChanged: t.CodeChangedSinceComment,
+				ChangedBy:   toXMLCommitRefs(t.ChangedBy),
 			}
 			for _, c := range t.Comments {
 				xt.Comments = append(xt.Comments, xmlComment{
@@ -108,15 +118,24 @@ func (f *XMLFormatter) FormatChecks(w io.Writer, result *domain.ChecksResult) er
 		FailCount:     result.FailCount,
 		PendingCount:  result.PendingCount,
 		Since:         result.Since,
+		BaseRef:       result.BaseRef,
+		BaseSHA:       result.BaseSHA,
 	}
 	for _, ch := range result.Checks {
 		xc := xmlCheckRun{
-			ID:         ch.ID,
-			Name:       ch.Name,
-			Status:     ch.Status,
-			Conclusion: ch.Conclusion,
-			HTMLURL:    ch.HTMLURL,
-			LogExcerpt: ch.LogExcerpt,
+			ID:             ch.ID,
+			Kind:           string(ch.Kind),
+			Name:           ch.Name,
+			Status:         ch.Status,
+			Conclusion:     ch.Conclusion,
+			HTMLURL:        ch.HTMLURL,
+			Description:    ch.Description,
+			LogExcerpt:     ch.LogExcerpt,
+			FailedSteps:    toXMLJobSteps(ch.FailedSteps),
+			FailedTests:    toXMLFailedTests(ch.FailedTests),
+			Flaky:          toXMLFlaky(ch.Flaky),
+			BaseComparison: ch.BaseComparison,
+			BaseConclusion: ch.BaseConclusion,
 		}
 		for _, a := range ch.Annotations {
 			xc.Annotations = append(xc.Annotations, xmlAnnotation{
@@ -222,22 +241,249 @@ func (f *XMLFormatter) FormatDismissResults(w io.Writer, result *domain.DismissR
 	return err
 }
 
+func (f *XMLFormatter) FormatSuggestions(w io.Writer, result *domain.SuggestionResults) error {
+	out := xmlSuggestionResults{
+		PRNumber:        result.PRNumber,
+		ApplicableCount: result.ApplicableCount,
+		AppliedCount:    result.AppliedCount,
+		ConflictCount:   result.ConflictCount,
+		FailureCount:    result.FailureCount,
+		DryRun:          result.DryRun,
+	}
+	for _, s := range result.Suggestions {
+		out.Suggestions = append(out.Suggestions, xmlSuggestion{
+			ThreadID:    s.ThreadID,
+			CommentID:   s.CommentID,
+			Author:      s.Author,
+			Path:        s.Path,
+			StartLine:   s.StartLine,
+			EndLine:     s.EndLine,
+			Status:      s.Status,
+			Replied:     s.Replied,
+			Resolved:    s.Resolved,
+			URL:         s.URL,
+			Reason:      s.Reason,
+			Error:       s.Error,
+			Original:    s.Original,
+			Replacement: s.Replacement,
+			Diff:        s.Diff,
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (f *XMLFormatter) FormatPlanResults(w io.Writer, result *domain.PlanResults) error {
+	out := xmlPlanResults{
+		PRNumber:     result.PRNumber,
+		SuccessCount: result.SuccessCount,
+		FailureCount: result.FailureCount,
+		SkippedCount: result.SkippedCount,
+		DryRun:       result.DryRun,
+	}
+	for _, r := range result.Results {
+		out.Results = append(out.Results, xmlPlanAction{
+			Index:    r.Index,
+			Action:   r.Action,
+			ThreadID: r.ThreadID,
+			ReviewID: r.ReviewID,
+			Status:   r.Status,
+			URL:      r.URL,
+			Error:    r.Error,
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (f *XMLFormatter) FormatAudit(w io.Writer, result *domain.AuditResult) error {
+	out := xmlAudit{Path: result.Path, Count: len(result.Entries)}
+	for _, e := range result.Entries {
+		out.Entries = append(out.Entries, xmlAuditEntry{
+			Time:      e.Time.Format(time.RFC3339),
+			Repo:      e.Repo,
+			PR:        e.PR,
+			Target:    e.Target,
+			Action:    e.Action,
+			Outcome:   e.Outcome,
+			BodyHash:  e.BodyHash,
+			CommentID: e.CommentID,
+			Error:     e.Error,
+			Command:   e.Command,
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (f *XMLFormatter) FormatRerunResults(w io.Writer, result *domain.RerunResults) error {
+	out := xmlRerunResults{
+		PRNumber:     result.PRNumber,
+		SuccessCount: result.SuccessCount,
+		FailureCount: result.FailureCount,
+		DryRun:       result.DryRun,
+	}
+	for _, r := range result.Results {
+		out.Results = append(out.Results, xmlRerunResult{
+			RunID:   r.RunID,
+			JobID:   r.JobID,
+			Action:  r.Action,
+			Success: r.Success,
+			Error:   r.Error,
+			Checks:  r.Checks,
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (f *XMLFormatter) FormatUpdateBranch(w io.Writer, result *domain.UpdateBranchResult) error {
+	out := xmlUpdateBranch{
+		PRNumber:        result.PRNumber,
+		BaseRef:         result.BaseRef,
+		Method:          result.Method,
+		BehindBy:        result.BehindBy,
+		PreviousHeadSHA: result.PreviousHeadSHA,
+		HeadSHA:         result.HeadSHA,
+		Updated:         result.Updated,
+		Action:          result.Action,
+		DryRun:          result.DryRun,
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (f *XMLFormatter) FormatMergeResult(w io.Writer, result *domain.MergeResult) error {
+	out := xmlMergeResult{
+		PRNumber:       result.PRNumber,
+		Method:         result.Method,
+		Mode:           result.Mode,
+		HeadSHA:        result.HeadSHA,
+		Action:         result.Action,
+		Merged:         result.Merged,
+		MergeCommitSHA: result.MergeCommitSHA,
+		DryRun:         result.DryRun,
+		Error:          result.Error,
+		Blockers:       toXMLBlockers(result.Blockers),
+		Deferred:       toXMLBlockers(result.Deferred),
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (f *XMLFormatter) FormatJobLog(w io.Writer, result *domain.JobLogResult) error {
+	out := xmlJobLog{
+		PRNumber:   result.PRNumber,
+		CheckID:    result.CheckID,
+		CheckName:  result.CheckName,
+		Conclusion: result.Conclusion,
+		HTMLURL:    result.HTMLURL,
+		TotalLines: result.TotalLines,
+		Steps:      toXMLJobSteps(result.Steps),
+	}
+	if result.Step != nil {
+		step := toXMLJobSteps([]domain.JobStep{*result.Step})[0]
+		out.Step = &step
+	}
+	for _, l := range result.Lines {
+		out.Lines = append(out.Lines, xmlLogLine(l))
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
 func (f *XMLFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) error {
 	out := xmlStatus{
 		PRNumber:     result.PRNumber,
 		IsMergeReady: result.IsMergeReady,
+		MergeState:   toXMLMergeState(result),
 		Comments: xmlStatusComments{
 			TotalCount:      result.Comments.TotalCount,
 			ResolvedCount:   result.Comments.ResolvedCount,
 			UnresolvedCount: result.Comments.UnresolvedCount,
+
+			ConversationCount:           result.Comments.ConversationCount,
+			UnansweredConversationCount: result.Comments.UnansweredConversationCount,
+			Conversation:                toXMLConversation(result.Comments.Conversation),
 		},
 		Checks: xmlStatusChecks{
 			OverallStatus: string(result.Checks.OverallStatus),
 			PassCount:     result.Checks.PassCount,
 			FailCount:     result.Checks.FailCount,
 			PendingCount:  result.Checks.PendingCount,
+			BaseRef:       result.Checks.BaseRef,
+			BaseSHA:       result.Checks.BaseSHA,
 		},
 	}
+	out.Blockers = toXMLBlockers(result.Blockers)
+	if req := result.MergeRequirements; req != nil {
+		out.Requirements = &xmlRequirements{
+			BaseBranch:                    req.BaseBranch,
+			RequiredApprovals:             req.RequiredApprovals,
+			RequireCodeOwnerReview:        req.RequireCodeOwnerReview,
+			DismissStaleApprovals:         req.DismissStaleApprovals,
+			RequireConversationResolution: req.RequireConversationResolution,
+			RequireLinearHistory:          req.RequireLinearHistory,
+			RequiredChecks:                req.RequiredChecks,
+			Sources:                       req.Sources,
+		}
+	}
 	if result.ReviewSettled != nil {
 		out.ReviewSettled = &xmlReviewSettlement{
 			Phase:         string(result.ReviewSettled.Phase),
@@ -269,6 +515,7 @@ func (f *XMLFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) er
 			Line:       t.Line,
 			IsResolved: t.IsResolved,
 			IsOutdated: t.IsOutdated,
+			Truncated:  t.Truncated,
 		}
 		for _, c := range t.Comments {
 			xt.Comments = append(xt.Comments, xmlComment{
@@ -288,12 +535,19 @@ func (f *XMLFormatter) FormatStatus(w io.Writer, result *domain.StatusResult) er
 			continue
 		}
 		xc := xmlCheckRun{
-			ID:         ch.ID,
-			Name:       ch.Name,
-			Status:     ch.Status,
-			Conclusion: ch.Conclusion,
-			HTMLURL:    ch.HTMLURL,
-			LogExcerpt: ch.LogExcerpt,
+			ID:             ch.ID,
+			Kind:           string(ch.Kind),
+			Name:           ch.Name,
+			Status:         ch.Status,
+			Conclusion:     ch.Conclusion,
+			HTMLURL:        ch.HTMLURL,
+			Description:    ch.Description,
+			LogExcerpt:     ch.LogExcerpt,
+			FailedSteps:    toXMLJobSteps(ch.FailedSteps),
+			FailedTests:    toXMLFailedTests(ch.FailedTests),
+			Flaky:          toXMLFlaky(ch.Flaky),
+			BaseComparison: ch.BaseComparison,
+			BaseConclusion: ch.BaseConclusion,
 		}
 		for _, a := range ch.Annotations {
 			xc.Annotations = append(xc.Annotations, xmlAnnotation{
@@ -349,6 +603,7 @@ func (f *XMLFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRes
 	out := xmlCompactStatus{
 		PRNumber:     result.PRNumber,
 		IsMergeReady: result.IsMergeReady,
+		MergeState:   toXMLMergeState(result),
 		PRAge:        result.PRAge,
 		LastUpdate:   result.LastUpdate,
 		ReviewCycles: result.ReviewCycles,
@@ -356,6 +611,7 @@ func (f *XMLFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRes
 		CheckStatus:  string(result.Checks.OverallStatus),
 		PassCount:    result.Checks.PassCount,
 		FailCount:    result.Checks.FailCount,
+		Blockers:     toXMLBlockers(result.Blockers),
 	}
 
 	for _, t := range result.Comments.Threads {
@@ -380,12 +636,19 @@ func (f *XMLFormatter) FormatCompactStatus(w io.Writer, result *domain.StatusRes
 			continue
 		}
 		xc := xmlCheckRun{
-			ID:         ch.ID,
-			Name:       ch.Name,
-			Status:     ch.Status,
-			Conclusion: ch.Conclusion,
-			HTMLURL:    ch.HTMLURL,
-			LogExcerpt: ch.LogExcerpt,
+			ID:             ch.ID,
+			Kind:           string(ch.Kind),
+			Name:           ch.Name,
+			Status:         ch.Status,
+			Conclusion:     ch.Conclusion,
+			HTMLURL:        ch.HTMLURL,
+			Description:    ch.Description,
+			LogExcerpt:     ch.LogExcerpt,
+			FailedSteps:    toXMLJobSteps(ch.FailedSteps),
+			FailedTests:    toXMLFailedTests(ch.FailedTests),
+			Flaky:          toXMLFlaky(ch.Flaky),
+			BaseComparison: ch.BaseComparison,
+			BaseConclusion: ch.BaseConclusion,
 		}
 		for _, a := range ch.Annotations {
 			xc.Annotations = append(xc.Annotations, xmlAnnotation{
@@ -511,15 +774,51 @@ type xmlComments struct {
 	UnansweredCount int         `xml:"unanswered_count,attr"`
 	Since           string      `xml:"since,attr,omitempty"`
 	Threads         []xmlThread `xml:"thread"`
+
+	ConversationCount           int                      `xml:"conversation_count,attr"`
+	UnansweredConversationCount int                      `xml:"unanswered_conversation_count,attr"`
+	Conversation                []xmlConversationComment `xml:"conversation>entry,omitempty"`
+}
+
+type xmlConversationComment struct {
+	ID              string `xml:"id,attr"`
+	Kind            string `xml:"kind,attr"`
+	Author          string `xml:"author,attr"`
+	IsBot           bool   `xml:"is_bot,attr"`
+	ReviewState     string `xml:"review_state,attr,omitempty"`
+	CreatedAt       string `xml:"created_at,attr"`
+	UpdatedAt       string `xml:"updated_at,attr,omitempty"`
+	URL             string `xml:"url,attr"`
+	IsMinimized     bool   `xml:"minimized,attr,omitempty"`
+	MinimizedReason string `xml:"minimized_reason,attr,omitempty"`
+	HasReply        bool   `xml:"has_reply,attr"`
+	Body            string `xml:"body"`
 }
 
 type xmlThread struct {
-	ID         string       `xml:"id,attr"`
-	Path       string       `xml:"path,attr"`
-	Line       int          `xml:"line,attr"`
-	IsResolved bool         `xml:"resolved,attr"`
-	IsOutdated bool         `xml:"outdated,attr"`
-	Comments   []xmlComment `xml:"comment"`
+	ID          string         `xml:"id,attr"`
+	Path        string         `xml:"path,attr"`
+	Line        int            `xml:"line,attr"`
+	IsResolved  bool           `xml:"resolved,attr"`
+	IsOutdated  bool           `xml:"outdated,attr"`
+	Truncated   bool           `xml:"truncated,attr,omitempty"`
+	CodeChanged *bool          `xml:"code_changed_since_comment,attr,omitempty"`
+	ChangedBy   []xmlCommitRef `xml:"changed_by>commit"`
+	Comments    []xmlComment   `xml:"comment"`
+}
+
+type xmlCommitRef struct {
+	SHA         string `xml:"sha,attr"`
+	CommittedAt string `xml:"committed_at,attr"`
+	Message     string `xml:",chardata"`
+}
+
+func toXMLCommitRefs(commits []domain.CommitRef) []xmlCommitRef {
+	var out []xmlCommitRef
+	for _, c := range commits {
+		out = append(out, xmlCommitRef{SHA: c.SHA, CommittedAt: formatXMLTime(c.CommittedAt), Message: c.Message})
+	}
+	return out
 }
 
 type xmlComment struct {
@@ -541,17 +840,99 @@ type xmlChecks struct {
 	FailCount     int           `xml:"fail_count,attr"`
 	PendingCount  int           `xml:"pending_count,attr"`
 	Since         string        `xml:"since,attr,omitempty"`
+	BaseRef       string        `xml:"base_ref,attr,omitempty"`
+	BaseSHA       string        `xml:"base_sha,attr,omitempty"`
 	Checks        []xmlCheckRun `xml:"check"`
 }
 
 type xmlCheckRun struct {
-	ID          int64           `xml:"id,attr"`
-	Name        string          `xml:"name,attr"`
-	Status      string          `xml:"status,attr"`
-	Conclusion  string          `xml:"conclusion,attr"`
-	HTMLURL     string          `xml:"html_url,attr"`
-	Annotations []xmlAnnotation `xml:"annotation,omitempty"`
-	LogExcerpt  string          `xml:"log_excerpt,omitempty"`
+	ID             int64           `xml:"id,attr"`
+	Kind           string          `xml:"kind,attr,omitempty"`
+	Name           string          `xml:"name,attr"`
+	Status         string          `xml:"status,attr"`
+	Conclusion     string          `xml:"conclusion,attr"`
+	HTMLURL        string          `xml:"html_url,attr"`
+	BaseComparison string          `xml:"base_comparison,attr,omitempty"`
+	BaseConclusion string          `xml:"base_conclusion,attr,omitempty"`
+	Description    string          `xml:"description,omitempty"`
+	Annotations    []xmlAnnotation `xml:"annotation,omitempty"`
+	LogExcerpt     string          `xml:"log_excerpt,omitempty"`
+	FailedSteps    []xmlJobStep    `xml:"failed_step,omitempty"`
+	FailedTests    []xmlFailedTest `xml:"failed_test,omitempty"`
+	Flaky          *xmlFlaky       `xml:"flaky,omitempty"`
+}
+
+type xmlFlaky struct {
+	Reason  string            `xml:"reason,attr"`
+	Flips   int               `xml:"flips,attr"`
+	Passes  int               `xml:"passes,attr"`
+	Fails   int               `xml:"fails,attr"`
+	History []xmlCheckOutcome `xml:"run"`
+}
+
+type xmlCheckOutcome struct {
+	SHA        string `xml:"sha,attr"`
+	CheckRunID int64  `xml:"check_run_id,attr"`
+	Conclusion string `xml:"conclusion,attr"`
+}
+
+func toXMLFlaky(fl *domain.FlakyCheck) *xmlFlaky {
+	if fl == nil {
+		return nil
+	}
+	out := &xmlFlaky{Reason: fl.Reason, Flips: fl.Flips, Passes: fl.Passes, Fails: fl.Fails}
+	for _, o := range fl.History {
+		out.History = append(out.History, xmlCheckOutcome{SHA: o.SHA, CheckRunID: o.CheckRunID, Conclusion: o.Conclusion})
+	}
+	return out
+}
+
+type xmlJobStep struct {
+	Number          int    `xml:"number,attr"`
+	Name            string `xml:"name,attr"`
+	Conclusion      string `xml:"conclusion,attr"`
+	DurationSeconds int    `xml:"duration_seconds,attr"`
+}
+
+func toXMLJobSteps(steps []domain.JobStep) []xmlJobStep {
+	var out []xmlJobStep
+	for _, st := range steps {
+		out = append(out, xmlJobStep{
+			Number:          st.Number,
+			Name:            st.Name,
+			Conclusion:      st.Conclusion,
+			DurationSeconds: st.DurationSeconds,
+		})
+	}
+	return out
+}
+
+type xmlFailedTest struct {
+	Name           string `xml:"name,attr"`
+	Package        string `xml:"package,attr,omitempty"`
+	File           string `xml:"file,attr,omitempty"`
+	Line           int    `xml:"line,attr,omitempty"`
+	Framework      string `xml:"framework,attr"`
+	BaseComparison string `xml:"base_comparison,attr,omitempty"`
+	Message        string `xml:"message,omitempty"`
+	Output         string `xml:"output,omitempty"`
+}
+
+func toXMLFailedTests(tests []domain.FailedTest) []xmlFailedTest {
+	var out []xmlFailedTest
+	for _, t := range tests {
+		out = append(out, xmlFailedTest{
+			Name:           t.Name,
+			Package:        t.Package,
+			File:           t.File,
+			Line:           t.Line,
+			Framework:      t.Framework,
+			BaseComparison: t.BaseComparison,
+			Message:        t.Message,
+			Output:         t.Output,
+		})
+	}
+	return out
 }
 
 type xmlAnnotation struct {
@@ -612,10 +993,146 @@ type xmlDismissError struct {
 	Message  string `xml:",chardata"`
 }
 
+type xmlRerunResults struct {
+	XMLName      xml.Name         `xml:"rerun_results"`
+	PRNumber     int              `xml:"pr_number,attr"`
+	SuccessCount int              `xml:"success_count,attr"`
+	FailureCount int              `xml:"failure_count,attr"`
+	DryRun       bool             `xml:"dry_run,attr,omitempty"`
+	Results      []xmlRerunResult `xml:"result"`
+}
+
+type xmlRerunResult struct {
+	RunID   int64    `xml:"run_id,attr"`
+	JobID   int64    `xml:"job_id,attr,omitempty"`
+	Action  string   `xml:"action,attr"`
+	Success bool     `xml:"success,attr"`
+	Error   string   `xml:"error,omitempty"`
+	Checks  []string `xml:"check"`
+}
+
+type xmlSuggestionResults struct {
+	XMLName         xml.Name        `xml:"suggestions"`
+	PRNumber        int             `xml:"pr_number,attr"`
+	ApplicableCount int             `xml:"applicable_count,attr"`
+	AppliedCount    int             `xml:"applied_count,attr"`
+	ConflictCount   int             `xml:"conflict_count,attr"`
+	FailureCount    int             `xml:"failure_count,attr"`
+	DryRun          bool            `xml:"dry_run,attr,omitempty"`
+	Suggestions     []xmlSuggestion `xml:"suggestion"`
+}
+
+type xmlSuggestion struct {
+	ThreadID    string   `xml:"thread_id,attr"`
+	CommentID   string   `xml:"comment_id,attr"`
+	Author      string   `xml:"author,attr"`
+	Path        string   `xml:"path,attr"`
+	StartLine   int      `xml:"start_line,attr,omitempty"`
+	EndLine     int      `xml:"end_line,attr,omitempty"`
+	Status      string   `xml:"status,attr"`
+	Replied     bool     `xml:"replied,attr,omitempty"`
+	Resolved    bool     `xml:"resolved,attr,omitempty"`
+	URL         string   `xml:"url,omitempty"`
+	Reason      string   `xml:"reason,omitempty"`
+	Error       string   `xml:"error,omitempty"`
+	Original    []string `xml:"original>line"`
+	Replacement []string `xml:"replacement>line"`
+	Diff        string   `xml:"diff,omitempty"`
+}
+
+type xmlPlanResults struct {
+	XMLName      xml.Name        `xml:"plan_results"`
+	PRNumber     int             `xml:"pr_number,attr"`
+	SuccessCount int             `xml:"success_count,attr"`
+	FailureCount int             `xml:"failure_count,attr"`
+	SkippedCount int             `xml:"skipped_count,attr"`
+	DryRun       bool            `xml:"dry_run,attr,omitempty"`
+	Results      []xmlPlanAction `xml:"action"`
+}
+
+type xmlPlanAction struct {
+	Index    int    `xml:"index,attr"`
+	Action   string `xml:"action,attr"`
+	ThreadID string `xml:"thread_id,attr,omitempty"`
+	ReviewID string `xml:"review_id,attr,omitempty"`
+	Status   string `xml:"status,attr"`
+	URL      string `xml:"url,omitempty"`
+	Error    string `xml:"error,omitempty"`
+}
+
+type xmlAudit struct {
+	XMLName xml.Name        `xml:"audit"`
+	Path    string          `xml:"path,attr"`
+	Count   int             `xml:"count,attr"`
+	Entries []xmlAuditEntry `xml:"entry"`
+}
+
+type xmlAuditEntry struct {
+	Time      string `xml:"time,attr"`
+	Repo      string `xml:"repo,attr,omitempty"`
+	PR        int    `xml:"pr,attr,omitempty"`
+	Target    string `xml:"target,attr"`
+	Action    string `xml:"action,attr"`
+	Outcome   string `xml:"outcome,attr"`
+	BodyHash  string `xml:"body_hash,omitempty"`
+	CommentID int64  `xml:"comment_id,omitempty"`
+	Error     string `xml:"error,omitempty"`
+	Command   string `xml:"command"`
+}
+
+type xmlUpdateBranch struct {
+	XMLName         xml.Name `xml:"update_branch"`
+	PRNumber        int      `xml:"pr_number,attr"`
+	BaseRef         string   `xml:"base_ref,attr"`
+	Method          string   `xml:"method,attr"`
+	BehindBy        int      `xml:"behind_by,attr"`
+	PreviousHeadSHA string   `xml:"previous_head_sha,attr"`
+	HeadSHA         string   `xml:"head_sha,attr,omitempty"`
+	Updated         bool     `xml:"updated,attr"`
+	Action          string   `xml:"action,attr"`
+	DryRun          bool     `xml:"dry_run,attr,omitempty"`
+}
+
+type xmlMergeResult struct {
+	XMLName        xml.Name     `xml:"merge"`
+	PRNumber       int          `xml:"pr_number,attr"`
+	Method         string       `xml:"method,attr"`
+	Mode           string       `xml:"mode,attr"`
+	HeadSHA        string       `xml:"head_sha,attr"`
+	Action         string       `xml:"action,attr"`
+	Merged         bool         `xml:"merged,attr"`
+	MergeCommitSHA string       `xml:"merge_commit_sha,attr,omitempty"`
+	DryRun         bool         `xml:"dry_run,attr,omitempty"`
+	Error          string       `xml:"error,omitempty"`
+	Blockers       []xmlBlocker `xml:"blocker,omitempty"`
+	Deferred       []xmlBlocker `xml:"deferred>blocker,omitempty"`
+}
+
+type xmlJobLog struct {
+	XMLName    xml.Name     `xml:"job_log"`
+	PRNumber   int          `xml:"pr_number,attr"`
+	CheckID    int64        `xml:"check_id,attr"`
+	CheckName  string       `xml:"check_name,attr"`
+	Conclusion string       `xml:"conclusion,attr"`
+	HTMLURL    string       `xml:"html_url,attr,omitempty"`
+	TotalLines int          `xml:"total_lines,attr"`
+	Step       *xmlJobStep  `xml:"step,omitempty"`
+	Steps      []xmlJobStep `xml:"steps>step,omitempty"`
+	Lines      []xmlLogLine `xml:"line"`
+}
+
+type xmlLogLine struct {
+	Number int    `xml:"n,attr"`
+	Text   string `xml:",chardata"`
+}
+
 type xmlStatus struct {
 	XMLName       xml.Name             `xml:"status"`
 	PRNumber      int                  `xml:"pr_number,attr"`
 	IsMergeReady  bool                 `xml:"is_merge_ready,attr"`
+	MergeState    *xmlMergeState       `xml:"merge_state,omitempty"`
+	Blockers      []xmlBlocker         `xml:"blocker,omitempty"`
+	Requirements  *xmlRequirements     `xml:"merge_requirements,omitempty"`
 	Comments      xmlStatusComments    `xml:"comments"`
 	Checks        xmlStatusChecks      `xml:"checks"`
 	Reviews       []xmlReview          `xml:"review,omitempty"`
@@ -624,6 +1141,33 @@ type xmlStatus struct {
 	ReviewSettled *xmlReviewSettlement `xml:"review_settled,omitempty"`
 }
 
+type xmlMergeState struct {
+	Status       string `xml:"status,attr,omitempty"`
+	Mergeable    string `xml:"mergeable,attr,omitempty"`
+	BaseRef      string `xml:"base_ref,attr,omitempty"`
+	IsDraft      bool   `xml:"is_draft,attr"`
+	HasConflicts bool   `xml:"has_conflicts,attr"`
+	IsBehind     bool   `xml:"is_behind,attr"`
+	BehindBy     int    `xml:"behind_by,attr,omitempty"`
+}
+
+type xmlBlocker struct {
+	Rule    string `xml:"rule,attr"`
+	Source  string `xml:"source,attr"`
+	Message string `xml:",chardata"`
+}
+
+type xmlRequirements struct {
+	BaseBranch                    string   `xml:"base_branch,attr"`
+	RequiredApprovals             int      `xml:"required_approvals,attr"`
+	RequireCodeOwnerReview        bool     `xml:"require_code_owner_review,attr,omitempty"`
+	DismissStaleApprovals         bool     `xml:"dismiss_stale_approvals,attr,omitempty"`
+	RequireConversationResolution bool     `xml:"require_conversation_resolution,attr,omitempty"`
+	RequireLinearHistory          bool     `xml:"require_linear_history,attr,omitempty"`
+	RequiredChecks                []string `xml:"required_check,omitempty"`
+	Sources                       []string `xml:"source,omitempty"`
+}
+
 type xmlReviewSettlement struct {
 	Phase         string `xml:"phase,attr"`
 	Confidence    string `xml:"confidence,attr,omitempty"`
@@ -638,6 +1182,10 @@ type xmlStatusComments struct {
 	ResolvedCount   int         `xml:"resolved_count,attr"`
 	UnresolvedCount int         `xml:"unresolved_count,attr"`
 	Threads         []xmlThread `xml:"thread,omitempty"`
+
+	ConversationCount           int                      `xml:"conversation_count,attr"`
+	UnansweredConversationCount int                      `xml:"unanswered_conversation_count,attr"`
+	Conversation                []xmlConversationComment `xml:"conversation>entry,omitempty"`
 }
 
 type xmlStatusChecks struct {
@@ -645,6 +1193,8 @@ type xmlStatusChecks struct {
 	PassCount     int           `xml:"pass_count,attr"`
 	FailCount     int           `xml:"fail_count,attr"`
 	PendingCount  int           `xml:"pending_count,attr"`
+	BaseRef       string        `xml:"base_ref,attr,omitempty"`
+	BaseSHA       string        `xml:"base_sha,attr,omitempty"`
 	FailedChecks  []xmlCheckRun `xml:"failed_check,omitempty"`
 }
 
@@ -668,6 +1218,8 @@ type xmlCompactStatus struct {
 	LastUpdate   string             `xml:"last_update,attr,omitempty"`
 	ReviewCycles int                `xml:"review_cycles,attr,omitempty"`
 	Unresolved   int                `xml:"unresolved,attr"`
+	MergeState   *xmlMergeState     `xml:"merge_state,omitempty"`
+	Blockers     []xmlBlocker       `xml:"blocker,omitempty"`
 	CheckStatus  string             `xml:"check_status,attr"`
 	PassCount    int                `xml:"pass_count,attr"`
 	FailCount    int                `xml:"fail_count,attr"`
@@ -683,6 +1235,54 @@ type xmlCompactThread struct {
 	BodyPreview string `xml:"body_preview"`
 }
 
+// toXMLMergeState returns nil when PR metadata was not available.
+func toXMLMergeState(result *domain.StatusResult) *xmlMergeState {
+	if result.MergeStateStatus == "" && result.Mergeable == "" {
+		return nil
+	}
+	return &xmlMergeState{
+		Status:       result.MergeStateStatus,
+		Mergeable:    result.Mergeable,
+		BaseRef:      result.BaseRef,
+		IsDraft:      result.IsDraft,
+		HasConflicts: result.HasConflicts,
+		IsBehind:     result.IsBehind,
+		BehindBy:     result.BehindBy,
+	}
+}
+
+func toXMLConversation(entries []domain.ConversationComment) []xmlConversationComment {
+	var out []xmlConversationComment
+	for _, c := range entries {
+		xc := xmlConversationComment{
+			ID:              c.ID,
+			Kind:            c.Kind,
+			Author:          c.Author,
+			IsBot:           c.IsBot,
+			ReviewState:     string(c.ReviewState),
+			CreatedAt:       formatXMLTime(c.CreatedAt),
+			URL:             c.URL,
+			IsMinimized:     c.IsMinimized,
+			MinimizedReason: c.MinimizedReason,
+			HasReply:        c.HasReply,
+			Body:            c.Body,
+		}
+		if c.UpdatedAt.After(c.CreatedAt) {
+			xc.UpdatedAt = formatXMLTime(c.UpdatedAt)
+		}
+		out = append(out, xc)
+	}
+	return out
+}
+
+func toXMLBlockers(blockers []domain.MergeBlocker) []xmlBlocker {
+	var out []xmlBlocker
+	for _, b := range blockers {
+		out = append(out, xmlBlocker{Rule: b.Rule, Source: b.Source, Message: b.Message})
+	}
+	return out
+}
+
 func formatXMLTime(t time.Time) string {
 	if t.IsZero() {
 		return ""
@@ -5,6 +5,8 @@ import (
 	"encoding/xml"
 	"strings"
 	"testing"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
 )
 
 func TestXMLFormatterWellFormed(t *testing.T) {
@@ -363,3 +365,53 @@ func TestXMLFormatterHasHeader(t *testing.T) {
 		t.Error("XML output missing XML declaration header")
 	}
 }
+
+func TestXMLStatusMergeState(t *testing.T) {
+	var buf bytes.Buffer
+	f := &XMLFormatter{}
+
+	result := sampleStatusResult()
+	result.BaseRef = "main"
+	result.IsDraft = true
+	result.Mergeable = "CONFLICTING"
+	result.MergeStateStatus = "DIRTY"
+	result.HasConflicts = true
+	if err := f.FormatStatus(&buf, result); err != nil {
+		t.Fatalf("FormatStatus: %v", err)
+	}
+
+	var v xmlStatus
+	if err := xml.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	want := &xmlMergeState{
+		Status: "DIRTY", Mergeable: "CONFLICTING", BaseRef: "main", IsDraft: true, HasConflicts: true,
+	}
+	if v.MergeState == nil || *v.MergeState != *want {
+		t.Errorf("merge_state = %+v, want %+v", v.MergeState, want)
+	}
+}
+
+func TestXMLMergeResult(t *testing.T) {
+	var buf bytes.Buffer
+	f := &XMLFormatter{}
+
+	err := f.FormatMergeResult(&buf, &domain.MergeResult{
+		PRNumber: 42, Method: "merge", Mode: "direct", HeadSHA: "aaa111", Action: "blocked",
+		Blockers: []domain.MergeBlocker{{Rule: "unresolved_threads", Source: "default", Message: "1 unresolved review thread"}},
+	})
+	if err != nil {
+		t.Fatalf("FormatMergeResult: %v", err)
+	}
+
+	var v xmlMergeResult
+	if err := xml.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if v.Action != "blocked" || v.HeadSHA != "aaa111" || v.Merged {
+		t.Errorf("merge = %+v", v)
+	}
+	if len(v.Blockers) != 1 || v.Blockers[0].Rule != "unresolved_threads" || len(v.Deferred) != 0 {
+		t.Errorf("blockers = %+v, deferred = %+v", v.Blockers, v.Deferred)
+	}
+}
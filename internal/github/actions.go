@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RerunFailedJobs re-runs the failed jobs of a workflow run, and the jobs
+// that depend on them, as a new run attempt.
+func (c *Client) RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/rerun-failed-jobs", owner, repo, runID)
+	if err := c.postActions(ctx, path); err != nil {
+		return fmt.Errorf("re-run failed jobs of run %d: %w", runID, err)
+	}
+	return nil
+}
+
+// RerunJob re-runs one workflow job, and the jobs that depend on it.
+func (c *Client) RerunJob(ctx context.Context, owner, repo string, jobID int64) error {
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/rerun", owner, repo, jobID)
+	if err := c.postActions(ctx, path); err != nil {
+		return fmt.Errorf("re-run job %d: %w", jobID, err)
+	}
+	return nil
+}
+
+// CancelRun cancels a queued or in-progress workflow run.
+func (c *Client) CancelRun(ctx context.Context, owner, repo string, runID int64) error {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/cancel", owner, repo, runID)
+	if err := c.postActions(ctx, path); err != nil {
+		return fmt.Errorf("cancel run %d: %w", runID, err)
+	}
+	return nil
+}
+
+// FetchJobRunID returns the ID of the workflow run a job belongs to. The job
+// may still be running, so the response is revalidated like any other.
+func (c *Client) FetchJobRunID(ctx context.Context, owner, repo string, jobID int64) (int64, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d", owner, repo, jobID)
+	var resp struct {
+		RunID int64 `json:"run_id"`
+	}
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, http.MethodGet, path, nil, &resp)
+	}); err != nil {
+		return 0, classifyError(err)
+	}
+	return resp.RunID, nil
+}
+
+// postActions sends a body-less POST to an Actions endpoint. They answer 201
+// or 202 with an empty JSON object.
+func (c *Client) postActions(ctx context.Context, path string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	slog.Debug("actions request", "path", path)
+	var resp struct{}
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, http.MethodPost, path, nil, &resp)
+	}); err != nil {
+		return classifyError(err)
+	}
+	return nil
+}
+
+// RunIDFromURL extracts the workflow run ID from an Actions job URL such as
+// https://github.com/o/r/actions/runs/123/job/456. It returns 0 for other
+// URLs, including the /runs/{check_run_id} form of non-Actions checks.
+func RunIDFromURL(htmlURL string) int64 {
+	const marker = "/actions/runs/"
+	idx := strings.Index(htmlURL, marker)
+	if idx < 0 {
+		return 0
+	}
+	rest := htmlURL[idx+len(marker):]
+	if end := strings.IndexAny(rest, "/?#"); end >= 0 {
+		rest = rest[:end]
+	}
+	id, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
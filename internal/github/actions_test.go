@@ -0,0 +1,25 @@
+package github
+
+import "testing"
+
+func TestRunIDFromURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want int64
+	}{
+		{"job URL", "https://github.com/o/r/actions/runs/123/job/456", 123},
+		{"run URL", "https://github.com/o/r/actions/runs/123", 123},
+		{"query string", "https://github.com/o/r/actions/runs/123?check_suite_focus=true", 123},
+		{"external check", "https://github.com/o/r/runs/789", 0},
+		{"commit status", "https://ci.example.com/build/1", 0},
+		{"empty", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RunIDFromURL(tt.url); got != tt.want {
+				t.Errorf("RunIDFromURL(%q) = %d, want %d", tt.url, got, tt.want)
+			}
+		})
+	}
+}
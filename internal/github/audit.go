@@ -0,0 +1,149 @@
+package github
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// DefaultAuditLogPath returns the file ghent logs its mutations to, in gh's
+// state directory (honors XDG_STATE_HOME).
+func DefaultAuditLogPath() string {
+	return filepath.Join(config.StateDir(), "ghent", "audit.jsonl")
+}
+
+// WithAuditLog appends an entry to the JSONL file at path for every resolve,
+// unresolve, reply, and dismiss call, recording command as the invoking
+// command line.
+func WithAuditLog(path, command string) Option {
+	return func(client *Client) {
+		client.audit = &auditLog{path: path, command: command}
+	}
+}
+
+// auditLog is an append-only JSONL file. Each entry is a single write to a
+// file opened with O_APPEND, so concurrent writers do not interleave lines.
+type auditLog struct {
+	path    string
+	command string
+	mu      sync.Mutex
+}
+
+// record appends e, stamped with the time, command line, and err. A failure
+// to write is logged but never fails the mutation, which already happened.
+func (c *Client) record(e domain.AuditEntry, err error) {
+	if c.audit == nil {
+		return
+	}
+	e.Time = time.Now().UTC()
+	e.Command = c.audit.command
+	if e.Outcome == "" {
+		e.Outcome = domain.AuditSuccess
+	}
+	if err != nil {
+		e.Outcome, e.Error = domain.AuditFailure, err.Error()
+	}
+	if writeErr := c.audit.append(e); writeErr != nil {
+		slog.Warn("audit log write failed", "path", c.audit.path, "error", writeErr)
+	}
+}
+
+func (l *auditLog) append(e domain.AuditEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// auditHash fingerprints a reply body or dismissal message without storing it.
+func auditHash(text string) string {
+	if text == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// AuditQuery selects audit log entries. Zero fields match everything.
+type AuditQuery struct {
+	Repo   string // OWNER/REPO, case-insensitive
+	PR     int
+	Action string
+	Since  time.Time // inclusive
+	Until  time.Time // exclusive
+}
+
+func (q AuditQuery) matches(e domain.AuditEntry) bool {
+	switch {
+	case q.Repo != "" && !strings.EqualFold(q.Repo, e.Repo):
+		return false
+	case q.PR != 0 && q.PR != e.PR:
+		return false
+	case q.Action != "" && q.Action != e.Action:
+		return false
+	case !q.Since.IsZero() && e.Time.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && !e.Time.Before(q.Until):
+		return false
+	}
+	return true
+}
+
+// ReadAuditLog returns the entries of the audit log at path that match q,
+// oldest first. A missing log has no entries; malformed lines are skipped.
+func ReadAuditLog(path string, q AuditQuery) ([]domain.AuditEntry, error) {
+	f, err := os.Open(path) //nolint:gosec // audit log location
+	if errors.Is(err, fs.ErrNotExist) {
+		return []domain.AuditEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	entries := []domain.AuditEntry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		var e domain.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			slog.Debug("skipping malformed audit log line", "path", path, "line", n, "error", err)
+			continue
+		}
+		if q.matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return entries, nil
+}
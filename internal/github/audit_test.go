@@ -0,0 +1,115 @@
+package github
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestAuditLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ghent", "audit.jsonl")
+	c := &Client{}
+	WithAuditLog(path, "gh-ghent resolve --pr 42")(c)
+
+	resolved := resolvedThreadNode{ID: "PRRT_1"}
+	resolved.PullRequest.Number = 42
+	resolved.Repository.NameWithOwner = "owner/repo"
+	c.recordResolution(domain.AuditResolve, "owner", "repo", 42, "PRRT_1", &resolved, nil)
+	c.recordResolution(domain.AuditUnresolve, "owner", "repo", 42, "PRRT_2", nil, errors.New("not found"))
+	c.recordReply("owner", "repo", 42, "PRRT_1", "Fixed", &domain.ReplyResult{CommentID: 7, AlreadyPosted: true}, nil)
+	c.recordReply("owner", "repo", 43, "PRRT_3", "Fixed", nil, errors.New("forbidden"))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("audit log mode = %o, want 600", perm)
+	}
+
+	got, err := ReadAuditLog(path, AuditQuery{})
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error: %v", err)
+	}
+	want := []domain.AuditEntry{
+		{Repo: "owner/repo", PR: 42, Target: "PRRT_1", Action: "resolve", Outcome: "success"},
+		{Repo: "owner/repo", PR: 42, Target: "PRRT_2", Action: "unresolve", Outcome: "failure", Error: "not found"},
+		{Repo: "owner/repo", PR: 42, Target: "PRRT_1", Action: "reply", BodyHash: auditHash("Fixed"), CommentID: 7, Outcome: "already_posted"},
+		{Repo: "owner/repo", PR: 43, Target: "PRRT_3", Action: "reply", BodyHash: auditHash("Fixed"), Outcome: "failure", Error: "forbidden"},
+	}
+	for i := range want {
+		want[i].Command = "gh-ghent resolve --pr 42"
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(domain.AuditEntry{}, "Time")); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+	for _, e := range got {
+		if time.Since(e.Time) > time.Minute {
+			t.Errorf("entry time %v is not current", e.Time)
+		}
+	}
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	// A client without WithAuditLog records nothing and does not panic.
+	(&Client{}).record(domain.AuditEntry{Action: domain.AuditResolve}, nil)
+}
+
+func TestReadAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := `{"time":"2026-03-01T10:00:00Z","repo":"owner/repo","pr":42,"target":"PRRT_1","action":"resolve","outcome":"success","command":"a"}
+not json
+{"time":"2026-03-01T11:00:00Z","repo":"Owner/Repo","pr":42,"target":"PRR_1","action":"dismiss","outcome":"success","command":"b"}
+{"time":"2026-03-01T12:00:00Z","repo":"owner/repo","pr":43,"target":"PRRT_2","action":"reply","outcome":"failure","command":"c"}
+{"time":"2026-03-01T13:00:00Z","repo":"other/repo","pr":42,"target":"PRRT_3","action":"reply","outcome":"success","command":"d"}
+`
+	if err := os.WriteFile(path, []byte(log), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	at := func(hour int) time.Time { return time.Date(2026, 3, 1, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		q    AuditQuery
+		want []string
+	}{
+		{name: "everything", q: AuditQuery{}, want: []string{"a", "b", "c", "d"}},
+		{name: "repo and PR", q: AuditQuery{Repo: "owner/repo", PR: 42}, want: []string{"a", "b"}},
+		{name: "PR in any repo", q: AuditQuery{PR: 42}, want: []string{"a", "b", "d"}},
+		{name: "action", q: AuditQuery{Action: domain.AuditReply}, want: []string{"c", "d"}},
+		{name: "time range", q: AuditQuery{Since: at(11), Until: at(13)}, want: []string{"b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ReadAuditLog(path, tt.q)
+			if err != nil {
+				t.Fatalf("ReadAuditLog() error: %v", err)
+			}
+			got := []string{}
+			for _, e := range entries {
+				got = append(got, e.Command)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("entries mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadAuditLog_Missing(t *testing.T) {
+	got, err := ReadAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"), AuditQuery{})
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ReadAuditLog() = %v, want no entries", got)
+	}
+}
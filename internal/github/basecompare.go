@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// FetchBaseChecks retrieves the latest check runs and commit statuses on the
+// head of a PR's base branch, without annotations. The result's HeadSHA is
+// the base branch head and BaseRef its name.
+func (c *Client) FetchBaseChecks(ctx context.Context, owner, repo string, pr int) (*domain.ChecksResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, pr)
+	var prResp struct {
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	}
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, "GET", path, nil, &prResp)
+	}); err != nil {
+		return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
+	}
+	baseRef := prResp.Base.Ref
+
+	path = fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, url.PathEscape(baseRef))
+	var branch struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, "GET", path, nil, &branch)
+	}); err != nil {
+		return nil, classifyWithContext(err, "branch", fmt.Sprintf("%s in %s/%s", baseRef, owner, repo))
+	}
+	sha := branch.Commit.SHA
+
+	runs, err := c.fetchCheckRuns(ctx, owner, repo, sha, false)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := c.fetchCommitStatuses(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("fetch commit statuses: %w", err)
+	}
+
+	result := &domain.ChecksResult{PRNumber: pr, HeadSHA: sha, BaseRef: baseRef, BaseSHA: sha}
+	for _, run := range runs {
+		result.Checks = append(result.Checks, mapCheckRunSummary(run))
+	}
+	if err := mergeCommitStatuses(result, statuses); err != nil {
+		return nil, err
+	}
+	slog.Debug("fetched base checks", "ref", baseRef, "sha", sha, "count", len(result.Checks), "duration", time.Since(start))
+	return result, nil
+}
+
+// CompareWithBase classifies every check of result against the same-named
+// check of the same kind on the base branch (see FetchBaseChecks):
+//
+//   - new_failure: fails on the PR, passes or does not exist on the base
+//   - pre_existing: fails on both
+//   - fixed_by_pr: passes on the PR, fails on the base
+//
+// A base check that is pending or cancelled says nothing either way and
+// leaves the check unclassified. Re-classifying a compared result is safe.
+func CompareWithBase(result, base *domain.ChecksResult) {
+	result.BaseRef, result.BaseSHA = base.BaseRef, base.HeadSHA
+	for i := range result.Checks {
+		ch := &result.Checks[i]
+		ch.BaseComparison, ch.BaseConclusion = "", ""
+
+		baseCh, found := BaseCheck(base, *ch)
+		ch.BaseConclusion = baseCh.Conclusion
+		baseOutcome := "missing"
+		if found {
+			baseOutcome = passFailOutcome(baseCh.Conclusion) // pass, fail, or "" for no evidence
+		}
+
+		switch {
+		case domain.IsFailConclusion(ch.Conclusion) && ch.Conclusion != "cancelled":
+			switch baseOutcome {
+			case "fail":
+				ch.BaseComparison = domain.BasePreExisting
+			case "pass", "missing":
+				ch.BaseComparison = domain.BaseNewFailure
+			}
+		case ch.Conclusion == "success" && baseOutcome == "fail":
+			ch.BaseComparison = domain.BaseFixedByPR
+		}
+	}
+}
+
+// BaseCheck returns the base branch's run of the check named like ch, with
+// the same kind, preferring a failing run when the name repeats.
+func BaseCheck(base *domain.ChecksResult, ch domain.CheckRun) (domain.CheckRun, bool) {
+	var match domain.CheckRun
+	found := false
+	for _, b := range base.Checks {
+		if b.Name != ch.Name || b.IsCommitStatus() != ch.IsCommitStatus() {
+			continue
+		}
+		if !found || (passFailOutcome(b.Conclusion) == "fail" && passFailOutcome(match.Conclusion) != "fail") {
+			match, found = b, true
+		}
+	}
+	return match, found
+}
+
+// CompareFailedTests marks each failed test of a PR check pre_existing when
+// a test with the same package and name also failed on the base branch, and
+// new_failure otherwise.
+func CompareFailedTests(tests, baseTests []domain.FailedTest) {
+	onBase := make(map[string]bool, len(baseTests))
+	for _, t := range baseTests {
+		onBase[t.Package+"\x00"+t.Name] = true
+	}
+	for i := range tests {
+		if onBase[tests[i].Package+"\x00"+tests[i].Name] {
+			tests[i].BaseComparison = domain.BasePreExisting
+		} else {
+			tests[i].BaseComparison = domain.BaseNewFailure
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestCompareWithBase(t *testing.T) {
+	result := &domain.ChecksResult{Checks: []domain.CheckRun{
+		{Name: "lint", Status: "completed", Conclusion: "failure"},
+		{Name: "test", Status: "completed", Conclusion: "failure"},
+		{Name: "new-job", Status: "completed", Conclusion: "failure"},
+		{Name: "build", Status: "completed", Conclusion: "success"},
+		{Name: "e2e", Status: "completed", Conclusion: "failure"},
+		{Name: "docs", Status: "completed", Conclusion: "success"},
+		{Name: "ci/jenkins", Kind: domain.CheckKindStatus, Status: "completed", Conclusion: "failure"},
+	}}
+	base := &domain.ChecksResult{HeadSHA: "base123", BaseRef: "main", Checks: []domain.CheckRun{
+		{Name: "lint", Status: "completed", Conclusion: "success"},
+		{Name: "lint", Status: "completed", Conclusion: "timed_out"}, // a failing duplicate wins
+		{Name: "test", Status: "completed", Conclusion: "success"},
+		{Name: "build", Status: "completed", Conclusion: "failure"},
+		{Name: "e2e", Status: "in_progress"},
+		{Name: "docs", Status: "completed", Conclusion: "success"},
+		{Name: "ci/jenkins", Kind: domain.CheckKindCheckRun, Status: "completed", Conclusion: "failure"},
+	}}
+
+	CompareWithBase(result, base)
+
+	type classified struct{ Name, Comparison, BaseConclusion string }
+	var got []classified
+	for _, ch := range result.Checks {
+		got = append(got, classified{ch.Name, ch.BaseComparison, ch.BaseConclusion})
+	}
+	want := []classified{
+		{"lint", domain.BasePreExisting, "timed_out"},
+		{"test", domain.BaseNewFailure, "success"},
+		{"new-job", domain.BaseNewFailure, ""},
+		{"build", domain.BaseFixedByPR, "failure"},
+		{"e2e", "", ""}, // base still running: no verdict
+		{"docs", "", "success"},
+		{"ci/jenkins", domain.BaseNewFailure, ""}, // kinds must match
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("classification mismatch (-want +got):\n%s", diff)
+	}
+	if result.BaseRef != "main" || result.BaseSHA != "base123" {
+		t.Errorf("base = %s@%s, want main@base123", result.BaseRef, result.BaseSHA)
+	}
+}
+
+func TestCompareFailedTests(t *testing.T) {
+	tests := []domain.FailedTest{
+		{Name: "TestA", Package: "pkg/a"},
+		{Name: "TestB", Package: "pkg/a"},
+		{Name: "TestA", Package: "pkg/b"},
+	}
+	CompareFailedTests(tests, []domain.FailedTest{{Name: "TestA", Package: "pkg/a"}})
+
+	want := []string{domain.BasePreExisting, domain.BaseNewFailure, domain.BaseNewFailure}
+	for i, tt := range tests {
+		if tt.BaseComparison != want[i] {
+			t.Errorf("%s/%s = %q, want %q", tt.Package, tt.Name, tt.BaseComparison, want[i])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// baseFileQuery fetches a file's text at the head of a PR's base branch. The
+// file is null when it does not exist there; text is null for binary files.
+const baseFileQuery = `
+query($owner: String!, $repo: String!, $pr: Int!, $path: String!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $pr) {
+      baseRef {
+        target {
+          ... on Commit {
+            file(path: $path) {
+              object { ... on Blob { text } }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+type baseFileResponse struct {
+	Repository *struct {
+		PullRequest *struct {
+			BaseRef *struct {
+				Target struct {
+					File *struct {
+						Object struct {
+							Text *string `json:"text"`
+						} `json:"object"`
+					} `json:"file"`
+				} `json:"target"`
+			} `json:"baseRef"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// FetchBaseFile returns the file at path as committed on the head of the
+// PR's base branch, or nil when it does not exist there. Reading from the
+// base rather than the PR (or a local checkout) means a PR cannot change the
+// rules it is judged by.
+func (c *Client) FetchBaseFile(ctx context.Context, owner, repo string, pr int, path string) ([]byte, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	vars := map[string]interface{}{"owner": owner, "repo": repo, "pr": pr, "path": path}
+	var resp baseFileResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, baseFileQuery, vars, &resp)
+	}); err != nil {
+		return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
+	}
+	if resp.Repository == nil || resp.Repository.PullRequest == nil {
+		return nil, &NotFoundError{Resource: "pull request", Detail: fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo)}
+	}
+	ref := resp.Repository.PullRequest.BaseRef
+	if ref == nil || ref.Target.File == nil || ref.Target.File.Object.Text == nil {
+		slog.Debug("no base branch file", "pr", pr, "path", path)
+		return nil, nil
+	}
+	return []byte(*ref.Target.File.Object.Text), nil
+}
@@ -0,0 +1,321 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+// cacheFileExt is the suffix of every cache entry file. Prune only touches
+// files it recognizes, so a misconfigured cache dir cannot lose user data.
+const cacheFileExt = ".ghent-cache"
+
+// DefaultCacheDir returns the directory ghent caches REST responses in,
+// next to gh's own cache (honors GH_CACHE_DIR and XDG_CACHE_HOME).
+func DefaultCacheDir() string {
+	return filepath.Join(config.CacheDir(), "ghent")
+}
+
+type immutableKey struct{}
+
+// withImmutableResponse marks the requests made with ctx as returning content
+// that never changes once it exists (completed job logs, annotations of a
+// completed check run). The cache serves such entries without revalidating.
+func withImmutableResponse(ctx context.Context) context.Context {
+	return context.WithValue(ctx, immutableKey{}, true)
+}
+
+func isImmutableResponse(ctx context.Context) bool {
+	v, _ := ctx.Value(immutableKey{}).(bool)
+	return v
+}
+
+// cacheEntry is the metadata line at the head of a cache file; the raw
+// response body follows it.
+type cacheEntry struct {
+	URL       string      `json:"url"`
+	Header    http.Header `json:"header"`
+	Immutable bool        `json:"immutable,omitempty"`
+	StoredAt  time.Time   `json:"stored_at"`
+}
+
+// cacheTransport is an http.RoundTripper that keeps successful REST GET
+// responses on disk. Mutable entries are revalidated with If-None-Match /
+// If-Modified-Since, so an unchanged resource costs a 304 — which GitHub does
+// not count against the rate limit. Immutable entries are served as-is.
+type cacheTransport struct {
+	dir  string
+	base http.RoundTripper
+}
+
+func newCacheTransport(dir string, base http.RoundTripper) *cacheTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cacheTransport{dir: dir, base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	immutable := isImmutableResponse(req.Context())
+
+	entry, body, err := t.load(key)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Debug("cache read failed, ignoring entry", "url", req.URL.String(), "error", err)
+		entry = nil
+	}
+	if entry != nil && entry.Immutable {
+		slog.Debug("cache hit", "url", req.URL.String())
+		t.touch(key)
+		return entry.response(req, body), nil
+	}
+
+	outReq := req
+	if entry != nil {
+		outReq = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			outReq.Header.Set("If-None-Match", etag)
+		}
+		if lm := entry.Header.Get("Last-Modified"); lm != "" {
+			outReq.Header.Set("If-Modified-Since", lm)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		_ = resp.Body.Close()
+		slog.Debug("cache revalidated", "url", req.URL.String())
+		// Keep the fresh rate-limit and validator headers from the 304.
+		for k, v := range resp.Header {
+			if k != "Content-Length" {
+				entry.Header[k] = v
+			}
+		}
+		entry.StoredAt = time.Now()
+		t.store(key, entry, body)
+		return entry.response(req, body), nil
+	}
+
+	if immutable && isRedirect(resp.StatusCode) {
+		resp, err = t.followRedirect(req, resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !cacheable(resp, immutable) {
+		return resp, nil
+	}
+
+	body, err = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.store(key, &cacheEntry{
+		URL:       req.URL.String(),
+		Header:    resp.Header.Clone(),
+		Immutable: immutable,
+		StoredAt:  time.Now(),
+	}, body)
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+// followRedirect resolves the redirect itself so the content lands under the
+// API URL's key. Job logs redirect to short-lived signed URLs; caching those
+// would never hit. The signed URL needs no credentials, and none are sent.
+func (t *cacheTransport) followRedirect(req *http.Request, resp *http.Response) (*http.Response, error) {
+	loc, err := resp.Location()
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("follow redirect for %s: %w", req.URL.Path, err)
+	}
+	next, err := http.NewRequestWithContext(req.Context(), http.MethodGet, loc.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("follow redirect for %s: %w", req.URL.Path, err)
+	}
+	return t.base.RoundTrip(next)
+}
+
+func isRedirect(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// cacheable reports whether a response is worth storing: only 200s, and
+// mutable ones only when they carry a validator to revalidate with.
+func cacheable(resp *http.Response, immutable bool) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return false
+	}
+	if immutable {
+		return true
+	}
+	return resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+// cacheKey identifies a request by URL and the headers that change the
+// response. The credential is part of the key so users sharing a cache dir
+// never see each other's private data.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n%s\n%s", req.Method, req.URL.String(),
+		req.Header.Get("Accept"), req.Header.Get("Authorization"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (t *cacheTransport) path(key string) string {
+	return filepath.Join(t.dir, key+cacheFileExt)
+}
+
+// load reads an entry. A missing entry returns an error wrapping fs.ErrNotExist.
+func (t *cacheTransport) load(key string) (*cacheEntry, []byte, error) {
+	data, err := os.ReadFile(t.path(key))
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, body, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, nil, fmt.Errorf("corrupt cache entry %s", key)
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(meta, &entry); err != nil {
+		return nil, nil, fmt.Errorf("corrupt cache entry %s: %w", key, err)
+	}
+	if entry.Header == nil {
+		entry.Header = http.Header{}
+	}
+	return &entry, body, nil
+}
+
+// store writes an entry atomically. Failures are logged, never returned: a
+// broken cache must not break the command that is using it.
+func (t *cacheTransport) store(key string, entry *cacheEntry, body []byte) {
+	if err := t.write(key, entry, body); err != nil {
+		slog.Debug("cache write failed", "url", entry.URL, "error", err)
+	}
+}
+
+func (t *cacheTransport) write(key string, entry *cacheEntry, body []byte) error {
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(t.dir, key+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	_, _ = w.Write(meta)
+	_ = w.WriteByte('\n')
+	_, _ = w.Write(body)
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), t.path(key))
+}
+
+// touch bumps an entry's mtime so PruneCache treats it as recently used.
+func (t *cacheTransport) touch(key string) {
+	now := time.Now()
+	_ = os.Chtimes(t.path(key), now, now)
+}
+
+func (e *cacheEntry) response(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// CachePruneResult summarizes a PruneCache run.
+type CachePruneResult struct {
+	Dir     string `json:"dir"`
+	Removed int    `json:"removed"`
+	Bytes   int64  `json:"bytes"`
+	Kept    int    `json:"kept"`
+}
+
+// PruneCache deletes cache entries in dir that were last used before cutoff.
+// A zero cutoff deletes every entry. A missing dir is not an error.
+func PruneCache(dir string, cutoff time.Time) (*CachePruneResult, error) {
+	result := &CachePruneResult{Dir: dir}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cache dir: %w", err)
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || (!strings.HasSuffix(name, cacheFileExt) && !strings.HasSuffix(name, ".tmp")) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if !cutoff.IsZero() && !info.ModTime().Before(cutoff) {
+			result.Kept++
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return result, fmt.Errorf("remove cache entry: %w", err)
+		}
+		result.Removed++
+		result.Bytes += info.Size()
+	}
+	return result, nil
+}
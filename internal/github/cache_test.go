@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func cacheGet(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestCacheTransport_RevalidatesWithETag(t *testing.T) {
+	var hits, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = io.WriteString(w, `{"total_count":1}`)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newCacheTransport(t.TempDir(), nil)}
+	for i := 0; i < 3; i++ {
+		code, body := cacheGet(t, client, srv.URL+"/check-runs")
+		if code != http.StatusOK || body != `{"total_count":1}` {
+			t.Fatalf("request %d: got %d %q", i, code, body)
+		}
+	}
+	if hits != 3 || notModified != 2 {
+		t.Errorf("server hits = %d (304s = %d), want 3 hits with 2 revalidated", hits, notModified)
+	}
+}
+
+func TestCacheTransport_SkipsResponsesWithoutValidator(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("unexpected conditional request")
+		}
+		_, _ = io.WriteString(w, "fresh")
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	client := &http.Client{Transport: newCacheTransport(dir, nil)}
+	cacheGet(t, client, srv.URL)
+	cacheGet(t, client, srv.URL)
+
+	if hits != 2 {
+		t.Errorf("server hits = %d, want 2", hits)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("cache has %d entries, want none", len(entries))
+	}
+}
+
+func TestCacheTransport_ImmutableFollowsRedirectOnce(t *testing.T) {
+	var apiHits, blobHits int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/logs", func(w http.ResponseWriter, r *http.Request) {
+		apiHits++
+		http.Redirect(w, r, fmt.Sprintf("/blob?sig=%d", apiHits), http.StatusFound)
+	})
+	mux.HandleFunc("/blob", func(w http.ResponseWriter, r *http.Request) {
+		blobHits++
+		if r.Header.Get("Authorization") != "" {
+			t.Error("credentials forwarded to the redirect target")
+		}
+		_, _ = io.WriteString(w, "##[error]boom")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := &http.Client{Transport: newCacheTransport(t.TempDir(), nil)}
+	ctx := withImmutableResponse(context.Background())
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/logs", nil)
+		req.Header.Set("Authorization", "token secret")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET logs: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "##[error]boom" {
+			t.Fatalf("request %d: body = %q", i, body)
+		}
+	}
+	if apiHits != 1 || blobHits != 1 {
+		t.Errorf("api hits = %d, blob hits = %d; want 1 each", apiHits, blobHits)
+	}
+}
+
+func TestCacheTransport_KeysOnCredential(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("conditional request reused another credential's entry")
+		}
+		_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newCacheTransport(t.TempDir(), nil)}
+	ctx := withImmutableResponse(context.Background())
+	for _, token := range []string{"token a", "token b"} {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		req.Header.Set("Authorization", token)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != token {
+			t.Errorf("body = %q, want %q", body, token)
+		}
+	}
+	if hits != 2 {
+		t.Errorf("server hits = %d, want 2", hits)
+	}
+}
+
+func TestPruneCache(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-48 * time.Hour)
+	files := map[string]time.Time{
+		"stale" + cacheFileExt: old,
+		"fresh" + cacheFileExt: time.Now(),
+		"abc-123.tmp":          old,
+		"notes.txt":            old,
+	}
+	for name, mtime := range files {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := PruneCache(dir, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("PruneCache() error: %v", err)
+	}
+	if got.Removed != 2 || got.Kept != 1 || got.Bytes != 8 {
+		t.Errorf("PruneCache() = %+v, want 2 removed (8 bytes), 1 kept", got)
+	}
+	for _, name := range []string{"fresh" + cacheFileExt, "notes.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should survive: %v", name, err)
+		}
+	}
+
+	got, err = PruneCache(dir, time.Time{})
+	if err != nil || got.Removed != 1 {
+		t.Errorf("PruneCache(all) = %+v, %v; want the remaining entry removed", got, err)
+	}
+
+	if got, err := PruneCache(filepath.Join(dir, "missing"), time.Time{}); err != nil || got.Removed != 0 {
+		t.Errorf("PruneCache(missing dir) = %+v, %v; want empty result", got, err)
+	}
+}
@@ -28,6 +28,24 @@ type checkRunNode struct {
 	} `json:"output"`
 }
 
+// combinedStatusResponse represents the REST API response for a commit's
+// combined status (legacy Statuses API, latest state per context).
+type combinedStatusResponse struct {
+	State      string             `json:"state"`
+	TotalCount int                `json:"total_count"`
+	Statuses   []commitStatusNode `json:"statuses"`
+}
+
+type commitStatusNode struct {
+	ID          int64   `json:"id"`
+	Context     string  `json:"context"`
+	State       string  `json:"state"` // pending, success, failure, error
+	Description *string `json:"description"`
+	TargetURL   *string `json:"target_url"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
 type annotationNode struct {
 	Path            string `json:"path"`
 	StartLine       int    `json:"start_line"`
@@ -53,12 +71,18 @@ func (c *Client) fetchHeadSHA(ctx context.Context, owner, repo string, pr int) (
 	return resp.Head.SHA, nil
 }
 
-// fetchCheckRuns retrieves all check runs for a commit SHA, paginating through results.
-func (c *Client) fetchCheckRuns(ctx context.Context, owner, repo, ref string) ([]checkRunNode, error) {
+// fetchCheckRuns retrieves all check runs for a commit SHA, paginating through
+// results. Only the latest attempt of each check is listed unless allAttempts
+// is set.
+func (c *Client) fetchCheckRuns(ctx context.Context, owner, repo, ref string, allAttempts bool) ([]checkRunNode, error) {
+	query := ""
+	if allAttempts {
+		query = "filter=all&"
+	}
 	var allRuns []checkRunNode
 	page := 1
 	for {
-		path := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?per_page=100&page=%d", owner, repo, ref, page)
+		path := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?%sper_page=100&page=%d", owner, repo, ref, query, page)
 		var resp checkRunsResponse
 		if err := doWithRetry(func() error {
 			return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
@@ -74,11 +98,35 @@ func (c *Client) fetchCheckRuns(ctx context.Context, owner, repo, ref string) ([
 	return allRuns, nil
 }
 
-// fetchAnnotations retrieves annotations for a single check run.
+// fetchCommitStatuses retrieves the latest commit status for every context on
+// a commit SHA via the combined status endpoint, paginating through results.
+func (c *Client) fetchCommitStatuses(ctx context.Context, owner, repo, ref string) ([]commitStatusNode, error) {
+	var all []commitStatusNode
+	page := 1
+	for {
+		path := fmt.Sprintf("repos/%s/%s/commits/%s/status?per_page=100&page=%d", owner, repo, ref, page)
+		var resp combinedStatusResponse
+		if err := doWithRetry(func() error {
+			return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
+		}); err != nil {
+			return nil, classifyError(err)
+		}
+		all = append(all, resp.Statuses...)
+		if len(resp.Statuses) == 0 || len(all) >= resp.TotalCount {
+			break
+		}
+		page++
+	}
+	return all, nil
+}
+
+// fetchAnnotations retrieves annotations for a single check run. It is only
+// called for completed runs, whose annotations never change, so the response
+// is cached without revalidation.
 func (c *Client) fetchAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]annotationNode, error) {
 	path := fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations", owner, repo, checkRunID)
 	var resp []annotationNode
-	if err := c.rest.DoWithContext(ctx, "GET", path, nil, &resp); err != nil {
+	if err := c.rest.DoWithContext(withImmutableResponse(ctx), "GET", path, nil, &resp); err != nil {
 		return nil, fmt.Errorf("list annotations for check %d: %w", checkRunID, err)
 	}
 	return resp, nil
@@ -99,13 +147,99 @@ func (c *Client) FetchChecks(ctx context.Context, owner, repo string, pr int) (*
 	}
 	slog.Debug("resolved head SHA", "sha", sha)
 
-	runs, err := c.fetchCheckRuns(ctx, owner, repo, sha)
+	runs, err := c.fetchCheckRuns(ctx, owner, repo, sha, false)
 	if err != nil {
 		return nil, err
 	}
 	slog.Debug("fetched check runs", "count", len(runs), "duration", time.Since(start))
 
-	return mapChecksToDomain(ctx, c, owner, repo, pr, sha, runs)
+	statuses, err := c.fetchCommitStatuses(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("fetch commit statuses: %w", err)
+	}
+	slog.Debug("fetched commit statuses", "count", len(statuses), "duration", time.Since(start))
+
+	result, err := mapChecksToDomain(ctx, c, owner, repo, pr, sha, runs)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergeCommitStatuses(result, statuses); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergeCommitStatuses appends commit status contexts to a ChecksResult and
+// recomputes the counters and overall status so both kinds gate equally.
+func mergeCommitStatuses(result *domain.ChecksResult, statuses []commitStatusNode) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	for _, st := range statuses {
+		check, err := mapCommitStatus(st)
+		if err != nil {
+			return err
+		}
+		result.Checks = append(result.Checks, check)
+	}
+
+	var all []domain.OverallStatus
+	result.PassCount, result.FailCount, result.PendingCount = 0, 0, 0
+	for _, ch := range result.Checks {
+		status := classifyCheckStatus(ch.Status, ch.Conclusion)
+		all = append(all, status)
+		switch status {
+		case domain.StatusPass:
+			result.PassCount++
+		case domain.StatusFail:
+			result.FailCount++
+		case domain.StatusPending:
+			result.PendingCount++
+		}
+	}
+	result.OverallStatus = domain.AggregateStatus(all)
+	return nil
+}
+
+// mapCommitStatus converts a commit status context to a domain CheckRun.
+// Pending statuses stay non-completed; every other state is treated as a
+// completed run whose conclusion is the raw state (success, failure, error).
+func mapCommitStatus(st commitStatusNode) (domain.CheckRun, error) {
+	check := domain.CheckRun{
+		ID:   st.ID,
+		Kind: domain.CheckKindStatus,
+		Name: st.Context,
+	}
+	if st.Description != nil {
+		check.Description = *st.Description
+	}
+	if st.TargetURL != nil {
+		check.HTMLURL = *st.TargetURL
+	}
+	if st.CreatedAt != "" {
+		t, err := time.Parse(time.RFC3339, st.CreatedAt)
+		if err != nil {
+			return domain.CheckRun{}, fmt.Errorf("parse created_at for status %q: %w", st.Context, err)
+		}
+		check.StartedAt = t
+	}
+
+	if st.State == "pending" {
+		check.Status = "pending"
+		return check, nil
+	}
+
+	check.Status = "completed"
+	check.Conclusion = st.State
+	if st.UpdatedAt != "" {
+		t, err := time.Parse(time.RFC3339, st.UpdatedAt)
+		if err != nil {
+			return domain.CheckRun{}, fmt.Errorf("parse updated_at for status %q: %w", st.Context, err)
+		}
+		check.CompletedAt = t
+	}
+	return check, nil
 }
 
 // mapChecksToDomain converts REST check run nodes to a domain ChecksResult,
@@ -118,6 +252,7 @@ func mapChecksToDomain(ctx context.Context, c *Client, owner, repo string, pr in
 	for _, run := range runs {
 		check := domain.CheckRun{
 			ID:      run.ID,
+			Kind:    domain.CheckKindCheckRun,
 			Name:    run.Name,
 			Status:  run.Status,
 			HTMLURL: run.HTMLURL,
@@ -195,7 +330,7 @@ func classifyCheckStatus(status, conclusion string) domain.OverallStatus {
 	switch conclusion {
 	case "success", "neutral", "skipped":
 		return domain.StatusPass
-	case "failure", "timed_out", "action_required", "startup_failure", "stale":
+	case "failure", "timed_out", "action_required", "startup_failure", "stale", "error":
 		return domain.StatusFail
 	case "cancelled":
 		return domain.StatusFail
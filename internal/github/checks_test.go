@@ -118,6 +118,8 @@ func TestClassifyCheckStatus(t *testing.T) {
 		{name: "completed action_required", status: "completed", conclusion: "action_required", want: domain.StatusFail},
 		{name: "completed startup_failure", status: "completed", conclusion: "startup_failure", want: domain.StatusFail},
 		{name: "completed stale", status: "completed", conclusion: "stale", want: domain.StatusFail},
+		{name: "status error", status: "completed", conclusion: "error", want: domain.StatusFail},
+		{name: "status pending", status: "pending", conclusion: "", want: domain.StatusPending},
 		{name: "in_progress", status: "in_progress", conclusion: "", want: domain.StatusPending},
 		{name: "queued", status: "queued", conclusion: "", want: domain.StatusPending},
 	}
@@ -154,3 +156,61 @@ func TestAggregateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeCommitStatuses(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/rest/combined_status.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var resp combinedStatusResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+
+	result := &domain.ChecksResult{
+		Checks: []domain.CheckRun{
+			{ID: 1, Kind: domain.CheckKindCheckRun, Name: "Lint", Status: "completed", Conclusion: "success"},
+		},
+		OverallStatus: domain.StatusPass,
+		PassCount:     1,
+	}
+	if err := mergeCommitStatuses(result, resp.Statuses); err != nil {
+		t.Fatalf("mergeCommitStatuses: %v", err)
+	}
+
+	type row struct {
+		Kind        domain.CheckKind
+		Name        string
+		Status      string
+		Conclusion  string
+		Description string
+		HTMLURL     string
+	}
+	var got []row
+	for _, ch := range result.Checks {
+		got = append(got, row{ch.Kind, ch.Name, ch.Status, ch.Conclusion, ch.Description, ch.HTMLURL})
+	}
+	want := []row{
+		{domain.CheckKindCheckRun, "Lint", "completed", "success", "", ""},
+		{domain.CheckKindStatus, "ci/jenkins", "completed", "failure", "Build #412 failed in 3m 12s", "https://jenkins.example.com/job/ghent/412/"},
+		{domain.CheckKindStatus, "codecov/project", "completed", "success", "84.21% (+0.12%) compared to 1a2b3c4", "https://app.codecov.io/gh/owner/repo/pull/1"},
+		{domain.CheckKindStatus, "deploy/preview", "pending", "", "", ""},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("checks mismatch (-want +got):\n%s", diff)
+	}
+
+	if result.PassCount != 2 || result.FailCount != 1 || result.PendingCount != 1 {
+		t.Errorf("counts = pass %d fail %d pending %d, want 2/1/1",
+			result.PassCount, result.FailCount, result.PendingCount)
+	}
+	if result.OverallStatus != domain.StatusFail {
+		t.Errorf("OverallStatus = %q, want %q", result.OverallStatus, domain.StatusFail)
+	}
+	if !result.Checks[2].CompletedAt.After(result.Checks[2].StartedAt) {
+		t.Errorf("codecov CompletedAt should be after StartedAt")
+	}
+	if !result.Checks[3].CompletedAt.IsZero() {
+		t.Errorf("pending status should have zero CompletedAt, got %v", result.Checks[3].CompletedAt)
+	}
+}
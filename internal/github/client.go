@@ -32,6 +32,29 @@ func doWithRetry(fn func() error) error {
 	return fn()
 }
 
+// retryUnlessLanded executes a non-idempotent request such as a POST. A
+// server error may come after the request took effect, so it is retried only
+// when landed, called after the backoff, reports that it did not. With a nil
+// landed, or when landed cannot tell, the error is returned unretried.
+func retryUnlessLanded(fn func() error, landed func() (bool, error)) error {
+	err := fn()
+	if err == nil || !isRetryable(err) || landed == nil {
+		return err
+	}
+	time.Sleep(retryBackoff)
+	ok, lerr := landed()
+	if lerr != nil {
+		slog.Debug("cannot tell whether request landed, not retrying", "error", err, "check_error", lerr)
+		return err
+	}
+	if ok {
+		slog.Debug("request landed despite server error", "error", err)
+		return nil
+	}
+	slog.Debug("retrying after server error", "error", err)
+	return fn()
+}
+
 // withTimeout returns a context with the default API timeout applied.
 func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, defaultTimeout)
@@ -41,6 +64,12 @@ func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 type Client struct {
 	gql  *api.GraphQLClient
 	rest *api.RESTClient
+
+	// cacheDir, when set, enables the on-disk REST response cache.
+	cacheDir string
+
+	// audit, when set, records every mutation (see audit.go).
+	audit *auditLog
 }
 
 // Option configures the Client.
@@ -60,6 +89,14 @@ func WithRESTClient(c *api.RESTClient) Option {
 	}
 }
 
+// WithCache enables the on-disk REST response cache in dir (see cache.go).
+// It only applies to the default REST client built by New.
+func WithCache(dir string) Option {
+	return func(client *Client) {
+		client.cacheDir = dir
+	}
+}
+
 // New creates a GitHub client with defaults from go-gh.
 // Use options to inject mock clients for testing.
 func New(opts ...Option) (*Client, error) {
@@ -87,6 +124,9 @@ func New(opts ...Option) (*Client, error) {
 			clientOpts.LogVerboseHTTP = true
 			clientOpts.LogColorize = true
 		}
+		if c.cacheDir != "" {
+			clientOpts.Transport = newCacheTransport(c.cacheDir, nil)
+		}
 		rest, err := api.NewRESTClient(clientOpts)
 		if err != nil {
 			return nil, classifyError(err)
@@ -98,13 +138,15 @@ func New(opts ...Option) (*Client, error) {
 
 // Compile-time interface satisfaction checks.
 var (
-	_ domain.ThreadFetcher   = (*Client)(nil)
-	_ domain.CheckFetcher    = (*Client)(nil)
-	_ domain.ThreadResolver  = (*Client)(nil)
-	_ domain.ThreadReplier   = (*Client)(nil)
-	_ domain.ReviewFetcher   = (*Client)(nil)
-	_ domain.ReviewDismisser = (*Client)(nil)
-	_ domain.ActivityProber  = (*Client)(nil)
+	_ domain.ThreadFetcher    = (*Client)(nil)
+	_ domain.CheckFetcher     = (*Client)(nil)
+	_ domain.ThreadResolver   = (*Client)(nil)
+	_ domain.ThreadReplier    = (*Client)(nil)
+	_ domain.CommentReplier   = (*Client)(nil)
+	_ domain.AllThreadFetcher = (*Client)(nil)
+	_ domain.ReviewFetcher    = (*Client)(nil)
+	_ domain.ReviewDismisser  = (*Client)(nil)
+	_ domain.ActivityProber   = (*Client)(nil)
 )
 
 // ResolveThread and UnresolveThread are implemented in resolve.go.
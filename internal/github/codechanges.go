@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// headBlobQuery fetches a file's text at a commit. The object is null when
+// the file does not exist there; text is null for binary files.
+const headBlobQuery = `
+query($owner: String!, $repo: String!, $expr: String!) {
+  repository(owner: $owner, name: $repo) {
+    object(expression: $expr) {
+      ... on Blob { text isBinary }
+    }
+  }
+}
+`
+
+// blameQuery fetches the blame of a file at a commit.
+const blameQuery = `
+query($owner: String!, $repo: String!, $oid: GitObjectID!, $path: String!) {
+  repository(owner: $owner, name: $repo) {
+    object(oid: $oid) {
+      ... on Commit {
+        blame(path: $path) {
+          ranges {
+            startingLine
+            endingLine
+            commit { oid committedDate messageHeadline }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+type headBlobResponse struct {
+	Repository *struct {
+		Object *struct {
+			Text     *string `json:"text"`
+			IsBinary bool    `json:"isBinary"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+type blameResponse struct {
+	Repository *struct {
+		Object *struct {
+			Blame struct {
+				Ranges []blameRange `json:"ranges"`
+			} `json:"blame"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+type blameRange struct {
+	StartingLine int `json:"startingLine"`
+	EndingLine   int `json:"endingLine"`
+	Commit       struct {
+		OID             string    `json:"oid"`
+		CommittedDate   time.Time `json:"committedDate"`
+		MessageHeadline string    `json:"messageHeadline"`
+	} `json:"commit"`
+}
+
+// headFile is a file as it reads at the PR head.
+type headFile struct {
+	missing bool // deleted or renamed since the comment
+	lines   []string
+	blame   []blameRange
+}
+
+// MarkCodeChanges compares the lines each unresolved thread comments on,
+// taken from the end of its diff hunk, with the file at the PR head. A
+// thread on a current line compares at that line; an outdated thread counts
+// as unchanged if its lines still occur exactly once in the file. Changed
+// threads list the commits after the first comment that the head's blame
+// shows on those lines (none for outdated threads, whose lines are gone).
+// An outdated thread whose lines occur more than once is left unknown.
+// Threads on deleted lines, and files that cannot be fetched, are skipped.
+func (c *Client) MarkCodeChanges(ctx context.Context, owner, repo string, pr int, result *domain.CommentsResult) error {
+	info, err := c.FetchPullRequest(ctx, owner, repo, pr)
+	if err != nil {
+		return fmt.Errorf("fetch pull request: %w", err)
+	}
+
+	start := time.Now()
+	files := make(map[string]*headFile)
+	for i := range result.Threads {
+		t := &result.Threads[i]
+		if t.IsResolved || len(t.Comments) == 0 || t.DiffSide == "LEFT" {
+			continue
+		}
+		f, seen := files[t.Path]
+		if !seen {
+			if f, err = c.fetchHeadFile(ctx, owner, repo, info.HeadSHA, t.Path); err != nil {
+				slog.Debug("fetch head file failed", "path", t.Path, "error", err)
+			}
+			files[t.Path] = f
+		}
+		if f != nil {
+			markCodeChange(t, f)
+		}
+	}
+	slog.Debug("marked code changes", "pr", pr, "files", len(files), "duration", time.Since(start))
+	return nil
+}
+
+// fetchHeadFile returns the file at sha with its blame, or nil for binary
+// files.
+func (c *Client) fetchHeadFile(ctx context.Context, owner, repo, sha, path string) (*headFile, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	vars := map[string]interface{}{"owner": owner, "repo": repo, "expr": sha + ":" + path}
+	var blob headBlobResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, headBlobQuery, vars, &blob)
+	}); err != nil {
+		return nil, classifyWithContext(err, "file", path)
+	}
+	if blob.Repository == nil || blob.Repository.Object == nil {
+		return &headFile{missing: true}, nil
+	}
+	if blob.Repository.Object.IsBinary || blob.Repository.Object.Text == nil {
+		return nil, nil
+	}
+	f := &headFile{lines: sourceLines([]byte(*blob.Repository.Object.Text))}
+
+	vars = map[string]interface{}{"owner": owner, "repo": repo, "oid": sha, "path": path}
+	var blame blameResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, blameQuery, vars, &blame)
+	}); err != nil {
+		return nil, classifyWithContext(err, "blame", path)
+	}
+	if blame.Repository != nil && blame.Repository.Object != nil {
+		f.blame = blame.Repository.Object.Blame.Ranges
+	}
+	return f, nil
+}
+
+// markCodeChange sets CodeChangedSinceComment and ChangedBy on one thread.
+// Threads whose hunk does not cover the commented lines, and outdated
+// threads whose lines occur more than once, are left unset.
+func markCodeChange(t *domain.ReviewThread, f *headFile) {
+	first := t.Comments[0]
+	span := threadSpan(*t)
+	original := hunkTail(first.DiffHunk, span)
+	if original == nil {
+		return
+	}
+
+	changed := true
+	from := t.Line - span + 1
+	switch {
+	case f.missing:
+	case t.Line > 0:
+		changed = !linesAt(f.lines, from, original)
+	default:
+		// The original line number means nothing in the head file. Lines
+		// that still occur more than once may or may not be the commented
+		// ones, so whether they changed is unknown.
+		_, count := findLines(f.lines, original)
+		if count > 1 {
+			return
+		}
+		changed = count == 0
+	}
+	t.CodeChangedSinceComment = &changed
+	// Gone lines (outdated threads, deleted files) have no blame to show.
+	if !changed || t.Line == 0 || f.missing || from < 1 {
+		return
+	}
+	t.ChangedBy = commitsSince(f.blame, from, t.Line, first.CreatedAt)
+}
+
+// commitsSince returns the commits after since that the blame shows on lines
+// from..to, oldest first.
+func commitsSince(blame []blameRange, from, to int, since time.Time) []domain.CommitRef {
+	var commits []domain.CommitRef
+	for _, r := range blame {
+		if r.EndingLine < from || r.StartingLine > to || !r.Commit.CommittedDate.After(since) {
+			continue
+		}
+		if slices.ContainsFunc(commits, func(c domain.CommitRef) bool { return c.SHA == r.Commit.OID }) {
+			continue
+		}
+		commits = append(commits, domain.CommitRef{
+			SHA:         r.Commit.OID,
+			Message:     r.Commit.MessageHeadline,
+			CommittedAt: r.Commit.CommittedDate,
+		})
+	}
+	slices.SortFunc(commits, func(a, b domain.CommitRef) int { return a.CommittedAt.Compare(b.CommittedAt) })
+	return commits
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func blameOf(from, to int, sha string, at time.Time) blameRange {
+	r := blameRange{StartingLine: from, EndingLine: to}
+	r.Commit.OID = sha
+	r.Commit.CommittedDate = at
+	r.Commit.MessageHeadline = "commit " + sha
+	return r
+}
+
+func TestMarkCodeChange(t *testing.T) {
+	commented := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after := commented.Add(-time.Hour), commented.Add(time.Hour)
+	hunk := "@@ -1,3 +1,4 @@\n package main\n \n+func run() {\n+\tlog.Print(cfg)"
+	thread := func(line, originalLine int) domain.ReviewThread {
+		return domain.ReviewThread{
+			Path: "main.go", Line: line, OriginalLine: originalLine, IsOutdated: line == 0,
+			Comments: []domain.Comment{{CreatedAt: commented, DiffHunk: hunk}},
+		}
+	}
+	changed, unchanged := true, false
+	blame := []blameRange{blameOf(1, 3, "old", before), blameOf(4, 4, "fix1", after), blameOf(5, 6, "fix2", after.Add(time.Hour))}
+
+	tests := []struct {
+		name        string
+		thread      domain.ReviewThread
+		file        *headFile
+		wantChanged *bool
+		wantCommits []string
+	}{
+		{
+			name:        "line unchanged",
+			thread:      thread(4, 4),
+			file:        &headFile{lines: []string{"package main", "", "func run() {", "\tlog.Print(cfg)"}, blame: blame},
+			wantChanged: &unchanged,
+		},
+		{
+			name:        "line changed",
+			thread:      thread(4, 4),
+			file:        &headFile{lines: []string{"package main", "", "func run() {", "\tslog.Info(\"cfg\")"}, blame: blame},
+			wantChanged: &changed,
+			wantCommits: []string{"fix1"},
+		},
+		{
+			name:        "outdated but only moved",
+			thread:      thread(0, 4),
+			file:        &headFile{lines: []string{"package main", "", "import \"log\"", "", "func run() {", "\tlog.Print(cfg)"}, blame: blame},
+			wantChanged: &unchanged,
+		},
+		{
+			name:        "outdated and gone",
+			thread:      thread(0, 4),
+			file:        &headFile{lines: []string{"package main", "", "func run() {", "}"}, blame: blame},
+			wantChanged: &changed,
+		},
+		{
+			name:   "outdated and duplicated is unknown",
+			thread: thread(0, 4),
+			file: &headFile{lines: []string{
+				"package main", "", "func run() {", "}", "func runAgain() {", "\tlog.Print(cfg)", "}", "\tlog.Print(cfg)",
+			}, blame: blame},
+		},
+		{
+			name:        "file deleted",
+			thread:      thread(0, 4),
+			file:        &headFile{missing: true},
+			wantChanged: &changed,
+		},
+		{
+			name: "hunk does not cover the line",
+			thread: domain.ReviewThread{
+				Path: "main.go", Line: 4, StartLine: 1,
+				Comments: []domain.Comment{{DiffHunk: "@@ -1 +1 @@\n+x"}},
+			},
+			file: &headFile{lines: []string{"x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := tt.thread
+			markCodeChange(&th, tt.file)
+			if diff := cmp.Diff(tt.wantChanged, th.CodeChangedSinceComment); diff != "" {
+				t.Errorf("CodeChangedSinceComment mismatch (-want +got):\n%s", diff)
+			}
+			var shas []string
+			for _, c := range th.ChangedBy {
+				shas = append(shas, c.SHA)
+			}
+			if diff := cmp.Diff(tt.wantCommits, shas); diff != "" {
+				t.Errorf("ChangedBy mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitsSince(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	blame := []blameRange{
+		blameOf(1, 2, "b", since.Add(2*time.Hour)),
+		blameOf(3, 3, "old", since.Add(-time.Hour)),
+		blameOf(4, 5, "a", since.Add(time.Hour)),
+		blameOf(6, 6, "b", since.Add(2*time.Hour)),
+		blameOf(7, 9, "outside", since.Add(time.Hour)),
+	}
+
+	got := commitsSince(blame, 2, 6, since)
+	want := []domain.CommitRef{
+		{SHA: "a", Message: "commit a", CommittedAt: since.Add(time.Hour)},
+		{SHA: "b", Message: "commit b", CommittedAt: since.Add(2 * time.Hour)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("commitsSince() mismatch (-want +got):\n%s", diff)
+	}
+}
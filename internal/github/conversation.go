@@ -0,0 +1,234 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// conversationQuery fetches top-level PR comments and review bodies. The two
+// connections paginate independently; @include drops whichever is exhausted.
+const conversationQuery = `
+query($owner: String!, $repo: String!, $pr: Int!, $commentsAfter: String, $reviewsAfter: String, $withComments: Boolean!, $withReviews: Boolean!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $pr) {
+      comments(first: 100, after: $commentsAfter) @include(if: $withComments) {
+        nodes {
+          id
+          databaseId
+          body
+          author { __typename login }
+          createdAt
+          updatedAt
+          url
+          isMinimized
+          minimizedReason
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+      reviews(first: 100, after: $reviewsAfter) @include(if: $withReviews) {
+        nodes {
+          id
+          databaseId
+          body
+          state
+          author { __typename login }
+          createdAt
+          updatedAt
+          submittedAt
+          url
+          isMinimized
+          minimizedReason
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}
+`
+
+type conversationResponse struct {
+	Repository struct {
+		PullRequest *struct {
+			Comments *struct {
+				Nodes    []conversationNode `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"comments"`
+			Reviews *struct {
+				Nodes    []conversationNode `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"reviews"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// conversationNode covers both IssueComment and PullRequestReview nodes;
+// State and SubmittedAt are only present on reviews. Both kinds can be
+// minimized (hidden) on GitHub.
+type conversationNode struct {
+	ID         string `json:"id"`
+	DatabaseID int64  `json:"databaseId"`
+	Body       string `json:"body"`
+	State      string `json:"state"`
+	Author     struct {
+		TypeName string `json:"__typename"`
+		Login    string `json:"login"`
+	} `json:"author"`
+	CreatedAt       string `json:"createdAt"`
+	UpdatedAt       string `json:"updatedAt"`
+	SubmittedAt     string `json:"submittedAt"`
+	URL             string `json:"url"`
+	IsMinimized     bool   `json:"isMinimized"`
+	MinimizedReason string `json:"minimizedReason"`
+}
+
+// FetchConversation retrieves the PR's top-level conversation comments and
+// non-empty review bodies, oldest first, with HasReply computed.
+func (c *Client) FetchConversation(ctx context.Context, owner, repo string, pr int) ([]domain.ConversationComment, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("fetching conversation", "owner", owner, "repo", repo, "pr", pr)
+
+	var commentNodes, reviewNodes []conversationNode
+	var commentsAfter, reviewsAfter *string
+	withComments, withReviews := true, true
+
+	for withComments || withReviews {
+		vars := map[string]interface{}{
+			"owner":        owner,
+			"repo":         repo,
+			"pr":           pr,
+			"withComments": withComments,
+			"withReviews":  withReviews,
+		}
+		if commentsAfter != nil {
+			vars["commentsAfter"] = *commentsAfter
+		}
+		if reviewsAfter != nil {
+			vars["reviewsAfter"] = *reviewsAfter
+		}
+
+		var resp conversationResponse
+		if err := doWithRetry(func() error {
+			return c.gql.DoWithContext(ctx, conversationQuery, vars, &resp)
+		}); err != nil {
+			return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
+		}
+
+		p := resp.Repository.PullRequest
+		if p == nil {
+			return nil, &NotFoundError{
+				Resource: "pull request",
+				Detail:   fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo),
+			}
+		}
+
+		withComments, withReviews = false, false
+		if p.Comments != nil {
+			commentNodes = append(commentNodes, p.Comments.Nodes...)
+			if p.Comments.PageInfo.HasNextPage {
+				withComments = true
+				commentsAfter = &p.Comments.PageInfo.EndCursor
+			}
+		}
+		if p.Reviews != nil {
+			reviewNodes = append(reviewNodes, p.Reviews.Nodes...)
+			if p.Reviews.PageInfo.HasNextPage {
+				withReviews = true
+				reviewsAfter = &p.Reviews.PageInfo.EndCursor
+			}
+		}
+	}
+
+	entries, err := mapConversation(commentNodes, reviewNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("fetched conversation", "owner", owner, "repo", repo, "pr", pr,
+		"comments", len(commentNodes), "reviews", len(reviewNodes), "entries", len(entries),
+		"duration", time.Since(start))
+
+	return entries, nil
+}
+
+// mapConversation merges comment and review nodes into a single timeline.
+// Reviews without a body (plain approvals, inline-only reviews) and pending
+// reviews are dropped: their feedback, if any, lives in review threads.
+func mapConversation(comments, reviews []conversationNode) ([]domain.ConversationComment, error) {
+	entries := make([]domain.ConversationComment, 0, len(comments)+len(reviews))
+	for _, n := range comments {
+		e, err := mapConversationNode(n, domain.ConversationKindComment, n.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	for _, n := range reviews {
+		if strings.TrimSpace(n.Body) == "" || n.State == "PENDING" {
+			continue
+		}
+		created := n.SubmittedAt
+		if created == "" {
+			created = n.CreatedAt
+		}
+		e, err := mapConversationNode(n, domain.ConversationKindReview, created)
+		if err != nil {
+			return nil, err
+		}
+		e.ReviewState = domain.ReviewState(n.State)
+		entries = append(entries, e)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	domain.MarkConversationReplies(entries)
+	return entries, nil
+}
+
+func mapConversationNode(n conversationNode, kind, createdAt string) (domain.ConversationComment, error) {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return domain.ConversationComment{}, fmt.Errorf("parse %s time %q: %w", kind, createdAt, err)
+	}
+	e := domain.ConversationComment{
+		ID:              n.ID,
+		DatabaseID:      n.DatabaseID,
+		Kind:            kind,
+		Author:          n.Author.Login,
+		IsBot:           domain.IsBot(n.Author.TypeName, n.Author.Login),
+		Body:            n.Body,
+		CreatedAt:       created,
+		URL:             n.URL,
+		IsMinimized:     n.IsMinimized,
+		MinimizedReason: n.MinimizedReason,
+	}
+	if n.UpdatedAt != "" {
+		updated, err := time.Parse(time.RFC3339, n.UpdatedAt)
+		if err != nil {
+			return domain.ConversationComment{}, fmt.Errorf("parse %s time %q: %w", kind, n.UpdatedAt, err)
+		}
+		e.UpdatedAt = updated
+	}
+	return e, nil
+}
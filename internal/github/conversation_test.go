@@ -0,0 +1,100 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestMapConversation(t *testing.T) {
+	bot := func(n conversationNode) conversationNode {
+		n.Author.TypeName, n.Author.Login = "Bot", "coderabbitai"
+		return n
+	}
+	human := func(n conversationNode, login string) conversationNode {
+		n.Author.TypeName, n.Author.Login = "User", login
+		return n
+	}
+
+	comments := []conversationNode{
+		bot(conversationNode{
+			ID: "IC_1", DatabaseID: 11, Body: "## Walkthrough", URL: "https://github.com/o/r/pull/1#issuecomment-11",
+			CreatedAt: "2026-02-20T10:00:00Z", UpdatedAt: "2026-02-21T09:00:00Z",
+		}),
+		human(conversationNode{
+			ID: "IC_2", DatabaseID: 12, Body: "old note", CreatedAt: "2026-02-20T12:00:00Z",
+			UpdatedAt: "2026-02-20T12:00:00Z", IsMinimized: true, MinimizedReason: "OUTDATED",
+		}, "alice"),
+	}
+	reviews := []conversationNode{
+		human(conversationNode{ID: "PRR_1", State: "APPROVED", CreatedAt: "2026-02-20T11:00:00Z"}, "bob"),
+		human(conversationNode{
+			ID: "PRR_2", Body: "Please split this PR.", State: "CHANGES_REQUESTED",
+			CreatedAt: "2026-02-20T10:30:00Z", SubmittedAt: "2026-02-20T11:30:00Z",
+		}, "bob"),
+		human(conversationNode{ID: "PRR_3", Body: "draft", State: "PENDING", CreatedAt: "2026-02-20T13:00:00Z"}, "carol"),
+		human(conversationNode{
+			ID: "PRR_4", Body: "buy now", State: "COMMENTED", SubmittedAt: "2026-02-20T14:00:00Z",
+			IsMinimized: true, MinimizedReason: "SPAM",
+		}, "mallory"),
+	}
+
+	got, err := mapConversation(comments, reviews)
+	if err != nil {
+		t.Fatalf("mapConversation: %v", err)
+	}
+
+	var ids []string
+	for _, e := range got {
+		ids = append(ids, e.ID)
+	}
+	// Empty and pending reviews are dropped; reviews sort by submittedAt.
+	if diff := cmp.Diff([]string{"IC_1", "PRR_2", "IC_2", "PRR_4"}, ids); diff != "" {
+		t.Fatalf("entry order mismatch (-want +got):\n%s", diff)
+	}
+
+	first := got[0]
+	if first.Kind != domain.ConversationKindComment || !first.IsBot || !first.HasReply {
+		t.Errorf("IC_1 = %+v, want bot comment with reply", first)
+	}
+	if first.LastActivity() != mustParseTime(t, "2026-02-21T09:00:00Z") {
+		t.Errorf("IC_1 LastActivity = %v, want edit time", first.LastActivity())
+	}
+	review := got[1]
+	if review.Kind != domain.ConversationKindReview || review.ReviewState != domain.ReviewChangesRequested {
+		t.Errorf("PRR_2 = %+v, want CHANGES_REQUESTED review", review)
+	}
+	if review.CreatedAt != mustParseTime(t, "2026-02-20T11:30:00Z") {
+		t.Errorf("PRR_2 CreatedAt = %v, want submittedAt", review.CreatedAt)
+	}
+	if hidden := got[2]; !hidden.IsMinimized || hidden.MinimizedReason != "OUTDATED" || hidden.IsUnanswered() {
+		t.Errorf("IC_2 = %+v, want minimized and not unanswered", hidden)
+	}
+	if hidden := got[3]; !hidden.IsMinimized || hidden.MinimizedReason != "SPAM" || hidden.IsUnanswered() {
+		t.Errorf("PRR_4 = %+v, want minimized review and not unanswered", hidden)
+	}
+}
+
+func TestConversationQuerySelectsMinimized(t *testing.T) {
+	// Both connections must select the minimized fields, or hidden reviews
+	// come back as unanswered.
+	_, reviews, ok := strings.Cut(conversationQuery, "reviews(")
+	if !ok {
+		t.Fatal("conversationQuery has no reviews connection")
+	}
+	for _, field := range []string{"isMinimized", "minimizedReason"} {
+		if !strings.Contains(reviews, field) {
+			t.Errorf("reviews selection lacks %s", field)
+		}
+	}
+}
+
+func TestMapConversationBadTime(t *testing.T) {
+	_, err := mapConversation([]conversationNode{{ID: "IC_1", CreatedAt: "yesterday"}}, nil)
+	if err == nil {
+		t.Fatal("expected error for unparseable createdAt")
+	}
+}
@@ -21,6 +21,24 @@ func (c *Client) DismissReview(
 	pr int,
 	review domain.Review,
 	message string,
+) (*domain.DismissResult, error) {
+	result, err := c.dismissReview(ctx, owner, repo, pr, review, message)
+	c.record(domain.AuditEntry{
+		Repo:     owner + "/" + repo,
+		PR:       pr,
+		Target:   review.ID,
+		Action:   domain.AuditDismiss,
+		BodyHash: auditHash(message),
+	}, err)
+	return result, err
+}
+
+func (c *Client) dismissReview(
+	ctx context.Context,
+	owner, repo string,
+	pr int,
+	review domain.Review,
+	message string,
 ) (*domain.DismissResult, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
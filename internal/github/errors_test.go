@@ -273,6 +273,60 @@ func TestDoWithRetry(t *testing.T) {
 	})
 }
 
+func TestRetryUnlessLanded(t *testing.T) {
+	orig := retryBackoff
+	retryBackoff = time.Millisecond
+	defer func() { retryBackoff = orig }()
+
+	serverErr := &api.HTTPError{StatusCode: 502, Message: "Bad Gateway", RequestURL: testReqURL()}
+	tests := []struct {
+		name      string
+		landed    func() (bool, error)
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "no check is not retried", wantCalls: 1, wantErr: true},
+		{name: "landed is not retried", landed: func() (bool, error) { return true, nil }, wantCalls: 1},
+		{name: "not landed is retried", landed: func() (bool, error) { return false, nil }, wantCalls: 2},
+		{name: "failed check is not retried", landed: func() (bool, error) { return false, fmt.Errorf("boom") }, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retryUnlessLanded(func() error {
+				calls++
+				if calls == 1 {
+					return serverErr
+				}
+				return nil
+			}, tt.landed)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+
+	t.Run("4xx skips the check", func(t *testing.T) {
+		checked := false
+		err := retryUnlessLanded(func() error {
+			return &api.HTTPError{StatusCode: 422, Message: "Unprocessable", RequestURL: testReqURL()}
+		}, func() (bool, error) {
+			checked = true
+			return false, nil
+		})
+		if err == nil {
+			t.Error("expected error")
+		}
+		if checked {
+			t.Error("landed called for a 4xx")
+		}
+	})
+}
+
 func TestClassifyError_Nil(t *testing.T) {
 	if got := classifyError(nil); got != nil {
 		t.Errorf("classifyError(nil) = %v, want nil", got)
@@ -0,0 +1,199 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// DefaultFlakyCommits is how many of a PR's most recent commits flakiness
+// analysis looks back over.
+const DefaultFlakyCommits = 10
+
+// flipFlopMinFlips is how many pass↔fail changes across commits mark a check
+// as flaky without same-code evidence. Fewer flips are the usual break-and-fix
+// cycle of a PR.
+const flipFlopMinFlips = 3
+
+// maxPRCommits is the most commits the pull request commits endpoint lists.
+const maxPRCommits = 250
+
+type prCommitNode struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Tree struct {
+			SHA string `json:"sha"`
+		} `json:"tree"`
+	} `json:"commit"`
+}
+
+// fetchPRCommits lists a PR's commits, oldest first.
+func (c *Client) fetchPRCommits(ctx context.Context, owner, repo string, pr int) ([]prCommitNode, error) {
+	var all []prCommitNode
+	for page := 1; len(all) < maxPRCommits; page++ {
+		path := fmt.Sprintf("repos/%s/%s/pulls/%d/commits?per_page=100&page=%d", owner, repo, pr, page)
+		var resp []prCommitNode
+		if err := doWithRetry(func() error {
+			return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
+		}); err != nil {
+			return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
+		}
+		all = append(all, resp...)
+		if len(resp) < 100 {
+			break
+		}
+	}
+	return all, nil
+}
+
+// FetchCheckHistory fetches every check run attempt on the last commits of a
+// PR, oldest commit first and attempts in completion order. Commit statuses
+// are not included.
+func (c *Client) FetchCheckHistory(ctx context.Context, owner, repo string, pr, commits int) ([]domain.CommitCheckRuns, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	nodes, err := c.fetchPRCommits(ctx, owner, repo, pr)
+	if err != nil {
+		return nil, err
+	}
+	if commits > 0 && len(nodes) > commits {
+		nodes = nodes[len(nodes)-commits:]
+	}
+
+	history := make([]domain.CommitCheckRuns, 0, len(nodes))
+	for _, n := range nodes {
+		runs, err := c.fetchCheckRuns(ctx, owner, repo, n.SHA, true)
+		if err != nil {
+			return nil, fmt.Errorf("check runs of %.7s: %w", n.SHA, err)
+		}
+		cc := domain.CommitCheckRuns{SHA: n.SHA, TreeSHA: n.Commit.Tree.SHA}
+		for _, run := range runs {
+			cc.Checks = append(cc.Checks, mapCheckRunSummary(run)) // unparsable times sort first
+		}
+		slices.SortStableFunc(cc.Checks, func(a, b domain.CheckRun) int { return a.CompletedAt.Compare(b.CompletedAt) })
+		history = append(history, cc)
+	}
+	slog.Debug("fetched check history", "commits", len(history), "duration", time.Since(start))
+	return history, nil
+}
+
+// mapCheckRunSummary converts a check run node to a domain CheckRun without
+// annotations. Timestamps that do not parse are left zero; the summary is
+// only compared, never shown as the PR's own result.
+func mapCheckRunSummary(run checkRunNode) domain.CheckRun {
+	check := domain.CheckRun{
+		ID:      run.ID,
+		Kind:    domain.CheckKindCheckRun,
+		Name:    run.Name,
+		Status:  run.Status,
+		HTMLURL: run.HTMLURL,
+	}
+	if run.Conclusion != nil {
+		check.Conclusion = *run.Conclusion
+	}
+	check.StartedAt, _ = time.Parse(time.RFC3339, run.StartedAt)
+	if run.CompletedAt != nil {
+		check.CompletedAt, _ = time.Parse(time.RFC3339, *run.CompletedAt)
+	}
+	return check
+}
+
+// MarkFlakyChecks sets Flaky on every check run of result whose history
+// shows it both passing and failing on identical code (same_code), or
+// flipping between pass and fail at least flipFlopMinFlips times across
+// commits (flip_flop). Only success and failure-class conclusions count;
+// cancelled runs are usually superseded pushes and are ignored. It returns
+// the number of checks marked.
+func MarkFlakyChecks(result *domain.ChecksResult, history []domain.CommitCheckRuns) int {
+	marked := 0
+	for i := range result.Checks {
+		ch := &result.Checks[i]
+		if ch.IsCommitStatus() {
+			continue
+		}
+		if ch.Flaky = flakyEvidence(ch.Name, history); ch.Flaky != nil {
+			marked++
+		}
+	}
+	return marked
+}
+
+// flakyEvidence returns the evidence that the check named name is flaky, or
+// nil when its history does not show it.
+func flakyEvidence(name string, history []domain.CommitCheckRuns) *domain.FlakyCheck {
+	ev := &domain.FlakyCheck{}
+	type codeOutcomes struct{ pass, fail bool }
+	byCode := make(map[string]*codeOutcomes)
+	sameCode := false
+	last := ""
+	for _, cc := range history {
+		code := cc.TreeSHA
+		if code == "" {
+			code = cc.SHA
+		}
+		for _, run := range cc.Checks {
+			if run.Name != name || run.Status != "completed" {
+				continue
+			}
+			outcome := passFailOutcome(run.Conclusion)
+			if outcome == "" {
+				continue
+			}
+			ev.History = append(ev.History, domain.CheckOutcome{
+				SHA:         cc.SHA,
+				CheckRunID:  run.ID,
+				Conclusion:  run.Conclusion,
+				CompletedAt: run.CompletedAt,
+			})
+			if last != "" && outcome != last {
+				ev.Flips++
+			}
+			last = outcome
+
+			seen := byCode[code]
+			if seen == nil {
+				seen = &codeOutcomes{}
+				byCode[code] = seen
+			}
+			if outcome == "pass" {
+				ev.Passes++
+				seen.pass = true
+			} else {
+				ev.Fails++
+				seen.fail = true
+			}
+			sameCode = sameCode || (seen.pass && seen.fail)
+		}
+	}
+
+	switch {
+	case sameCode:
+		ev.Reason = domain.FlakyReasonSameCode
+	case ev.Flips >= flipFlopMinFlips:
+		ev.Reason = domain.FlakyReasonFlipFlop
+	default:
+		return nil
+	}
+	return ev
+}
+
+// passFailOutcome classifies a conclusion as "pass", "fail", or "" when it is
+// no evidence either way.
+func passFailOutcome(conclusion string) string {
+	switch {
+	case conclusion == "success":
+		return "pass"
+	case conclusion == "cancelled":
+		return ""
+	case domain.IsFailConclusion(conclusion):
+		return "fail"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// commitRuns builds one commit's history with a run of "test" per conclusion.
+func commitRuns(sha, tree string, conclusions ...string) domain.CommitCheckRuns {
+	cc := domain.CommitCheckRuns{SHA: sha, TreeSHA: tree}
+	for i, c := range conclusions {
+		cc.Checks = append(cc.Checks, domain.CheckRun{ID: int64(len(sha)*10 + i), Name: "test", Status: "completed", Conclusion: c})
+	}
+	return cc
+}
+
+func TestMarkFlakyChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		history    []domain.CommitCheckRuns
+		wantReason string // "" = not flaky
+		wantFlips  int
+	}{
+		{
+			name:       "re-run attempt passed on the same commit",
+			history:    []domain.CommitCheckRuns{commitRuns("a", "t1", "failure", "success")},
+			wantReason: domain.FlakyReasonSameCode,
+			wantFlips:  1,
+		},
+		{
+			name: "empty commit with the same tree",
+			history: []domain.CommitCheckRuns{
+				commitRuns("a", "t1", "failure"),
+				commitRuns("bb", "t1", "success"),
+			},
+			wantReason: domain.FlakyReasonSameCode,
+			wantFlips:  1,
+		},
+		{
+			name: "break and fix across commits",
+			history: []domain.CommitCheckRuns{
+				commitRuns("a", "t1", "success"),
+				commitRuns("bb", "t2", "failure"),
+				commitRuns("ccc", "t3", "success"),
+			},
+		},
+		{
+			name: "keeps flipping across commits",
+			history: []domain.CommitCheckRuns{
+				commitRuns("a", "t1", "success"),
+				commitRuns("bb", "t2", "failure"),
+				commitRuns("ccc", "t3", "success"),
+				commitRuns("dddd", "t4", "timed_out"),
+			},
+			wantReason: domain.FlakyReasonFlipFlop,
+			wantFlips:  3,
+		},
+		{
+			name:    "cancelled runs are no evidence",
+			history: []domain.CommitCheckRuns{commitRuns("a", "t1", "cancelled", "success")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &domain.ChecksResult{Checks: []domain.CheckRun{
+				{Name: "test", Status: "completed", Conclusion: "failure"},
+				{Name: "test", Kind: domain.CheckKindStatus, Status: "completed", Conclusion: "failure"},
+			}}
+			marked := MarkFlakyChecks(result, tt.history)
+
+			if result.Checks[1].Flaky != nil {
+				t.Error("commit status marked flaky")
+			}
+			fl := result.Checks[0].Flaky
+			if tt.wantReason == "" {
+				if fl != nil || marked != 0 {
+					t.Errorf("marked %d, flaky = %+v; want none", marked, fl)
+				}
+				return
+			}
+			if fl == nil || marked != 1 {
+				t.Fatalf("marked %d, flaky = nil; want %s", marked, tt.wantReason)
+			}
+			if fl.Reason != tt.wantReason || fl.Flips != tt.wantFlips {
+				t.Errorf("reason, flips = %s, %d; want %s, %d", fl.Reason, fl.Flips, tt.wantReason, tt.wantFlips)
+			}
+		})
+	}
+}
+
+func TestMarkFlakyChecksEvidence(t *testing.T) {
+	history := []domain.CommitCheckRuns{
+		{SHA: "a", TreeSHA: "t1", Checks: []domain.CheckRun{
+			{ID: 1, Name: "test", Status: "completed", Conclusion: "failure"},
+			{ID: 2, Name: "lint", Status: "completed", Conclusion: "success"},
+			{ID: 3, Name: "test", Status: "in_progress"},
+			{ID: 4, Name: "test", Status: "completed", Conclusion: "success"},
+		}},
+	}
+	result := &domain.ChecksResult{Checks: []domain.CheckRun{{Name: "test"}, {Name: "lint"}}}
+	MarkFlakyChecks(result, history)
+
+	want := &domain.FlakyCheck{
+		Reason: domain.FlakyReasonSameCode,
+		Flips:  1,
+		Passes: 1,
+		Fails:  1,
+		History: []domain.CheckOutcome{
+			{SHA: "a", CheckRunID: 1, Conclusion: "failure"},
+			{SHA: "a", CheckRunID: 4, Conclusion: "success"},
+		},
+	}
+	if diff := cmp.Diff(want, result.Checks[0].Flaky); diff != "" {
+		t.Errorf("evidence mismatch (-want +got):\n%s", diff)
+	}
+	if result.Checks[1].Flaky != nil {
+		t.Errorf("lint marked flaky: %+v", result.Checks[1].Flaky)
+	}
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// errNoStepTimestamps is returned when a step is requested from a log whose
+// lines carry no timestamps to split it by.
+var errNoStepTimestamps = errors.New("job log has no timestamps to split by step")
+
+// LogFilter selects lines from a cleaned job log. The zero value keeps every
+// line.
+type LogFilter struct {
+	Grep         *regexp.Regexp // keep lines matching the pattern
+	AroundErrors bool           // keep error-relevant lines (see isErrorLine)
+	Context      int            // lines kept on each side of a Grep or AroundErrors match
+	Tail         int            // keep only the last Tail lines of the result
+}
+
+// JobLogLines cleans a raw job log into numbered lines, stripping ANSI codes
+// and timestamps. With step > 0 only that step's lines are kept, assigned by
+// timestamp as in ExtractStepErrorLines; numbers stay those of the full log.
+func JobLogLines(log string, steps []domain.JobStep, step int) ([]domain.LogLine, error) {
+	raw := strings.Split(strings.TrimRight(log, "\n"), "\n")
+	if log == "" {
+		raw = nil
+	}
+
+	var numbers []int
+	if step > 0 {
+		var ok bool
+		if numbers, ok = lineSteps(raw, steps); !ok {
+			return nil, errNoStepTimestamps
+		}
+	}
+
+	var lines []domain.LogLine
+	for i, line := range raw {
+		if numbers != nil && numbers[i] != step {
+			continue
+		}
+		lines = append(lines, domain.LogLine{Number: i + 1, Text: strings.TrimRight(cleanLine(line), "\r")})
+	}
+	return lines, nil
+}
+
+// FilterLogLines applies f to lines. Grep and AroundErrors matches are
+// combined, each widened by Context lines; Tail is applied last.
+func FilterLogLines(lines []domain.LogLine, f LogFilter) []domain.LogLine {
+	if f.Grep != nil || f.AroundErrors {
+		keep := make([]bool, len(lines))
+		for i, l := range lines {
+			if (f.Grep != nil && f.Grep.MatchString(l.Text)) || (f.AroundErrors && isErrorLine(l.Text)) {
+				for j := max(0, i-f.Context); j <= min(len(lines)-1, i+f.Context); j++ {
+					keep[j] = true
+				}
+			}
+		}
+		var kept []domain.LogLine
+		for i, l := range lines {
+			if keep[i] {
+				kept = append(kept, l)
+			}
+		}
+		lines = kept
+	}
+	if f.Tail > 0 && len(lines) > f.Tail {
+		lines = lines[len(lines)-f.Tail:]
+	}
+	return lines
+}
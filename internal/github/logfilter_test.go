@@ -0,0 +1,53 @@
+package github
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestFilterLogLines(t *testing.T) {
+	lines, err := JobLogLines("a\nb\nerror: x\nc\nd\ne\nmatch\nf\n", nil, 0)
+	if err != nil {
+		t.Fatalf("JobLogLines: %v", err)
+	}
+	if len(lines) != 8 {
+		t.Fatalf("JobLogLines kept %d lines, want 8 (trailing newline dropped)", len(lines))
+	}
+
+	numbers := func(ls []domain.LogLine) []int {
+		var out []int
+		for _, l := range ls {
+			out = append(out, l.Number)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   []int
+	}{
+		{"zero value keeps all", LogFilter{}, []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"tail only", LogFilter{Tail: 2}, []int{7, 8}},
+		{"errors with context", LogFilter{AroundErrors: true, Context: 1}, []int{2, 3, 4}},
+		{"grep and errors combine", LogFilter{AroundErrors: true, Grep: regexp.MustCompile(`^match$`)}, []int{3, 7}},
+		{"tail after grep", LogFilter{Grep: regexp.MustCompile(`^[a-d]$`), Tail: 2}, []int{4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, numbers(FilterLogLines(lines, tt.filter))); diff != "" {
+				t.Errorf("line numbers mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJobLogLinesStepNeedsTimestamps(t *testing.T) {
+	if _, err := JobLogLines("untimed\n", []domain.JobStep{{Number: 1}}, 1); err == nil {
+		t.Error("JobLogLines with a step on an untimed log: want an error")
+	}
+}
@@ -6,13 +6,20 @@ import (
 	"io"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
 )
 
 // maxExcerptLines is the maximum number of lines in an extracted log excerpt.
 const maxExcerptLines = 50
 
+// stepTailLines is how many trailing lines a failing step contributes when
+// none of its lines look error-relevant.
+const stepTailLines = 10
+
 // ansiRegexp matches ANSI escape sequences.
 var ansiRegexp = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 
@@ -35,13 +42,11 @@ var errorPrefixes = []string{
 	"##[error]",
 }
 
-// fileLineRegexp matches file:line patterns like foo.go:42: or src/main.rs:10:5:
-var fileLineRegexp = regexp.MustCompile(`\S+\.\w+:\d+`)
-
 // FetchJobLog fetches the plain-text log for a GitHub Actions job via REST.
 // The endpoint returns a 302 redirect to the log content; go-gh follows redirects
 // automatically. We use RequestWithContext to get the raw response since the
-// endpoint returns plain text, not JSON.
+// endpoint returns plain text, not JSON. Logs are fetched for completed jobs
+// only, so the cache keeps them without revalidation.
 func (c *Client) FetchJobLog(ctx context.Context, owner, repo string, jobID int64) (string, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
@@ -50,7 +55,7 @@ func (c *Client) FetchJobLog(ctx context.Context, owner, repo string, jobID int6
 	slog.Debug("fetching job log", "owner", owner, "repo", repo, "jobID", jobID)
 
 	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID)
-	resp, err := c.rest.RequestWithContext(ctx, "GET", path, nil)
+	resp, err := c.rest.RequestWithContext(withImmutableResponse(ctx), "GET", path, nil)
 	if err != nil {
 		return "", classifyError(err)
 	}
@@ -73,27 +78,198 @@ func (c *Client) FetchJobLog(ctx context.Context, owner, repo string, jobID int6
 	return string(body), nil
 }
 
+// jobResponse is the subset of the REST job object ghent reads.
+type jobResponse struct {
+	Steps []struct {
+		Number      int        `json:"number"`
+		Name        string     `json:"name"`
+		Status      string     `json:"status"`
+		Conclusion  *string    `json:"conclusion"` // null while the step runs
+		StartedAt   *time.Time `json:"started_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+	} `json:"steps"`
+}
+
+// FetchJobSteps fetches the step list of a GitHub Actions job via REST. Like
+// the log, it is only fetched for completed jobs, so the cache keeps it
+// without revalidation.
+func (c *Client) FetchJobSteps(ctx context.Context, owner, repo string, jobID int64) ([]domain.JobStep, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d", owner, repo, jobID)
+	var resp jobResponse
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(withImmutableResponse(ctx), "GET", path, nil, &resp)
+	}); err != nil {
+		return nil, classifyError(err)
+	}
+
+	steps := make([]domain.JobStep, 0, len(resp.Steps))
+	for _, s := range resp.Steps {
+		st := domain.JobStep{Number: s.Number, Name: s.Name, Status: s.Status}
+		if s.Conclusion != nil {
+			st.Conclusion = *s.Conclusion
+		}
+		if s.StartedAt != nil {
+			st.StartedAt = *s.StartedAt
+		}
+		if s.CompletedAt != nil {
+			st.CompletedAt = *s.CompletedAt
+		}
+		if !st.StartedAt.IsZero() && st.CompletedAt.After(st.StartedAt) {
+			st.DurationSeconds = int(st.CompletedAt.Sub(st.StartedAt).Seconds())
+		}
+		steps = append(steps, st)
+	}
+	slog.Debug("fetched job steps", "jobID", jobID, "count", len(steps))
+	return steps, nil
+}
+
 // ExtractErrorLines extracts error-relevant lines from a job log.
 // It looks for lines containing error keywords, file:line patterns,
 // and common error prefixes. Context lines (1 before, 1 after) are included
 // around each match. The result is truncated to maxExcerptLines.
 func ExtractErrorLines(log string) string {
-	lines := strings.Split(log, "\n")
+	return excerptErrorLines(cleanLines(strings.Split(log, "\n")), false)
+}
 
-	// Clean all lines first: strip ANSI codes and timestamps
-	cleaned := make([]string, len(lines))
+// ExtractStepErrorLines extracts error-relevant lines from the failing steps
+// of a job log only, so setup and teardown noise stays out of the excerpt.
+// Lines are assigned to steps by timestamp (see splitLogBySteps), and each
+// match carries the nearest preceding ##[group] header of its step, which
+// names the command that ran. A failing step without error-relevant lines
+// contributes its last stepTailLines lines. It falls back to
+// ExtractErrorLines when no step failed or the log has no timestamps.
+func ExtractStepErrorLines(log string, steps []domain.JobStep) (string, []domain.JobStep) {
+	var failed []domain.JobStep
+	for _, st := range steps {
+		if domain.IsFailConclusion(st.Conclusion) {
+			failed = append(failed, st)
+		}
+	}
+	if len(failed) == 0 {
+		return ExtractErrorLines(log), nil
+	}
+	sections, ok := splitLogBySteps(strings.Split(log, "\n"), steps)
+	if !ok {
+		return ExtractErrorLines(log), failed
+	}
+
+	var parts []string
+	for _, st := range failed {
+		cleaned := cleanLines(sections[st.Number])
+		excerpt := excerptErrorLines(cleaned, true)
+		if excerpt == "" {
+			cleaned = trimBlank(cleaned)
+			excerpt = strings.Join(cleaned[max(0, len(cleaned)-stepTailLines):], "\n")
+		}
+		if excerpt == "" {
+			continue
+		}
+		if len(failed) > 1 {
+			excerpt = fmt.Sprintf("=== step %d: %s (%s) ===\n%s", st.Number, st.Name, st.Conclusion, excerpt)
+		}
+		parts = append(parts, excerpt)
+	}
+	result := strings.Split(strings.Join(parts, "\n"), "\n")
+	if len(result) > maxExcerptLines {
+		result = result[:maxExcerptLines]
+	}
+	return strings.Join(result, "\n"), failed
+}
+
+// splitLogBySteps groups raw log lines by step number (see lineSteps).
+func splitLogBySteps(lines []string, steps []domain.JobStep) (map[int][]string, bool) {
+	numbers, ok := lineSteps(lines, steps)
+	if !ok {
+		return nil, false
+	}
+	sections := make(map[int][]string)
 	for i, line := range lines {
-		cleaned[i] = cleanLine(line)
+		sections[numbers[i]] = append(sections[numbers[i]], line)
+	}
+	return sections, true
+}
+
+// lineSteps returns the number of the step running at each raw log line's
+// timestamp. Step times from the jobs API have second precision, so within
+// the second a step starts, the switch happens at the first ##[group] header
+// (the step's "Run ..." banner) or once the previous step has completed.
+// Lines without a timestamp stay with the current step. ok is false when no
+// step has a start time or no line has a timestamp.
+func lineSteps(lines []string, steps []domain.JobStep) ([]int, bool) {
+	var timed []domain.JobStep
+	for _, st := range steps {
+		if !st.StartedAt.IsZero() {
+			timed = append(timed, st)
+		}
+	}
+	if len(timed) == 0 {
+		return nil, false
+	}
+	slices.SortStableFunc(timed, func(a, b domain.JobStep) int { return a.Number - b.Number })
+
+	numbers := make([]int, len(lines))
+	cur, sawTime := 0, false
+	for i, line := range lines {
+		if ts, ok := parseLogTime(line); ok {
+			sawTime = true
+			for cur+1 < len(timed) && startsStep(ts, line, timed[cur], timed[cur+1]) {
+				cur++
+			}
+		}
+		numbers[i] = timed[cur].Number
+	}
+	return numbers, sawTime
+}
+
+// startsStep reports whether a line stamped ts belongs to next rather than
+// cur. Within the second next starts, it does so only at a ##[group] header
+// or once cur has finished.
+func startsStep(ts time.Time, line string, cur, next domain.JobStep) bool {
+	switch {
+	case ts.Before(next.StartedAt):
+		return false
+	case !ts.Before(next.StartedAt.Add(time.Second)):
+		return true
+	case !cur.CompletedAt.IsZero() && !ts.Before(cur.CompletedAt.Add(time.Second)):
+		return true
+	default:
+		return strings.HasPrefix(cleanLine(line), "##[group]")
+	}
+}
+
+// parseLogTime parses the GitHub Actions timestamp prefix of a raw log line.
+func parseLogTime(line string) (time.Time, bool) {
+	m := timestampRegexp.FindString(line)
+	if m == "" {
+		return time.Time{}, false
 	}
+	ts, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(m))
+	return ts, err == nil
+}
 
+// excerptErrorLines collects the error-relevant lines of cleaned log lines
+// with one line of context on each side, inserting "..." for gaps. With
+// groups set, each match also pulls in the nearest preceding ##[group]
+// header. ##[endgroup] markers are never shown.
+func excerptErrorLines(cleaned []string, groups bool) string {
 	// Find indices of error-relevant lines
 	matchSet := make(map[int]bool)
+	group := -1
 	for i, line := range cleaned {
+		if strings.HasPrefix(line, "##[group]") {
+			group = i
+		}
 		if isErrorLine(line) {
 			// Include the match and 1 line of context on each side
 			for j := max(0, i-1); j <= min(len(cleaned)-1, i+1); j++ {
 				matchSet[j] = true
 			}
+			if groups && group >= 0 {
+				matchSet[group] = true
+			}
 		}
 	}
 
@@ -106,7 +282,7 @@ func ExtractErrorLines(log string) string {
 	prevIdx := -2
 
 	for i := range len(cleaned) {
-		if !matchSet[i] {
+		if !matchSet[i] || (groups && strings.HasPrefix(cleaned[i], "##[endgroup]")) {
 			continue
 		}
 
@@ -126,6 +302,15 @@ func ExtractErrorLines(log string) string {
 	return strings.Join(result, "\n")
 }
 
+// cleanLines applies cleanLine to every line.
+func cleanLines(lines []string) []string {
+	cleaned := make([]string, len(lines))
+	for i, line := range lines {
+		cleaned[i] = cleanLine(line)
+	}
+	return cleaned
+}
+
 // cleanLine strips ANSI escape codes and GitHub Actions timestamp prefixes.
 func cleanLine(line string) string {
 	line = ansiRegexp.ReplaceAllString(line, "")
@@ -153,7 +338,7 @@ func isErrorLine(line string) bool {
 	}
 
 	// Check file:line patterns
-	if fileLineRegexp.MatchString(line) {
+	if _, ok := domain.ParseFileLocation(line); ok {
 		return true
 	}
 
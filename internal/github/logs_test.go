@@ -4,6 +4,11 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
 )
 
 func TestExtractErrorLines_GoTestFailures(t *testing.T) {
@@ -296,3 +301,77 @@ func TestIsErrorLine(t *testing.T) {
 		})
 	}
 }
+
+// stepLog is a job log with a noisy setup step and a failing test step.
+const stepLog = `2025-01-15T10:30:00.0000000Z ##[group]Run actions/checkout@v4
+2025-01-15T10:30:00.1000000Z with:
+2025-01-15T10:30:00.2000000Z ##[endgroup]
+2025-01-15T10:30:01.0000000Z warning: error-prone default config ignored
+2025-01-15T10:30:05.5000000Z ##[group]Run go test ./...
+2025-01-15T10:30:05.6000000Z go test ./...
+2025-01-15T10:30:05.7000000Z ##[endgroup]
+2025-01-15T10:30:06.0000000Z ok  	example.com/a	0.1s
+2025-01-15T10:30:07.0000000Z --- FAIL: TestB (0.00s)
+2025-01-15T10:30:07.1000000Z     b_test.go:9: boom
+2025-01-15T10:30:07.2000000Z FAIL	example.com/b	0.2s
+2025-01-15T10:30:08.0000000Z ##[error]Process completed with exit code 1.
+2025-01-15T10:30:09.0000000Z Post job cleanup.`
+
+func stepFixture() []domain.JobStep {
+	at := func(sec int) time.Time { return time.Date(2025, 1, 15, 10, 30, sec, 0, time.UTC) }
+	return []domain.JobStep{
+		{Number: 1, Name: "Checkout", Conclusion: "success", StartedAt: at(0), CompletedAt: at(5)},
+		{Number: 2, Name: "Test", Conclusion: "failure", StartedAt: at(5), CompletedAt: at(8), DurationSeconds: 3},
+		{Number: 3, Name: "Post Checkout", Conclusion: "success", StartedAt: at(9), CompletedAt: at(9)},
+	}
+}
+
+func TestExtractStepErrorLines_FailingStepOnly(t *testing.T) {
+	excerpt, failed := ExtractStepErrorLines(stepLog, stepFixture())
+
+	if len(failed) != 1 || failed[0].Name != "Test" || failed[0].DurationSeconds != 3 {
+		t.Fatalf("failed steps = %+v, want the Test step", failed)
+	}
+	want := "##[group]Run go test ./...\n...\nok  \texample.com/a\t0.1s\n--- FAIL: TestB (0.00s)\n" +
+		"    b_test.go:9: boom\nFAIL\texample.com/b\t0.2s\n##[error]Process completed with exit code 1."
+	if excerpt != want {
+		t.Errorf("excerpt =\n%s\nwant\n%s", excerpt, want)
+	}
+}
+
+func TestExtractStepErrorLines_Fallbacks(t *testing.T) {
+	// No failing step: flat extraction over the whole log, setup noise included.
+	steps := stepFixture()
+	steps[1].Conclusion = "success"
+	excerpt, failed := ExtractStepErrorLines(stepLog, steps)
+	if failed != nil || !strings.Contains(excerpt, "error-prone default") {
+		t.Errorf("no failing step: failed = %+v, excerpt =\n%s", failed, excerpt)
+	}
+
+	// A failing step without error lines contributes its tail.
+	steps = stepFixture()
+	steps[2].Conclusion = "cancelled"
+	excerpt, failed = ExtractStepErrorLines(stepLog, steps)
+	if len(failed) != 2 || !strings.Contains(excerpt, "=== step 3: Post Checkout (cancelled) ===\nPost job cleanup.") {
+		t.Errorf("tail fallback: failed = %+v, excerpt =\n%s", failed, excerpt)
+	}
+}
+
+func TestSplitLogBySteps(t *testing.T) {
+	sections, ok := splitLogBySteps(strings.Split(stepLog, "\n"), stepFixture())
+	if !ok {
+		t.Fatal("splitLogBySteps reported no timestamps")
+	}
+	counts := map[int]int{}
+	for n, lines := range sections {
+		counts[n] = len(lines)
+	}
+	// The Test step starts at :05; its group header at :05.5 opens it.
+	if diff := cmp.Diff(map[int]int{1: 4, 2: 8, 3: 1}, counts); diff != "" {
+		t.Errorf("lines per step mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, ok := splitLogBySteps([]string{"no timestamps"}, stepFixture()); ok {
+		t.Error("splitLogBySteps on an untimed log: ok = true, want false")
+	}
+}
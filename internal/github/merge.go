@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// Every merge mutation passes expectedHeadOid so GitHub rejects the request
+// if a commit landed after ghent verified readiness.
+
+const mergePullRequestMutation = `
+mutation($id: ID!, $head: GitObjectID!, $method: PullRequestMergeMethod!, $headline: String, $body: String) {
+  mergePullRequest(input: {pullRequestId: $id, expectedHeadOid: $head, mergeMethod: $method, commitHeadline: $headline, commitBody: $body}) {
+    pullRequest { merged mergeCommit { oid } }
+  }
+}
+`
+
+const enableAutoMergeMutation = `
+mutation($id: ID!, $head: GitObjectID!, $method: PullRequestMergeMethod!, $headline: String, $body: String) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, expectedHeadOid: $head, mergeMethod: $method, commitHeadline: $headline, commitBody: $body}) {
+    pullRequest { number }
+  }
+}
+`
+
+const enqueuePullRequestMutation = `
+mutation($id: ID!, $head: GitObjectID!) {
+  enqueuePullRequest(input: {pullRequestId: $id, expectedHeadOid: $head}) {
+    mergeQueueEntry { position }
+  }
+}
+`
+
+type mergePullRequestResponse struct {
+	MergePullRequest struct {
+		PullRequest struct {
+			Merged      bool `json:"merged"`
+			MergeCommit *struct {
+				OID string `json:"oid"`
+			} `json:"mergeCommit"`
+		} `json:"pullRequest"`
+	} `json:"mergePullRequest"`
+}
+
+// MergePullRequest merges pr with req.Method and returns the merge commit SHA.
+func (c *Client) MergePullRequest(ctx context.Context, pr *domain.PullRequestInfo, req domain.MergeRequest) (string, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("merging pull request", "pr", pr.Number, "method", req.Method)
+
+	var resp mergePullRequestResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, mergePullRequestMutation, mergeVars(pr, req), &resp)
+	}); err != nil {
+		return "", fmt.Errorf("merge pull request: %w", classifyError(err))
+	}
+
+	slog.Debug("merged pull request", "pr", pr.Number, "duration", time.Since(start))
+	if commit := resp.MergePullRequest.PullRequest.MergeCommit; commit != nil {
+		return commit.OID, nil
+	}
+	return "", nil
+}
+
+// EnableAutoMerge asks GitHub to merge pr with req.Method once its branch
+// rules are satisfied.
+func (c *Client) EnableAutoMerge(ctx context.Context, pr *domain.PullRequestInfo, req domain.MergeRequest) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("enabling auto-merge", "pr", pr.Number, "method", req.Method)
+
+	var resp map[string]any
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, enableAutoMergeMutation, mergeVars(pr, req), &resp)
+	}); err != nil {
+		return fmt.Errorf("enable auto-merge: %w", classifyError(err))
+	}
+
+	slog.Debug("enabled auto-merge", "pr", pr.Number, "duration", time.Since(start))
+	return nil
+}
+
+// EnqueuePullRequest adds pr to the base branch's merge queue. The queue's
+// own configuration decides the merge method.
+func (c *Client) EnqueuePullRequest(ctx context.Context, pr *domain.PullRequestInfo) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("enqueuing pull request", "pr", pr.Number)
+
+	vars := map[string]interface{}{
+		"id":   pr.ID,
+		"head": pr.HeadSHA,
+	}
+	var resp map[string]any
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, enqueuePullRequestMutation, vars, &resp)
+	}); err != nil {
+		return fmt.Errorf("enqueue pull request: %w", classifyError(err))
+	}
+
+	slog.Debug("enqueued pull request", "pr", pr.Number, "duration", time.Since(start))
+	return nil
+}
+
+// mergeVars builds the shared variables for the merge and auto-merge
+// mutations. Empty headline/body are sent as null so GitHub uses its defaults.
+func mergeVars(pr *domain.PullRequestInfo, req domain.MergeRequest) map[string]interface{} {
+	vars := map[string]interface{}{
+		"id":       pr.ID,
+		"head":     pr.HeadSHA,
+		"method":   strings.ToUpper(req.Method),
+		"headline": nil,
+		"body":     nil,
+	}
+	if req.CommitHeadline != "" {
+		vars["headline"] = req.CommitHeadline
+	}
+	if req.CommitBody != "" {
+		vars["body"] = req.CommitBody
+	}
+	return vars
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestMergeVars(t *testing.T) {
+	pr := &domain.PullRequestInfo{ID: "PR_kw1", HeadSHA: "abc123"}
+
+	got := mergeVars(pr, domain.MergeRequest{Method: domain.MergeMethodSquash})
+	want := map[string]interface{}{
+		"id": "PR_kw1", "head": "abc123", "method": "SQUASH", "headline": nil, "body": nil,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeVars mismatch (-want +got):\n%s", diff)
+	}
+
+	got = mergeVars(pr, domain.MergeRequest{Method: domain.MergeMethodMerge, CommitHeadline: "Ship it", CommitBody: "body"})
+	if got["headline"] != "Ship it" || got["body"] != "body" || got["method"] != "MERGE" {
+		t.Errorf("mergeVars with message = %v", got)
+	}
+}
+
+func TestMergePullRequestResponseParsing(t *testing.T) {
+	data := []byte(`{"mergePullRequest":{"pullRequest":{"merged":true,"mergeCommit":{"oid":"def456"}}}}`)
+
+	var resp mergePullRequestResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !resp.MergePullRequest.PullRequest.Merged {
+		t.Error("merged = false, want true")
+	}
+	if c := resp.MergePullRequest.PullRequest.MergeCommit; c == nil || c.OID != "def456" {
+		t.Errorf("mergeCommit = %+v, want oid def456", c)
+	}
+}
@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// branchProtectionResponse is the classic branch protection payload
+// (GET repos/{o}/{r}/branches/{b}/protection). Reading it requires admin.
+type branchProtectionResponse struct {
+	RequiredStatusChecks       *requiredStatusChecks `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	RequiredLinearHistory          *enabledFlag `json:"required_linear_history"`
+	RequiredConversationResolution *enabledFlag `json:"required_conversation_resolution"`
+
+	// summaryOnly marks a response built from the branch summary, which
+	// omits every setting but the required status checks.
+	summaryOnly bool
+}
+
+type requiredStatusChecks struct {
+	Contexts []string         `json:"contexts"`
+	Checks   []statusCheckRef `json:"checks"`
+}
+
+type statusCheckRef struct {
+	Context string `json:"context"`
+}
+
+type enabledFlag struct {
+	Enabled bool `json:"enabled"`
+}
+
+// branchResponse is the subset of GET repos/{o}/{r}/branches/{b} used when the
+// caller cannot read full protection settings. Only required status checks are
+// exposed there.
+type branchResponse struct {
+	Protected  bool `json:"protected"`
+	Protection struct {
+		RequiredStatusChecks requiredStatusChecks `json:"required_status_checks"`
+	} `json:"protection"`
+}
+
+// branchRuleNode is one active rule from GET repos/{o}/{r}/rules/branches/{b}.
+type branchRuleNode struct {
+	Type       string `json:"type"`
+	RulesetID  int64  `json:"ruleset_id"`
+	Parameters struct {
+		RequiredApprovingReviewCount   int              `json:"required_approving_review_count"`
+		RequireCodeOwnerReview         bool             `json:"require_code_owner_review"`
+		DismissStaleReviewsOnPush      bool             `json:"dismiss_stale_reviews_on_push"`
+		RequiredReviewThreadResolution bool             `json:"required_review_thread_resolution"`
+		RequiredStatusChecks           []statusCheckRef `json:"required_status_checks"`
+	} `json:"parameters"`
+}
+
+// FetchMergeRequirements returns the merge rules that apply to a branch,
+// combining classic branch protection with active rulesets. A branch with no
+// rules yields a MergeRequirements with no Sources.
+func (c *Client) FetchMergeRequirements(ctx context.Context, owner, repo, branch string) (*domain.MergeRequirements, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("fetching merge requirements", "owner", owner, "repo", repo, "branch", branch)
+
+	req := &domain.MergeRequirements{BaseBranch: branch}
+
+	protection, err := c.fetchBranchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	if protection != nil {
+		applyBranchProtection(req, protection)
+	}
+
+	rules, err := c.fetchBranchRules(ctx, owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	applyBranchRules(req, rules)
+
+	slog.Debug("fetched merge requirements", "sources", req.Sources, "duration", time.Since(start))
+	return req, nil
+}
+
+// fetchBranchProtection reads classic protection. An unprotected branch yields
+// nil. Without admin access it degrades to the required status checks that
+// the public branch endpoint exposes.
+func (c *Client) fetchBranchProtection(ctx context.Context, owner, repo, branch string) (*branchProtectionResponse, error) {
+	path := fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, url.PathEscape(branch))
+	var resp branchProtectionResponse
+	err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
+	})
+	if err == nil {
+		return &resp, nil
+	}
+
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) {
+		return nil, classifyError(err)
+	}
+	switch httpErr.StatusCode {
+	case 404:
+		return nil, nil //nolint:nilerr // 404 means the branch is not protected
+	case 403:
+		if isRateLimitMessage(httpErr.Message) {
+			return nil, classifyError(err)
+		}
+		slog.Debug("branch protection not readable, using branch summary", "branch", branch)
+		return c.fetchBranchSummaryProtection(ctx, owner, repo, branch)
+	}
+	return nil, classifyError(err)
+}
+
+func (c *Client) fetchBranchSummaryProtection(ctx context.Context, owner, repo, branch string) (*branchProtectionResponse, error) {
+	path := fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, url.PathEscape(branch))
+	var resp branchResponse
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
+	}); err != nil {
+		return nil, classifyWithContext(err, "branch", fmt.Sprintf("branch %q in %s/%s", branch, owner, repo))
+	}
+	if !resp.Protected {
+		return nil, nil
+	}
+
+	return &branchProtectionResponse{RequiredStatusChecks: &resp.Protection.RequiredStatusChecks, summaryOnly: true}, nil
+}
+
+// fetchBranchRules lists every active ruleset rule targeting the branch.
+func (c *Client) fetchBranchRules(ctx context.Context, owner, repo, branch string) ([]branchRuleNode, error) {
+	var all []branchRuleNode
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=100&page=%d",
+			owner, repo, url.PathEscape(branch), page)
+		var resp []branchRuleNode
+		if err := doWithRetry(func() error {
+			return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
+		}); err != nil {
+			return nil, classifyError(err)
+		}
+		all = append(all, resp...)
+		if len(resp) < 100 {
+			break
+		}
+	}
+	return all, nil
+}
+
+func applyBranchProtection(req *domain.MergeRequirements, p *branchProtectionResponse) {
+	req.Sources = append(req.Sources, "branch_protection")
+	req.ReviewRulesUnknown = p.summaryOnly
+	if p.RequiredStatusChecks != nil {
+		for _, ctxName := range p.RequiredStatusChecks.Contexts {
+			addRequiredCheck(req, ctxName)
+		}
+		for _, ch := range p.RequiredStatusChecks.Checks {
+			addRequiredCheck(req, ch.Context)
+		}
+	}
+	if r := p.RequiredPullRequestReviews; r != nil {
+		req.RequiredApprovals = max(req.RequiredApprovals, r.RequiredApprovingReviewCount)
+		req.RequireCodeOwnerReview = req.RequireCodeOwnerReview || r.RequireCodeOwnerReviews
+		req.DismissStaleApprovals = req.DismissStaleApprovals || r.DismissStaleReviews
+	}
+	if p.RequiredLinearHistory != nil && p.RequiredLinearHistory.Enabled {
+		req.RequireLinearHistory = true
+	}
+	if p.RequiredConversationResolution != nil && p.RequiredConversationResolution.Enabled {
+		req.RequireConversationResolution = true
+	}
+}
+
+// applyBranchRules folds ruleset rules into req. Only rule types that affect
+// merge readiness are considered; each contributing ruleset is recorded once.
+func applyBranchRules(req *domain.MergeRequirements, rules []branchRuleNode) {
+	for _, rule := range rules {
+		switch rule.Type {
+		case "pull_request":
+			p := rule.Parameters
+			req.RequiredApprovals = max(req.RequiredApprovals, p.RequiredApprovingReviewCount)
+			req.RequireCodeOwnerReview = req.RequireCodeOwnerReview || p.RequireCodeOwnerReview
+			req.DismissStaleApprovals = req.DismissStaleApprovals || p.DismissStaleReviewsOnPush
+			req.RequireConversationResolution = req.RequireConversationResolution || p.RequiredReviewThreadResolution
+		case "required_status_checks":
+			for _, ch := range rule.Parameters.RequiredStatusChecks {
+				addRequiredCheck(req, ch.Context)
+			}
+		case "required_linear_history":
+			req.RequireLinearHistory = true
+		default:
+			continue
+		}
+		source := "ruleset:" + strconv.FormatInt(rule.RulesetID, 10)
+		if !slices.Contains(req.Sources, source) {
+			req.Sources = append(req.Sources, source)
+		}
+	}
+}
+
+func addRequiredCheck(req *domain.MergeRequirements, name string) {
+	if name != "" && !slices.Contains(req.RequiredChecks, name) {
+		req.RequiredChecks = append(req.RequiredChecks, name)
+	}
+}
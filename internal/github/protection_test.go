@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func loadJSONFixture(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("unmarshal fixture %s: %v", path, err)
+	}
+}
+
+func TestMergeRequirementsFromProtectionAndRules(t *testing.T) {
+	var protection branchProtectionResponse
+	loadJSONFixture(t, "../../testdata/rest/branch_protection.json", &protection)
+	var rules []branchRuleNode
+	loadJSONFixture(t, "../../testdata/rest/branch_rules.json", &rules)
+
+	got := &domain.MergeRequirements{BaseBranch: "main"}
+	applyBranchProtection(got, &protection)
+	applyBranchRules(got, rules)
+
+	want := &domain.MergeRequirements{
+		BaseBranch:                    "main",
+		RequiredApprovals:             2,
+		RequireCodeOwnerReview:        true,
+		DismissStaleApprovals:         true,
+		RequireConversationResolution: true,
+		RequireLinearHistory:          true,
+		RequiredChecks:                []string{"build", "ci/jenkins", "security/scan"},
+		Sources:                       []string{"branch_protection", "ruleset:202", "ruleset:303"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("requirements mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyBranchRules_NoRelevantRules(t *testing.T) {
+	got := &domain.MergeRequirements{BaseBranch: "main"}
+	applyBranchRules(got, []branchRuleNode{{Type: "deletion", RulesetID: 1}, {Type: "non_fast_forward", RulesetID: 1}})
+	if got.HasRules() {
+		t.Errorf("HasRules() = true for rulesets without merge rules, sources = %v", got.Sources)
+	}
+}
+
+// stubTransport answers REST requests by URL path.
+type stubTransport func(path string) (int, string)
+
+func (f stubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	code, body := f(r.URL.Path)
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestFetchMergeRequirements_ProtectionForbidden(t *testing.T) {
+	rest, err := api.NewRESTClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "fake-token",
+		Transport: stubTransport(func(path string) (int, string) {
+			switch path {
+			case "/repos/owner/repo/branches/main/protection":
+				return http.StatusForbidden, `{"message":"Resource not accessible by integration"}`
+			case "/repos/owner/repo/branches/main":
+				return http.StatusOK, `{"protected":true,"protection":{"required_status_checks":{"contexts":["build"]}}}`
+			case "/repos/owner/repo/rules/branches/main":
+				return http.StatusOK, `[]`
+			}
+			return http.StatusNotFound, `{"message":"Not Found"}`
+		}),
+	})
+	if err != nil {
+		t.Fatalf("creating test REST client: %v", err)
+	}
+	c := &Client{rest: rest}
+
+	req, err := c.FetchMergeRequirements(context.Background(), "owner", "repo", "main")
+	if err != nil {
+		t.Fatalf("FetchMergeRequirements() error: %v", err)
+	}
+	want := &domain.MergeRequirements{
+		BaseBranch:         "main",
+		RequiredChecks:     []string{"build"},
+		Sources:            []string{"branch_protection"},
+		ReviewRulesUnknown: true,
+	}
+	if diff := cmp.Diff(want, req); diff != "" {
+		t.Errorf("requirements mismatch (-want +got):\n%s", diff)
+	}
+
+	// The unreadable review settings must not waive the approval rule.
+	blockers := domain.EvaluateReadiness(domain.ReadinessInput{Requirements: req, Reviews: []domain.Review{}})
+	wantBlockers := []domain.MergeBlocker{
+		{Rule: "required_approvals", Source: domain.BlockerSourceDefault, Message: "needs 1 approval, has 0"},
+	}
+	if diff := cmp.Diff(wantBlockers, blockers); diff != "" {
+		t.Errorf("blockers mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,280 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// pullRequestsByBranchQuery finds open pull requests whose head branch matches
+// the given ref name. The head repository owner is included so callers can
+// disambiguate fork PRs that happen to share a branch name.
+const pullRequestsByBranchQuery = `
+query($owner: String!, $repo: String!, $branch: String!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequests(headRefName: $branch, states: OPEN, first: 10, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      nodes {
+        number
+        headRepositoryOwner { login }
+      }
+    }
+  }
+}
+`
+
+// pullRequestQuery fetches the PR metadata used for merge-readiness decisions.
+const pullRequestQuery = `
+query($owner: String!, $repo: String!, $pr: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $pr) {
+      id
+      number
+      isDraft
+      mergeable
+      mergeStateStatus
+      baseRefName
+      baseRefOid
+      headRefName
+      headRefOid
+      reviewDecision
+      labels(first: 100) { nodes { name } }
+    }
+  }
+}
+`
+
+type pullRequestResponse struct {
+	Repository *struct {
+		PullRequest *pullRequestNode `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type pullRequestNode struct {
+	ID               string `json:"id"`
+	Number           int    `json:"number"`
+	IsDraft          bool   `json:"isDraft"`
+	Mergeable        string `json:"mergeable"`
+	MergeStateStatus string `json:"mergeStateStatus"`
+	BaseRefName      string `json:"baseRefName"`
+	BaseRefOID       string `json:"baseRefOid"`
+	HeadRefName      string `json:"headRefName"`
+	HeadRefOID       string `json:"headRefOid"`
+	ReviewDecision   string `json:"reviewDecision"`
+	Labels           struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+type pullRequestsByBranchResponse struct {
+	Repository *struct {
+		PullRequests struct {
+			Nodes []pullRequestBranchNode `json:"nodes"`
+		} `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+type pullRequestBranchNode struct {
+	Number              int         `json:"number"`
+	HeadRepositoryOwner *ownerLogin `json:"headRepositoryOwner"`
+}
+
+type ownerLogin struct {
+	Login string `json:"login"`
+}
+
+// FindPRForBranch returns the number of the open pull request whose head is
+// the given branch. headOwner narrows the match to PRs opened from that
+// owner's repository; with "" the branch must match exactly one open PR,
+// since forks often share branch names like "fix" or "patch-1".
+func (c *Client) FindPRForBranch(ctx context.Context, owner, repo, branch, headOwner string) (int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("finding PR for branch", "owner", owner, "repo", repo, "branch", branch, "headOwner", headOwner)
+
+	vars := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"branch": branch,
+	}
+
+	var resp pullRequestsByBranchResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, pullRequestsByBranchQuery, vars, &resp)
+	}); err != nil {
+		return 0, classifyWithContext(err, "repository", fmt.Sprintf("%s/%s", owner, repo))
+	}
+	if resp.Repository == nil {
+		return 0, &NotFoundError{Resource: "repository", Detail: fmt.Sprintf("%s/%s", owner, repo)}
+	}
+
+	number, err := pickBranchPR(resp.Repository.PullRequests.Nodes, headOwner)
+	if err != nil {
+		return 0, fmt.Errorf("branch %q in %s/%s: %w", branch, owner, repo, err)
+	}
+	if number == 0 {
+		return 0, &NotFoundError{
+			Resource: "pull request",
+			Detail:   fmt.Sprintf("open PR for branch %q in %s/%s", branch, owner, repo),
+		}
+	}
+
+	slog.Debug("found PR for branch", "branch", branch, "pr", number, "duration", time.Since(start))
+	return number, nil
+}
+
+// FetchPullRequest retrieves base/head refs, labels, mergeability, and the
+// review decision for a PR. How far the head is behind its base comes from a
+// follow-up compare call; if that fails BehindBy is left at zero.
+func (c *Client) FetchPullRequest(ctx context.Context, owner, repo string, pr int) (*domain.PullRequestInfo, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("fetching pull request", "owner", owner, "repo", repo, "pr", pr)
+
+	vars := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+		"pr":    pr,
+	}
+
+	var resp pullRequestResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, pullRequestQuery, vars, &resp)
+	}); err != nil {
+		return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
+	}
+	if resp.Repository == nil || resp.Repository.PullRequest == nil {
+		return nil, &NotFoundError{Resource: "pull request", Detail: fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo)}
+	}
+
+	info := mapPullRequest(resp.Repository.PullRequest)
+	if info.BaseSHA != "" && info.HeadSHA != "" {
+		behind, err := c.fetchBehindBy(ctx, owner, repo, info.BaseSHA, info.HeadSHA)
+		if err != nil {
+			slog.Debug("compare with base failed", "pr", pr, "error", err)
+		}
+		info.BehindBy = behind
+	}
+
+	slog.Debug("fetched pull request", "pr", pr, "mergeState", info.MergeStateStatus, "duration", time.Since(start))
+	return info, nil
+}
+
+type compareResponse struct {
+	BehindBy int `json:"behind_by"`
+}
+
+// fetchBehindBy returns how many commits base has that head does not. Fork
+// heads resolve too, since their commits are reachable from the base repo.
+func (c *Client) fetchBehindBy(ctx context.Context, owner, repo, base, head string) (int, error) {
+	path := fmt.Sprintf("repos/%s/%s/compare/%s...%s?per_page=1", owner, repo, base, head)
+	var resp compareResponse
+	if err := doWithRetry(func() error {
+		return c.rest.DoWithContext(ctx, "GET", path, nil, &resp)
+	}); err != nil {
+		return 0, classifyError(err)
+	}
+	return resp.BehindBy, nil
+}
+
+// updateBranchMutation merges or rebases the base branch into the PR head.
+// expectedHeadOid makes GitHub refuse the update if the head moved.
+const updateBranchMutation = `
+mutation($id: ID!, $head: GitObjectID!, $method: PullRequestBranchUpdateMethod!) {
+  updatePullRequestBranch(input: {pullRequestId: $id, expectedHeadOid: $head, updateMethod: $method}) {
+    pullRequest { headRefOid }
+  }
+}
+`
+
+type updateBranchResponse struct {
+	UpdatePullRequestBranch struct {
+		PullRequest struct {
+			HeadRefOID string `json:"headRefOid"`
+		} `json:"pullRequest"`
+	} `json:"updatePullRequestBranch"`
+}
+
+// UpdateBranch brings the PR head up to date with its base using method
+// (domain.UpdateMethodMerge or domain.UpdateMethodRebase) and returns the new
+// head SHA. The update is rejected if the head is no longer pr.HeadSHA.
+func (c *Client) UpdateBranch(ctx context.Context, pr *domain.PullRequestInfo, method string) (string, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("updating pull request branch", "pr", pr.Number, "method", method)
+
+	vars := map[string]interface{}{
+		"id":     pr.ID,
+		"head":   pr.HeadSHA,
+		"method": strings.ToUpper(method),
+	}
+
+	var resp updateBranchResponse
+	if err := doWithRetry(func() error {
+		return c.gql.DoWithContext(ctx, updateBranchMutation, vars, &resp)
+	}); err != nil {
+		return "", fmt.Errorf("update branch: %w", classifyError(err))
+	}
+
+	slog.Debug("updated pull request branch", "pr", pr.Number, "duration", time.Since(start))
+	return resp.UpdatePullRequestBranch.PullRequest.HeadRefOID, nil
+}
+
+func mapPullRequest(n *pullRequestNode) *domain.PullRequestInfo {
+	info := &domain.PullRequestInfo{
+		ID:               n.ID,
+		Number:           n.Number,
+		BaseRef:          n.BaseRefName,
+		BaseSHA:          n.BaseRefOID,
+		HeadRef:          n.HeadRefName,
+		HeadSHA:          n.HeadRefOID,
+		ReviewDecision:   n.ReviewDecision,
+		IsDraft:          n.IsDraft,
+		Mergeable:        n.Mergeable,
+		MergeStateStatus: n.MergeStateStatus,
+	}
+	for _, l := range n.Labels.Nodes {
+		info.Labels = append(info.Labels, l.Name)
+	}
+	return info
+}
+
+// pickBranchPR selects the most recently updated PR whose head owner matches
+// headOwner (case-insensitive), or 0 when none does. With an empty headOwner
+// a single PR is taken as is, and several are an error listing them.
+func pickBranchPR(nodes []pullRequestBranchNode, headOwner string) (int, error) {
+	if headOwner == "" {
+		if len(nodes) > 1 {
+			candidates := make([]string, len(nodes))
+			for i, n := range nodes {
+				candidates[i] = fmt.Sprintf("#%d", n.Number)
+				if n.HeadRepositoryOwner != nil {
+					candidates[i] += " (" + n.HeadRepositoryOwner.Login + ")"
+				}
+			}
+			return 0, fmt.Errorf("matches %d open PRs: %s; pass --pr with a number or URL",
+				len(nodes), strings.Join(candidates, ", "))
+		}
+		if len(nodes) == 1 {
+			return nodes[0].Number, nil
+		}
+		return 0, nil
+	}
+	for _, n := range nodes {
+		if n.HeadRepositoryOwner != nil && strings.EqualFold(n.HeadRepositoryOwner.Login, headOwner) {
+			return n.Number, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+func TestPickBranchPR(t *testing.T) {
+	owner := func(login string) *ownerLogin { return &ownerLogin{Login: login} }
+	nodes := []pullRequestBranchNode{
+		{Number: 12, HeadRepositoryOwner: owner("fork-user")},
+		{Number: 7, HeadRepositoryOwner: owner("Upstream")},
+		{Number: 3},
+	}
+
+	tests := []struct {
+		name      string
+		nodes     []pullRequestBranchNode
+		headOwner string
+		want      int
+		wantErr   string
+	}{
+		{
+			name:    "any owner is ambiguous across forks",
+			nodes:   nodes,
+			wantErr: "matches 3 open PRs: #12 (fork-user), #7 (Upstream), #3; pass --pr with a number or URL",
+		},
+		{name: "any owner with a single PR", nodes: nodes[1:2], want: 7},
+		{name: "owner filter", nodes: nodes, headOwner: "upstream", want: 7},
+		{name: "owner filter no match", nodes: nodes, headOwner: "nobody", want: 0},
+		{name: "no nodes", nodes: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pickBranchPR(tt.nodes, tt.headOwner)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("pickBranchPR() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pickBranchPR() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("pickBranchPR() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapPullRequest(t *testing.T) {
+	var n pullRequestNode
+	raw := `{
+		"id": "PR_kwDO123", "number": 42, "isDraft": true,
+		"mergeable": "CONFLICTING", "mergeStateStatus": "DIRTY",
+		"baseRefName": "main", "baseRefOid": "base111", "headRefName": "feat", "headRefOid": "head222",
+		"reviewDecision": "REVIEW_REQUIRED",
+		"labels": {"nodes": [{"name": "wip"}]}
+	}`
+	if err := json.Unmarshal([]byte(raw), &n); err != nil {
+		t.Fatal(err)
+	}
+
+	got := mapPullRequest(&n)
+	want := &domain.PullRequestInfo{
+		ID: "PR_kwDO123", Number: 42, BaseRef: "main", BaseSHA: "base111", HeadRef: "feat", HeadSHA: "head222",
+		ReviewDecision: "REVIEW_REQUIRED", Labels: []string{"wip"},
+		IsDraft: true, Mergeable: "CONFLICTING", MergeStateStatus: "DIRTY",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mapPullRequest mismatch (-want +got):\n%s", diff)
+	}
+	if !got.HasConflicts() {
+		t.Error("HasConflicts() = false, want true")
+	}
+}
@@ -3,10 +3,14 @@ package github
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/indrasvat/gh-ghent/internal/domain"
@@ -21,12 +25,51 @@ type replyResponse struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// idempotencyMarkerRe matches the hidden marker WithIdempotencyKey adds to a
+// reply body.
+var idempotencyMarkerRe = regexp.MustCompile(`<!-- ghent:idempotency-key=([A-Za-z0-9._:-]+) -->`)
+
+var validIdempotencyKey = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// WithIdempotencyKey appends a hidden HTML comment carrying key to a reply
+// body. Keys are 1-128 letters, digits, '.', '_', ':', or '-'.
+func WithIdempotencyKey(body, key string) (string, error) {
+	if !validIdempotencyKey.MatchString(key) {
+		return "", fmt.Errorf("invalid idempotency key %q: use 1-128 letters, digits, '.', '_', ':', or '-'", key)
+	}
+	return strings.TrimRight(body, "\n") + "\n\n<!-- ghent:idempotency-key=" + key + " -->", nil
+}
+
+// ContentIdempotencyKey derives an idempotency key from a reply body, so
+// that posting the same text to a thread twice is detected.
+func ContentIdempotencyKey(body string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(body)))
+	return "sha256-" + hex.EncodeToString(sum[:8])
+}
+
+// IdempotencyKey returns the idempotency key marked in a comment body, or
+// "" if there is none.
+func IdempotencyKey(body string) string {
+	if m := idempotencyMarkerRe.FindStringSubmatch(body); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
 // ReplyToThread validates a thread exists via GraphQL, then posts a reply via REST.
+// If body carries an idempotency key (see WithIdempotencyKey) and a comment
+// in the thread already carries the same key, that comment is returned as
+// already posted instead.
 func (c *Client) ReplyToThread(ctx context.Context, owner, repo string, pr int, threadID, body string) (*domain.ReplyResult, error) {
+	result, err := c.replyToThread(ctx, owner, repo, pr, threadID, body)
+	c.recordReply(owner, repo, pr, threadID, body, result, err)
+	return result, err
+}
+
+func (c *Client) replyToThread(ctx context.Context, owner, repo string, pr int, threadID, body string) (*domain.ReplyResult, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	start := time.Now()
 	slog.Debug("posting reply", "owner", owner, "repo", repo, "pr", pr, "threadID", threadID, "bodyLen", len(body))
 
 	thread, err := c.findThreadByID(ctx, owner, repo, pr, threadID)
@@ -34,6 +77,15 @@ func (c *Client) ReplyToThread(ctx context.Context, owner, repo string, pr int,
 		return nil, err
 	}
 
+	if key := IdempotencyKey(body); key != "" {
+		for _, cn := range thread.Comments.Nodes {
+			if IdempotencyKey(cn.Body) == key {
+				slog.Debug("reply already posted", "threadID", threadID, "commentID", cn.DatabaseID, "key", key)
+				return alreadyPostedReply(threadID, cn)
+			}
+		}
+	}
+
 	if !thread.ViewerCanReply {
 		return nil, fmt.Errorf("reply: viewer cannot reply to thread %s", threadID)
 	}
@@ -44,7 +96,51 @@ func (c *Client) ReplyToThread(ctx context.Context, owner, repo string, pr int,
 
 	// REST reply targets the last comment's databaseId.
 	lastComment := thread.Comments.Nodes[len(thread.Comments.Nodes)-1]
-	commentID := lastComment.DatabaseID
+	return c.postReply(ctx, owner, repo, pr, threadID, lastComment.DatabaseID, body)
+}
+
+// alreadyPostedReply reports an existing comment as the result of a reply.
+func alreadyPostedReply(threadID string, cn commentNode) (*domain.ReplyResult, error) {
+	createdAt, err := time.Parse(time.RFC3339, cn.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("reply: parse created_at %q: %w", cn.CreatedAt, err)
+	}
+	return &domain.ReplyResult{
+		ThreadID:      threadID,
+		CommentID:     cn.DatabaseID,
+		URL:           cn.URL,
+		Body:          cn.Body,
+		CreatedAt:     createdAt,
+		AlreadyPosted: true,
+	}, nil
+}
+
+// ReplyToComment posts a reply to a review comment via REST. Unlike
+// ReplyToThread it does not look the thread up: callers that already fetched
+// the threads pass the comment to reply to.
+func (c *Client) ReplyToComment(ctx context.Context, owner, repo string, pr int, threadID string, commentID int64, body string) (*domain.ReplyResult, error) {
+	result, err := c.postReply(ctx, owner, repo, pr, threadID, commentID, body)
+	c.recordReply(owner, repo, pr, threadID, body, result, err)
+	return result, err
+}
+
+// recordReply audits a reply, including one found already posted.
+func (c *Client) recordReply(owner, repo string, pr int, threadID, body string, result *domain.ReplyResult, err error) {
+	e := domain.AuditEntry{Repo: owner + "/" + repo, PR: pr, Target: threadID, Action: domain.AuditReply, BodyHash: auditHash(body)}
+	if result != nil {
+		e.CommentID = result.CommentID
+		if result.AlreadyPosted {
+			e.Outcome = domain.AuditAlreadyPosted
+		}
+	}
+	c.record(e, err)
+}
+
+func (c *Client) postReply(ctx context.Context, owner, repo string, pr int, threadID string, commentID int64, body string) (*domain.ReplyResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
 
 	reqBody := struct {
 		Body string `json:"body"`
@@ -57,12 +153,34 @@ func (c *Client) ReplyToThread(ctx context.Context, owner, repo string, pr int,
 
 	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d/comments/%d/replies", owner, repo, pr, commentID)
 
+	// A reply is not idempotent: after a server error it is only retried
+	// when its idempotency key shows it did not land in the thread.
 	var resp replyResponse
-	if err := doWithRetry(func() error {
+	var posted *commentNode
+	var landed func() (bool, error)
+	if key := IdempotencyKey(body); key != "" {
+		landed = func() (bool, error) {
+			thread, err := c.findThreadByID(ctx, owner, repo, pr, threadID)
+			if err != nil {
+				return false, err
+			}
+			for i, cn := range thread.Comments.Nodes {
+				if IdempotencyKey(cn.Body) == key {
+					posted = &thread.Comments.Nodes[i]
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	}
+	if err := retryUnlessLanded(func() error {
 		return c.rest.DoWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(encoded), &resp)
-	}); err != nil {
+	}, landed); err != nil {
 		return nil, classifyError(err)
 	}
+	if posted != nil {
+		return alreadyPostedReply(threadID, *posted)
+	}
 
 	createdAt, err := time.Parse(time.RFC3339, resp.CreatedAt)
 	if err != nil {
@@ -112,6 +230,10 @@ func (c *Client) findThreadByID(ctx context.Context, owner, repo string, pr int,
 		rt := resp.Repository.PullRequest.ReviewThreads
 		for i := range rt.Nodes {
 			if rt.Nodes[i].ID == threadID {
+				// Load every comment so the reply targets the real last one.
+				if err := c.fetchRemainingComments(ctx, &rt.Nodes[i], 0); err != nil {
+					return nil, err
+				}
 				return &rt.Nodes[i], nil
 			}
 		}
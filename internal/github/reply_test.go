@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
 )
 
 func TestReplyResponseParsing(t *testing.T) {
@@ -138,3 +141,62 @@ func TestLastCommentDatabaseID(t *testing.T) {
 		})
 	}
 }
+
+func TestIdempotencyKey(t *testing.T) {
+	body, err := WithIdempotencyKey("Fixed in abc123\n", "fix-abc123")
+	if err != nil {
+		t.Fatalf("WithIdempotencyKey: %v", err)
+	}
+	if want := "Fixed in abc123\n\n<!-- ghent:idempotency-key=fix-abc123 -->"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+	if got := IdempotencyKey(body); got != "fix-abc123" {
+		t.Errorf("IdempotencyKey() = %q, want %q", got, "fix-abc123")
+	}
+	if got := IdempotencyKey("Fixed in abc123"); got != "" {
+		t.Errorf("IdempotencyKey() of an unmarked body = %q, want empty", got)
+	}
+
+	for _, key := range []string{"", "two words", "x-->", string(make([]byte, 129))} {
+		if _, err := WithIdempotencyKey("body", key); err == nil {
+			t.Errorf("WithIdempotencyKey(%q) succeeded, want an error", key)
+		}
+	}
+}
+
+func TestContentIdempotencyKey(t *testing.T) {
+	key := ContentIdempotencyKey("Fixed in latest commit")
+	if _, err := WithIdempotencyKey("", key); err != nil {
+		t.Errorf("content key %q is not a valid key: %v", key, err)
+	}
+	if got := ContentIdempotencyKey("  Fixed in latest commit\n"); got != key {
+		t.Errorf("key changed with surrounding whitespace: %q != %q", got, key)
+	}
+	if got := ContentIdempotencyKey("Fixed in the latest commit"); got == key {
+		t.Errorf("different bodies share key %q", key)
+	}
+}
+
+func TestAlreadyPostedReply(t *testing.T) {
+	cn := commentNode{
+		DatabaseID: 1004,
+		Body:       "Fixed\n\n<!-- ghent:idempotency-key=k1 -->",
+		CreatedAt:  "2026-02-22T14:00:00Z",
+		URL:        "https://github.com/owner/repo/pull/42#discussion_r1004",
+	}
+	got, err := alreadyPostedReply("PRRT_thread3", cn)
+	if err != nil {
+		t.Fatalf("alreadyPostedReply: %v", err)
+	}
+	want := &domain.ReplyResult{
+		ThreadID:      "PRRT_thread3",
+		CommentID:     1004,
+		URL:           cn.URL,
+		Body:          cn.Body,
+		CreatedAt:     time.Date(2026, 2, 22, 14, 0, 0, 0, time.UTC),
+		AlreadyPosted: true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("alreadyPostedReply() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -16,6 +16,8 @@ mutation ResolveThread($threadId: ID!) {
       isResolved
       path
       line
+      pullRequest { number }
+      repository { nameWithOwner }
     }
   }
 }
@@ -29,6 +31,8 @@ mutation UnresolveThread($threadId: ID!) {
       isResolved
       path
       line
+      pullRequest { number }
+      repository { nameWithOwner }
     }
   }
 }
@@ -51,10 +55,29 @@ type resolvedThreadNode struct {
 	IsResolved bool   `json:"isResolved"`
 	Path       string `json:"path"`
 	Line       int    `json:"line"`
+	// PullRequest and Repository identify the thread in the audit log.
+	PullRequest struct {
+		Number int `json:"number"`
+	} `json:"pullRequest"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
 }
 
-// ResolveThread marks a review thread as resolved via GraphQL mutation.
-func (c *Client) ResolveThread(ctx context.Context, threadID string) (*domain.ResolveResult, error) {
+// recordResolution audits a resolve or unresolve against the caller's repo
+// and PR. A successful call records the thread's own repo and PR instead,
+// in case the thread ID belongs to a different PR.
+func (c *Client) recordResolution(action, owner, repo string, pr int, threadID string, t *resolvedThreadNode, err error) {
+	e := domain.AuditEntry{Action: action, Repo: owner + "/" + repo, PR: pr, Target: threadID}
+	if t != nil && t.Repository.NameWithOwner != "" {
+		e.Repo, e.PR = t.Repository.NameWithOwner, t.PullRequest.Number
+	}
+	c.record(e, err)
+}
+
+// ResolveThread marks a review thread as resolved via GraphQL mutation. The
+// owner, repo, and pr the thread was found under go to the audit log.
+func (c *Client) ResolveThread(ctx context.Context, owner, repo string, pr int, threadID string) (*domain.ResolveResult, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -69,10 +92,13 @@ func (c *Client) ResolveThread(ctx context.Context, threadID string) (*domain.Re
 	if err := doWithRetry(func() error {
 		return c.gql.DoWithContext(ctx, resolveThreadMutation, vars, &resp)
 	}); err != nil {
-		return nil, classifyWithContext(err, "thread", threadID)
+		err = classifyWithContext(err, "thread", threadID)
+		c.recordResolution(domain.AuditResolve, owner, repo, pr, threadID, nil, err)
+		return nil, err
 	}
 
 	t := resp.ResolveReviewThread.Thread
+	c.recordResolution(domain.AuditResolve, owner, repo, pr, threadID, &t, nil)
 	slog.Debug("resolved thread", "threadID", t.ID, "path", t.Path, "line", t.Line, "duration", time.Since(start))
 
 	return &domain.ResolveResult{
@@ -85,7 +111,8 @@ func (c *Client) ResolveThread(ctx context.Context, threadID string) (*domain.Re
 }
 
 // UnresolveThread marks a review thread as unresolved via GraphQL mutation.
-func (c *Client) UnresolveThread(ctx context.Context, threadID string) (*domain.ResolveResult, error) {
+// Like ResolveThread, owner, repo, and pr go to the audit log.
+func (c *Client) UnresolveThread(ctx context.Context, owner, repo string, pr int, threadID string) (*domain.ResolveResult, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -100,10 +127,13 @@ func (c *Client) UnresolveThread(ctx context.Context, threadID string) (*domain.
 	if err := doWithRetry(func() error {
 		return c.gql.DoWithContext(ctx, unresolveThreadMutation, vars, &resp)
 	}); err != nil {
-		return nil, classifyWithContext(err, "thread", threadID)
+		err = classifyWithContext(err, "thread", threadID)
+		c.recordResolution(domain.AuditUnresolve, owner, repo, pr, threadID, nil, err)
+		return nil, err
 	}
 
 	t := resp.UnresolveReviewThread.Thread
+	c.recordResolution(domain.AuditUnresolve, owner, repo, pr, threadID, &t, nil)
 	slog.Debug("unresolved thread", "threadID", t.ID, "path", t.Path, "line", t.Line, "duration", time.Since(start))
 
 	return &domain.ResolveResult{
@@ -24,6 +24,9 @@ func TestResolveResponseMapping(t *testing.T) {
 	}
 
 	thread := envelope.Data.ResolveReviewThread.Thread
+	if thread.Repository.NameWithOwner != "indrasvat/tbgs" || thread.PullRequest.Number != 1 {
+		t.Errorf("audit target = %s#%d, want indrasvat/tbgs#1", thread.Repository.NameWithOwner, thread.PullRequest.Number)
+	}
 	got := &domain.ResolveResult{
 		ThreadID:   thread.ID,
 		Path:       thread.Path,
@@ -0,0 +1,311 @@
+package github
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// suggestionContext is the number of unchanged lines shown on each side of a
+// suggestion's diff.
+const suggestionContext = 3
+
+// suggestionFenceRegexp matches the opening fence of a suggestion block.
+var suggestionFenceRegexp = regexp.MustCompile("^\\s*(`{3,}|~{3,})\\s*suggestion\\s*$")
+
+// ExtractSuggestions returns the suggestion blocks in a thread's comments,
+// oldest first. Original holds the commented lines, read from the end of the
+// diff hunk. StartLine and EndLine start out as the thread's lines and stay
+// zero for outdated threads, which PlanSuggestions locates by content. A
+// comment with several blocks is reported once as a conflict: GitHub applies
+// those as a whole, which is left to the reviewer's UI.
+func ExtractSuggestions(t domain.ReviewThread) []domain.Suggestion {
+	var out []domain.Suggestion
+	for _, c := range t.Comments {
+		blocks := parseSuggestionBlocks(c.Body)
+		if len(blocks) == 0 {
+			continue
+		}
+		hunk := c.DiffHunk
+		if hunk == "" {
+			hunk = t.Comments[0].DiffHunk
+		}
+		span := threadSpan(t)
+		s := domain.Suggestion{
+			ThreadID:    t.ID,
+			CommentID:   c.ID,
+			Author:      c.Author,
+			IsBot:       c.IsBot,
+			URL:         c.URL,
+			Path:        t.Path,
+			Original:    hunkTail(hunk, span),
+			Replacement: blocks[0],
+		}
+		if !t.IsOutdated && t.Line > 0 {
+			s.StartLine, s.EndLine = t.Line-span+1, t.Line
+		}
+		switch {
+		case len(blocks) > 1:
+			s.Status, s.Reason = domain.SuggestionConflict, fmt.Sprintf("comment holds %d suggestion blocks; apply it on GitHub", len(blocks))
+		case t.DiffSide == "LEFT":
+			s.Status, s.Reason = domain.SuggestionConflict, "comment is on deleted lines"
+		case s.Original == nil:
+			s.Status, s.Reason = domain.SuggestionConflict, "diff hunk does not cover the commented lines"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// parseSuggestionBlocks returns the lines of each closed suggestion block in
+// a comment body. An empty block deletes the commented lines.
+func parseSuggestionBlocks(body string) [][]string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var blocks [][]string
+	for i := 0; i < len(lines); i++ {
+		m := suggestionFenceRegexp.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		block := []string{}
+		closed := false
+		for i++; i < len(lines); i++ {
+			if isClosingFence(lines[i], m[1]) {
+				closed = true
+				break
+			}
+			block = append(block, lines[i])
+		}
+		if closed {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// isClosingFence reports whether line closes a block opened by fence: the
+// same character, at least as many times, and nothing else.
+func isClosingFence(line, fence string) bool {
+	t := strings.TrimSpace(line)
+	return len(t) >= len(fence) && strings.Trim(t, fence[:1]) == ""
+}
+
+// threadSpan is the number of lines a thread comments on, falling back to
+// the original lines for outdated threads.
+func threadSpan(t domain.ReviewThread) int {
+	start, end := t.StartLine, t.Line
+	if end == 0 {
+		start, end = t.OriginalStartLine, t.OriginalLine
+	}
+	if start == 0 || start > end {
+		return 1
+	}
+	return end - start + 1
+}
+
+// hunkTail returns the last n lines on the new side of a review comment's
+// diff hunk, which ends at the commented line. It returns nil when the hunk
+// holds fewer lines.
+func hunkTail(hunk string, n int) []string {
+	var lines []string
+	for _, l := range strings.Split(strings.TrimRight(hunk, "\n"), "\n") {
+		l = strings.TrimSuffix(l, "\r")
+		switch {
+		case l == "":
+			lines = append(lines, "")
+		case l[0] == ' ' || l[0] == '+':
+			lines = append(lines, l[1:])
+		}
+	}
+	if len(lines) < n {
+		return nil
+	}
+	return lines[len(lines)-n:]
+}
+
+// PlanSuggestions locates the suggestions on one file in its local content.
+// Each gets the status applicable, already_applied, or conflict, with
+// StartLine and EndLine moved to where the commented lines are now. A
+// suggestion touching lines an earlier one replaces is a conflict. Applicable
+// suggestions get a unified diff. Suggestions that are already conflicts are
+// left alone.
+func PlanSuggestions(content []byte, suggestions []domain.Suggestion) {
+	lines := sourceLines(content)
+	var taken []*domain.Suggestion
+	for i := range suggestions {
+		s := &suggestions[i]
+		if s.Status == domain.SuggestionConflict {
+			continue
+		}
+		locateSuggestion(s, lines)
+		if s.Status != domain.SuggestionApplicable {
+			continue
+		}
+		for _, o := range taken {
+			if s.StartLine <= o.EndLine && o.StartLine <= s.EndLine {
+				s.Status = domain.SuggestionConflict
+				s.Reason = "overlaps the suggestion in comment " + o.CommentID
+				break
+			}
+		}
+		if s.Status == domain.SuggestionApplicable {
+			s.Diff = suggestionDiff(s, lines)
+			taken = append(taken, s)
+		}
+	}
+}
+
+// locateSuggestion finds the commented lines at the thread's position, or
+// anywhere in the file if they occur exactly once. A suggestion whose
+// replacement is already there instead is already_applied.
+func locateSuggestion(s *domain.Suggestion, lines []string) {
+	n := len(s.Original)
+	if s.EndLine > 0 {
+		if linesAt(lines, s.StartLine, s.Original) {
+			s.Status = domain.SuggestionApplicable
+			return
+		}
+		if len(s.Replacement) > 0 && linesAt(lines, s.StartLine, s.Replacement) {
+			s.Status = domain.SuggestionAlreadyApplied
+			s.EndLine = s.StartLine + len(s.Replacement) - 1
+			return
+		}
+	}
+
+	at, count := findLines(lines, s.Original)
+	if count == 1 {
+		if s.EndLine > 0 {
+			s.Reason = fmt.Sprintf("moved from line %d", s.StartLine)
+		} else {
+			s.Reason = "outdated comment located by content"
+		}
+		s.StartLine, s.EndLine = at, at+n-1
+		s.Status = domain.SuggestionApplicable
+		return
+	}
+	if len(s.Replacement) > 0 {
+		if rat, rcount := findLines(lines, s.Replacement); rcount == 1 {
+			s.StartLine, s.EndLine = rat, rat+len(s.Replacement)-1
+			s.Status = domain.SuggestionAlreadyApplied
+			return
+		}
+	}
+
+	s.Status = domain.SuggestionConflict
+	switch {
+	case count > 1:
+		s.Reason = fmt.Sprintf("commented lines changed in place and occur %d times elsewhere", count)
+	case s.EndLine > 0:
+		s.Reason = "commented lines changed since the comment"
+	default:
+		s.Reason = "commented lines of the outdated comment are gone"
+	}
+}
+
+// linesAt reports whether want appears in lines starting at 1-based line.
+func linesAt(lines []string, line int, want []string) bool {
+	if line < 1 || line-1+len(want) > len(lines) {
+		return false
+	}
+	return slices.Equal(lines[line-1:line-1+len(want)], want)
+}
+
+// findLines returns the 1-based line of the first occurrence of want in
+// lines and how many times it occurs.
+func findLines(lines []string, want []string) (int, int) {
+	first, count := 0, 0
+	for i := 1; i+len(want)-1 <= len(lines); i++ {
+		if linesAt(lines, i, want) {
+			if count == 0 {
+				first = i
+			}
+			count++
+		}
+	}
+	return first, count
+}
+
+// sourceLines splits file content into lines without their line endings.
+func sourceLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	return lines
+}
+
+// suggestionDiff renders an applicable suggestion as a unified diff against
+// the local lines.
+func suggestionDiff(s *domain.Suggestion, lines []string) string {
+	from := max(s.StartLine-suggestionContext, 1)
+	to := min(s.EndLine+suggestionContext, len(lines))
+	context := (s.StartLine - from) + (to - s.EndLine)
+	oldCount := context + s.EndLine - s.StartLine + 1
+	newCount := context + len(s.Replacement)
+	newStart := from
+	if newCount == 0 {
+		newStart = from - 1
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", s.Path, s.Path)
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(from, oldCount), hunkRange(newStart, newCount))
+	for i := from; i < s.StartLine; i++ {
+		b.WriteString(" " + lines[i-1] + "\n")
+	}
+	for i := s.StartLine; i <= s.EndLine; i++ {
+		b.WriteString("-" + lines[i-1] + "\n")
+	}
+	for _, l := range s.Replacement {
+		b.WriteString("+" + l + "\n")
+	}
+	for i := s.EndLine + 1; i <= to; i++ {
+		b.WriteString(" " + lines[i-1] + "\n")
+	}
+	return b.String()
+}
+
+// hunkRange formats one side of a unified diff hunk header.
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// ApplySuggestions returns content with its applicable suggestions applied.
+// The suggestions must have been planned against the same content. The
+// file's line endings and final newline are kept.
+func ApplySuggestions(content []byte, suggestions []domain.Suggestion) []byte {
+	text := string(content)
+	eol := "\n"
+	if strings.Contains(text, "\r\n") {
+		eol = "\r\n"
+	}
+	lines := sourceLines(content)
+
+	var apply []domain.Suggestion
+	for _, s := range suggestions {
+		if s.Status == domain.SuggestionApplicable {
+			apply = append(apply, s)
+		}
+	}
+	// Bottom-up, so earlier line numbers stay valid.
+	slices.SortFunc(apply, func(a, b domain.Suggestion) int { return cmp.Compare(b.StartLine, a.StartLine) })
+	for _, s := range apply {
+		lines = slices.Replace(lines, s.StartLine-1, s.EndLine, s.Replacement...)
+	}
+
+	out := strings.Join(lines, eol)
+	if strings.HasSuffix(text, "\n") && len(lines) > 0 {
+		out += eol
+	}
+	return []byte(out)
+}
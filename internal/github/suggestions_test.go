@@ -0,0 +1,250 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+const sampleHunk = "@@ -8,5 +8,6 @@ func run() error {\n" +
+	" \tcfg := load()\n" +
+	"-\tif cfg == nil {\n" +
+	"+\tif cfg == nil || cfg.Empty() {\n" +
+	" \t\treturn errNoConfig\n" +
+	" \t}\n" +
+	"+\tlog.Print(cfg)"
+
+// sampleHunk11 is the hunk GitHub sends for a comment ending on line 11.
+const sampleHunk11 = "@@ -8,4 +8,4 @@ func run() error {\n" +
+	" \tcfg := load()\n" +
+	"-\tif cfg == nil {\n" +
+	"+\tif cfg == nil || cfg.Empty() {\n" +
+	" \t\treturn errNoConfig\n" +
+	" \t}"
+
+// suggestionThread is a thread on lines start..end of main.go whose first
+// comment carries body.
+func suggestionThread(start, end int, body string) domain.ReviewThread {
+	return domain.ReviewThread{
+		ID: "PRRT_1", Path: "main.go", Line: end, StartLine: start,
+		Comments: []domain.Comment{{ID: "C1", Author: "alice", Body: body, DiffHunk: sampleHunk}},
+	}
+}
+
+func withHunk(t domain.ReviewThread, hunk string) domain.ReviewThread {
+	t.Comments[0].DiffHunk = hunk
+	return t
+}
+
+func TestExtractSuggestions(t *testing.T) {
+	tests := []struct {
+		name       string
+		thread     domain.ReviewThread
+		wantOrig   []string
+		wantRepl   [][]string
+		wantStatus string
+		wantLines  [2]int
+	}{
+		{
+			name:      "single line",
+			thread:    suggestionThread(0, 12, "Use slog:\n```suggestion\n\tslog.Info(\"config\", \"cfg\", cfg)\n```\n"),
+			wantOrig:  []string{"\tlog.Print(cfg)"},
+			wantRepl:  [][]string{{"\tslog.Info(\"config\", \"cfg\", cfg)"}},
+			wantLines: [2]int{12, 12},
+		},
+		{
+			name:      "multi-line with CRLF body and longer fence",
+			thread:    withHunk(suggestionThread(10, 11, "````suggestion\r\n\t\treturn nil\r\n\t}\r\n````"), sampleHunk11),
+			wantOrig:  []string{"\t\treturn errNoConfig", "\t}"},
+			wantRepl:  [][]string{{"\t\treturn nil", "\t}"}},
+			wantLines: [2]int{10, 11},
+		},
+		{
+			name:      "empty block deletes",
+			thread:    suggestionThread(0, 12, "```suggestion\n```"),
+			wantOrig:  []string{"\tlog.Print(cfg)"},
+			wantRepl:  [][]string{{}},
+			wantLines: [2]int{12, 12},
+		},
+		{
+			name:       "several blocks conflict",
+			thread:     suggestionThread(0, 12, "```suggestion\na\n```\nor\n```suggestion\nb\n```"),
+			wantOrig:   []string{"\tlog.Print(cfg)"},
+			wantRepl:   [][]string{{"a"}},
+			wantStatus: domain.SuggestionConflict,
+			wantLines:  [2]int{12, 12},
+		},
+		{
+			name:      "unclosed and non-suggestion fences ignored",
+			thread:    suggestionThread(0, 12, "```go\nx\n```\n```suggestion\ny"),
+			wantOrig:  nil,
+			wantRepl:  nil,
+			wantLines: [2]int{},
+		},
+		{
+			name: "outdated thread uses the original span",
+			thread: domain.ReviewThread{
+				ID: "PRRT_2", Path: "main.go", IsOutdated: true, OriginalStartLine: 10, OriginalLine: 11,
+				Comments: []domain.Comment{{ID: "C2", Body: "```suggestion\nz\n```", DiffHunk: sampleHunk11}},
+			},
+			wantOrig: []string{"\t\treturn errNoConfig", "\t}"},
+			wantRepl: [][]string{{"z"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractSuggestions(tt.thread)
+			if len(got) != len(tt.wantRepl) {
+				t.Fatalf("got %d suggestions, want %d", len(got), len(tt.wantRepl))
+			}
+			for i, s := range got {
+				if diff := cmp.Diff(tt.wantOrig, s.Original); diff != "" {
+					t.Errorf("Original mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tt.wantRepl[i], s.Replacement); diff != "" {
+					t.Errorf("Replacement mismatch (-want +got):\n%s", diff)
+				}
+				if s.Status != tt.wantStatus {
+					t.Errorf("Status = %q, want %q", s.Status, tt.wantStatus)
+				}
+				if lines := [2]int{s.StartLine, s.EndLine}; lines != tt.wantLines {
+					t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+				}
+			}
+		})
+	}
+}
+
+const sampleSource = "package main\n\nfunc run() error {\n\tcfg := load()\n\tif cfg == nil {\n\t\treturn errNoConfig\n\t}\n\tlog.Print(cfg)\n\treturn nil\n}\n"
+
+func TestPlanSuggestions(t *testing.T) {
+	suggestion := func(start, end int, orig, repl []string) domain.Suggestion {
+		return domain.Suggestion{CommentID: "C", Path: "main.go", StartLine: start, EndLine: end, Original: orig, Replacement: repl}
+	}
+	logLine := []string{"\tlog.Print(cfg)"}
+	slogLine := []string{"\tslog.Info(\"config\")"}
+
+	tests := []struct {
+		name       string
+		content    string // default sampleSource
+		in         domain.Suggestion
+		wantStatus string
+		wantLines  [2]int
+		wantReason string
+	}{
+		{name: "lines unchanged", in: suggestion(8, 8, logLine, slogLine), wantStatus: domain.SuggestionApplicable, wantLines: [2]int{8, 8}},
+		{name: "lines moved", in: suggestion(12, 12, logLine, slogLine), wantStatus: domain.SuggestionApplicable, wantLines: [2]int{8, 8}, wantReason: "moved from line 12"},
+		{name: "outdated located by content", in: suggestion(0, 0, logLine, slogLine), wantStatus: domain.SuggestionApplicable, wantLines: [2]int{8, 8}, wantReason: "outdated comment located by content"},
+		{name: "already applied", in: suggestion(8, 8, []string{"\tlog.Printf(cfg)"}, logLine), wantStatus: domain.SuggestionAlreadyApplied, wantLines: [2]int{8, 8}},
+		{name: "lines changed", in: suggestion(8, 8, []string{"\tfmt.Println(cfg)"}, slogLine), wantStatus: domain.SuggestionConflict, wantLines: [2]int{8, 8}, wantReason: "commented lines changed since the comment"},
+		{
+			name: "changed and ambiguous elsewhere", content: "x\ny\nx\n", in: suggestion(2, 2, []string{"x"}, []string{"z"}),
+			wantStatus: domain.SuggestionConflict, wantLines: [2]int{2, 2}, wantReason: "commented lines changed in place and occur 2 times elsewhere",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := tt.content
+			if content == "" {
+				content = sampleSource
+			}
+			got := []domain.Suggestion{tt.in}
+			PlanSuggestions([]byte(content), got)
+			s := got[0]
+			if s.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q (reason %q)", s.Status, tt.wantStatus, s.Reason)
+			}
+			if lines := [2]int{s.StartLine, s.EndLine}; lines != tt.wantLines {
+				t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+			}
+			if s.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", s.Reason, tt.wantReason)
+			}
+			if (s.Diff != "") != (tt.wantStatus == domain.SuggestionApplicable) {
+				t.Errorf("Diff = %q, want one only for applicable suggestions", s.Diff)
+			}
+		})
+	}
+}
+
+func TestPlanSuggestions_Overlap(t *testing.T) {
+	got := []domain.Suggestion{
+		{CommentID: "C1", StartLine: 5, EndLine: 7, Original: []string{"\tif cfg == nil {", "\t\treturn errNoConfig", "\t}"}, Replacement: []string{"\tmust(cfg)"}},
+		{CommentID: "C2", StartLine: 6, EndLine: 6, Original: []string{"\t\treturn errNoConfig"}, Replacement: []string{"\t\treturn nil"}},
+	}
+	PlanSuggestions([]byte(sampleSource), got)
+	if got[0].Status != domain.SuggestionApplicable {
+		t.Errorf("first Status = %q, want applicable", got[0].Status)
+	}
+	if got[1].Status != domain.SuggestionConflict || got[1].Reason != "overlaps the suggestion in comment C1" {
+		t.Errorf("second = %q (%q), want an overlap conflict", got[1].Status, got[1].Reason)
+	}
+}
+
+func TestSuggestionDiff(t *testing.T) {
+	got := []domain.Suggestion{{
+		Path: "main.go", StartLine: 8, EndLine: 8,
+		Original: []string{"\tlog.Print(cfg)"}, Replacement: []string{"\tslog.Info(\"config\")", "\t_ = cfg"},
+	}}
+	PlanSuggestions([]byte(sampleSource), got)
+
+	want := "--- a/main.go\n+++ b/main.go\n" +
+		"@@ -5,6 +5,7 @@\n" +
+		" \tif cfg == nil {\n" +
+		" \t\treturn errNoConfig\n" +
+		" \t}\n" +
+		"-\tlog.Print(cfg)\n" +
+		"+\tslog.Info(\"config\")\n" +
+		"+\t_ = cfg\n" +
+		" \treturn nil\n" +
+		" }\n"
+	if diff := cmp.Diff(want, got[0].Diff); diff != "" {
+		t.Errorf("Diff mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplySuggestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "LF with final newline",
+			content: "a\nb\nc\nd\n",
+			want:    "a\nB\nc\n",
+		},
+		{
+			name:    "CRLF kept",
+			content: "a\r\nb\r\nc\r\nd\r\n",
+			want:    "a\r\nB\r\nc\r\n",
+		},
+		{
+			name:    "no final newline",
+			content: "a\nb\nc\nd",
+			want:    "a\nB\nc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := []domain.Suggestion{
+				{StartLine: 2, EndLine: 2, Original: []string{"b"}, Replacement: []string{"B"}},
+				{StartLine: 4, EndLine: 4, Original: []string{"d"}, Replacement: []string{}},
+				{StartLine: 1, EndLine: 1, Original: []string{"x"}, Replacement: []string{"X"}},
+			}
+			PlanSuggestions([]byte(tt.content), suggestions)
+			if suggestions[2].Status != domain.SuggestionConflict {
+				t.Errorf("stale suggestion Status = %q, want conflict", suggestions[2].Status)
+			}
+			got := string(ApplySuggestions([]byte(tt.content), suggestions))
+			if got != tt.want {
+				t.Errorf("ApplySuggestions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
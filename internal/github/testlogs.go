@@ -0,0 +1,430 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// maxFailedTests caps the number of failed tests extracted from one job log.
+const maxFailedTests = 50
+
+// maxTestOutputLines caps the output kept for each failed test.
+const maxTestOutputLines = 20
+
+// testLogParsers recognize failing tests for one framework each. A log is
+// run through all of them, since one job can run several test suites.
+var testLogParsers = []func(lines []string) []domain.FailedTest{
+	parseGoTests,
+	parsePytest,
+	parseJest,
+	parseCargoTests,
+	parseJUnit,
+}
+
+// ExtractFailedTests returns the failing tests recognized in a job log from
+// go test, pytest, jest, cargo test, and Maven/Gradle JUnit output. Lines are
+// cleaned of ANSI codes and timestamps first. The result is capped at
+// maxFailedTests; nil means no framework output was recognized.
+func ExtractFailedTests(log string) []domain.FailedTest {
+	lines := strings.Split(log, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(cleanLine(line), "\r")
+	}
+
+	var tests []domain.FailedTest
+	for _, parse := range testLogParsers {
+		tests = append(tests, parse(lines)...)
+	}
+	if len(tests) > maxFailedTests {
+		tests = tests[:maxFailedTests]
+	}
+	return tests
+}
+
+// --- go test ---
+
+var (
+	goRunRegexp      = regexp.MustCompile(`^=== (?:RUN|CONT)\s+(\S+)`)
+	goFailRegexp     = regexp.MustCompile(`^(\s*)--- FAIL: (\S+) \(`)
+	goPkgRegexp      = regexp.MustCompile(`^(?:FAIL|ok)\s+(\S+)\s+(?:\(|\[|\d)`)
+	goFileLineRegexp = regexp.MustCompile(`^\s*([\w.-]+\.go):(\d+): ?(.*)$`)
+)
+
+// parseGoTests reads "--- FAIL: TestName" blocks. Output is taken from the
+// indented lines after the marker, plus the lines logged between
+// "=== RUN TestName" and the marker under -v. The package comes from the
+// "FAIL <pkg>" line that closes the package. Parents of failing subtests are
+// dropped so only the leaves are reported.
+func parseGoTests(lines []string) []domain.FailedTest {
+	var tests []domain.FailedTest
+	unassigned := 0 // first test whose package line has not been seen
+	runOutput := make(map[string][]string)
+	current := ""
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := goRunRegexp.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			continue
+		}
+		if m := goFailRegexp.FindStringSubmatch(line); m != nil {
+			indent := len(m[1])
+			output := runOutput[m[2]]
+			for i+1 < len(lines) && indentOf(lines[i+1]) > indent && !goFailRegexp.MatchString(lines[i+1]) &&
+				!strings.HasPrefix(strings.TrimSpace(lines[i+1]), "--- ") {
+				i++
+				output = append(output, lines[i])
+			}
+			tests = append(tests, goFailedTest(m[2], output))
+			continue
+		}
+		if m := goPkgRegexp.FindStringSubmatch(line); m != nil {
+			for j := unassigned; j < len(tests); j++ {
+				tests[j].Package = m[1]
+			}
+			unassigned = len(tests)
+			current = ""
+			continue
+		}
+		if current != "" && indentOf(line) > 0 {
+			runOutput[current] = append(runOutput[current], line)
+		}
+	}
+
+	var leaves []domain.FailedTest
+	for _, t := range tests {
+		if !hasFailingSubtest(tests, t) {
+			leaves = append(leaves, t)
+		}
+	}
+	return leaves
+}
+
+func goFailedTest(name string, output []string) domain.FailedTest {
+	t := domain.FailedTest{Name: name, Framework: "go", Output: capOutput(output)}
+	for i, line := range output {
+		m := goFileLineRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		t.File, t.Line = m[1], atoiOrZero(m[2])
+		t.Message = strings.TrimSpace(m[3])
+		if t.Message == "" {
+			// Multi-line messages (testify) start on the next line.
+			t.Message = firstNonBlank(output[i+1:])
+		}
+		return t
+	}
+	t.Message = firstNonBlank(output)
+	return t
+}
+
+func hasFailingSubtest(tests []domain.FailedTest, parent domain.FailedTest) bool {
+	for _, t := range tests {
+		if t.Package == parent.Package && strings.HasPrefix(t.Name, parent.Name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// --- pytest ---
+
+var (
+	pytestSummaryRegexp  = regexp.MustCompile(`^(?:FAILED|ERROR) (\S+?\.py)::(.+?)(?: - (.*))?$`)
+	pytestSectionRegexp  = regexp.MustCompile(`^_{3,} (.+?) _{3,}$`)
+	pytestLocationRegexp = regexp.MustCompile(`^(\S+\.py):(\d+): \w`)
+)
+
+// parsePytest reads the "short test summary info" lines
+// ("FAILED path::Class::test - message") and enriches each with its
+// traceback section from the FAILURES block, which carries the line number.
+func parsePytest(lines []string) []domain.FailedTest {
+	sections := make(map[string][]string)
+	for i := 0; i < len(lines); i++ {
+		m := pytestSectionRegexp.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		var body []string
+		for i+1 < len(lines) && !pytestSectionRegexp.MatchString(lines[i+1]) && !strings.HasPrefix(lines[i+1], "===") {
+			i++
+			body = append(body, lines[i])
+		}
+		sections[m[1]] = trimBlank(body)
+	}
+
+	var tests []domain.FailedTest
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		m := pytestSummaryRegexp.FindStringSubmatch(line)
+		if m == nil || seen[m[1]+"::"+m[2]] {
+			continue
+		}
+		seen[m[1]+"::"+m[2]] = true
+
+		t := domain.FailedTest{Name: m[2], Package: m[1], File: m[1], Message: m[3], Framework: "pytest"}
+		body := sections[strings.ReplaceAll(m[2], "::", ".")]
+		for _, b := range body {
+			if loc := pytestLocationRegexp.FindStringSubmatch(b); loc != nil && loc[1] == m[1] {
+				t.Line = atoiOrZero(loc[2])
+				break
+			}
+		}
+		if t.Message == "" {
+			for _, b := range body {
+				if strings.HasPrefix(b, "E ") {
+					t.Message = strings.TrimSpace(b[1:])
+					break
+				}
+			}
+		}
+		t.Output = capOutput(body)
+		tests = append(tests, t)
+	}
+	return tests
+}
+
+// --- jest ---
+
+var (
+	jestSuiteRegexp = regexp.MustCompile(`^\s*(FAIL|PASS)\s+(\S+)`)
+	jestTestRegexp  = regexp.MustCompile(`^\s*● (.+)$`)
+	jestFrameRegexp = regexp.MustCompile(`\bat (?:.*\()?([^\s()]+):(\d+):\d+\)?$`)
+)
+
+// parseJest reads "● Suite › test" blocks under a "FAIL <file>" header. The
+// line comes from the first stack frame in the test file. Jest repeats the
+// blocks in its closing summary, so entries are deduplicated.
+func parseJest(lines []string) []domain.FailedTest {
+	var tests []domain.FailedTest
+	seen := make(map[string]bool)
+	suite := ""
+
+	for i := 0; i < len(lines); i++ {
+		if m := jestSuiteRegexp.FindStringSubmatch(lines[i]); m != nil {
+			suite = m[2]
+			continue
+		}
+		m := jestTestRegexp.FindStringSubmatch(lines[i])
+		if m == nil || suite == "" {
+			continue
+		}
+		var body []string
+		for i+1 < len(lines) && !jestTestRegexp.MatchString(lines[i+1]) && !jestSuiteRegexp.MatchString(lines[i+1]) &&
+			!strings.HasPrefix(lines[i+1], "Test Suites:") && !strings.HasPrefix(lines[i+1], "Summary of all failing tests") {
+			i++
+			body = append(body, lines[i])
+		}
+		name := strings.TrimSpace(m[1])
+		if seen[suite+"\x00"+name] {
+			continue
+		}
+		seen[suite+"\x00"+name] = true
+
+		body = trimBlank(body)
+		t := domain.FailedTest{Name: name, Package: suite, File: suite, Message: firstNonBlank(body), Framework: "jest", Output: capOutput(body)}
+		for _, b := range body {
+			if f := jestFrameRegexp.FindStringSubmatch(b); f != nil && strings.HasSuffix(f[1], suite) {
+				t.Line = atoiOrZero(f[2])
+				break
+			}
+		}
+		tests = append(tests, t)
+	}
+	return tests
+}
+
+// --- cargo test ---
+
+var (
+	cargoRunningRegexp = regexp.MustCompile(`^\s*Running .*\(target/\S*deps/([\w-]+?)-[0-9a-f]+(?:\.exe)?\)`)
+	cargoFailedRegexp  = regexp.MustCompile(`^test (\S+) \.\.\. FAILED$`)
+	cargoStdoutRegexp  = regexp.MustCompile(`^---- (\S+) stdout ----$`)
+	cargoPanicRegexp   = regexp.MustCompile(`panicked at (?:'(.*)', )?([^\s:]+):(\d+):\d+:?$`)
+)
+
+// parseCargoTests reads "test name ... FAILED" lines and enriches each with
+// its "---- name stdout ----" block, whose panic line carries the location.
+// The crate is the test binary named in the preceding "Running" line.
+func parseCargoTests(lines []string) []domain.FailedTest {
+	var tests []domain.FailedTest
+	index := make(map[string]int)
+	crate := ""
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := cargoRunningRegexp.FindStringSubmatch(line); m != nil {
+			crate = m[1]
+			continue
+		}
+		if m := cargoFailedRegexp.FindStringSubmatch(line); m != nil {
+			if _, ok := index[crate+"::"+m[1]]; !ok {
+				index[crate+"::"+m[1]] = len(tests)
+				tests = append(tests, domain.FailedTest{Name: m[1], Package: crate, Framework: "cargo"})
+			}
+			continue
+		}
+		m := cargoStdoutRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		var body []string
+		for i+1 < len(lines) && !cargoStdoutRegexp.MatchString(lines[i+1]) &&
+			strings.TrimSpace(lines[i+1]) != "failures:" && !strings.HasPrefix(lines[i+1], "test result:") {
+			i++
+			body = append(body, lines[i])
+		}
+		j, ok := index[crate+"::"+m[1]]
+		if !ok {
+			continue
+		}
+		body = trimBlank(body)
+		t := &tests[j]
+		t.Output = capOutput(body)
+		for k, b := range body {
+			p := cargoPanicRegexp.FindStringSubmatch(b)
+			if p == nil {
+				continue
+			}
+			t.File, t.Line = p[2], atoiOrZero(p[3])
+			t.Message = p[1]
+			if t.Message == "" {
+				// Rust 1.73+ prints the panic message on the following line.
+				t.Message = firstNonBlank(body[k+1:])
+			}
+			break
+		}
+	}
+	return tests
+}
+
+// --- JUnit (Maven Surefire, Gradle) ---
+
+var (
+	// "[ERROR] testDivide(com.example.MathTest)  Time elapsed: 0.01 s  <<< FAILURE!" (Surefire 2)
+	surefireOldRegexp = regexp.MustCompile(`^\[ERROR\] (\w+)\(([\w.$]+)\)\s+Time elapsed:.*<<< (?:FAILURE|ERROR)!`)
+	// "[ERROR] com.example.MathTest.testDivide -- Time elapsed: 0.01 s <<< FAILURE!" (Surefire 3)
+	surefireNewRegexp = regexp.MustCompile(`^\[ERROR\] ([\w.$]+)\.([\w$]+) -- Time elapsed:.*<<< (?:FAILURE|ERROR)!`)
+	// "com.example.MathTest > testDivide() FAILED" (Gradle)
+	gradleFailRegexp  = regexp.MustCompile(`^([\w.$]+) > (.+?) FAILED$`)
+	javaFrameRegexp   = regexp.MustCompile(`\bat (?:([\w.$<>]+)\()?(\w+\.(?:java|kt|groovy|scala)):(\d+)\)?$`)
+	javaExcLineRegexp = regexp.MustCompile(`^\s*[\w.$]+(?:Exception|Error|Failure)\b`)
+)
+
+// parseJUnit reads Maven Surefire and Gradle failure headers. The message is
+// the exception line that follows; the location is the first stack frame in
+// the test class, falling back to the first frame with a source file.
+func parseJUnit(lines []string) []domain.FailedTest {
+	var tests []domain.FailedTest
+	for i := 0; i < len(lines); i++ {
+		var class, method string
+		if m := surefireOldRegexp.FindStringSubmatch(lines[i]); m != nil {
+			class, method = m[2], m[1]
+		} else if m := surefireNewRegexp.FindStringSubmatch(lines[i]); m != nil {
+			class, method = m[1], m[2]
+		} else if m := gradleFailRegexp.FindStringSubmatch(lines[i]); m != nil {
+			class, method = m[1], m[2]
+		} else {
+			continue
+		}
+
+		var body []string
+		for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" && !strings.HasPrefix(lines[i+1], "[") &&
+			!gradleFailRegexp.MatchString(lines[i+1]) {
+			i++
+			body = append(body, lines[i])
+		}
+
+		t := domain.FailedTest{Name: method, Package: class, Framework: "junit", Output: capOutput(body)}
+		for _, b := range body {
+			if javaExcLineRegexp.MatchString(b) {
+				t.Message = strings.TrimSpace(b)
+				break
+			}
+		}
+		if t.Message == "" {
+			t.Message = firstNonBlank(body)
+		}
+		simple := class[strings.LastIndex(class, ".")+1:]
+		for _, b := range body {
+			f := javaFrameRegexp.FindStringSubmatch(b)
+			if f == nil {
+				continue
+			}
+			inClass := strings.HasPrefix(f[1], class+".") || strings.TrimSuffix(f[2], ".java") == simple
+			if t.File == "" || inClass {
+				t.File, t.Line = f[2], atoiOrZero(f[3])
+			}
+			if inClass {
+				break
+			}
+		}
+		tests = append(tests, t)
+	}
+	return tests
+}
+
+// --- helpers ---
+
+// capOutput drops the common indentation and keeps at most
+// maxTestOutputLines lines, marking a cut with "...".
+func capOutput(lines []string) string {
+	lines = trimBlank(lines)
+	if len(lines) == 0 {
+		return ""
+	}
+	common := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if n := indentOf(l); common < 0 || n < common {
+			common = n
+		}
+	}
+	out := make([]string, 0, min(len(lines), maxTestOutputLines+1))
+	for i, l := range lines {
+		if i == maxTestOutputLines {
+			out = append(out, "...")
+			break
+		}
+		if len(l) >= common {
+			l = l[common:]
+		}
+		out = append(out, strings.TrimRight(l, " \t"))
+	}
+	return strings.Join(out, "\n")
+}
+
+// trimBlank removes leading and trailing blank lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func firstNonBlank(lines []string) string {
+	for _, l := range lines {
+		if s := strings.TrimSpace(l); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// ignoreOutput compares everything but the captured output.
+var ignoreOutput = cmpopts.IgnoreFields(domain.FailedTest{}, "Output")
+
+func TestExtractFailedTests_GoFixture(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/rest/job_log.txt")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	got := ExtractFailedTests(string(data))
+	want := []domain.FailedTest{
+		{Name: "TestParseConfig", Package: "github.com/owner/repo/internal/handler", File: "config_test.go", Line: 42, Message: `expected "production", got "development"`, Framework: "go"},
+		{Name: "TestValidateInput", Package: "github.com/owner/repo/internal/handler", File: "validate_test.go", Line: 15, Message: "validation should have returned error for empty input", Framework: "go"},
+	}
+	if diff := cmp.Diff(want, got, ignoreOutput); diff != "" {
+		t.Errorf("failed tests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractFailedTests_GoVerboseSubtests(t *testing.T) {
+	log := strings.Join([]string{
+		"=== RUN   TestSum",
+		"=== RUN   TestSum/negative",
+		"    sum_test.go:20: ",
+		"        Error: Not equal",
+		"--- FAIL: TestSum (0.00s)",
+		"    --- FAIL: TestSum/negative (0.00s)",
+		"=== RUN   TestOther",
+		"--- PASS: TestOther (0.00s)",
+		"FAIL",
+		"FAIL\texample.com/calc\t0.004s",
+		"ok  \texample.com/other\t0.002s",
+	}, "\n")
+
+	got := ExtractFailedTests(log)
+	want := []domain.FailedTest{
+		{Name: "TestSum/negative", Package: "example.com/calc", File: "sum_test.go", Line: 20, Message: "Error: Not equal", Framework: "go",
+			Output: "sum_test.go:20:\n    Error: Not equal"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("failed tests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractFailedTests_Pytest(t *testing.T) {
+	log := strings.Join([]string{
+		"=================================== FAILURES ===================================",
+		"____________________________ TestMath.test_divide _____________________________",
+		"",
+		"    def test_divide(self):",
+		">       assert divide(1, 0) == 0",
+		"E       ZeroDivisionError: division by zero",
+		"",
+		"tests/test_math.py:12: ZeroDivisionError",
+		"=========================== short test summary info ============================",
+		"FAILED tests/test_math.py::TestMath::test_divide - ZeroDivisionError: division by zero",
+		"========================= 1 failed, 3 passed in 0.12s ==========================",
+	}, "\n")
+
+	got := ExtractFailedTests(log)
+	want := []domain.FailedTest{
+		{Name: "TestMath::test_divide", Package: "tests/test_math.py", File: "tests/test_math.py", Line: 12, Message: "ZeroDivisionError: division by zero", Framework: "pytest"},
+	}
+	if diff := cmp.Diff(want, got, ignoreOutput); diff != "" {
+		t.Errorf("failed tests mismatch (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(got[0].Output, "E       ZeroDivisionError") {
+		t.Errorf("output missing traceback:\n%s", got[0].Output)
+	}
+}
+
+func TestExtractFailedTests_Jest(t *testing.T) {
+	block := []string{
+		"  ● Math › divides by zero",
+		"",
+		"    expect(received).toBe(expected) // Object.is equality",
+		"",
+		"      at Object.<anonymous> (src/math.test.js:11:26)",
+		"",
+	}
+	lines := append([]string{"FAIL src/math.test.js"}, block...)
+	lines = append(lines, "Summary of all failing tests", "FAIL src/math.test.js")
+	lines = append(lines, block...)
+	lines = append(lines, "Test Suites: 1 failed, 1 total")
+
+	got := ExtractFailedTests(strings.Join(lines, "\n"))
+	want := []domain.FailedTest{
+		{Name: "Math › divides by zero", Package: "src/math.test.js", File: "src/math.test.js", Line: 11, Message: "expect(received).toBe(expected) // Object.is equality", Framework: "jest"},
+	}
+	if diff := cmp.Diff(want, got, ignoreOutput); diff != "" {
+		t.Errorf("failed tests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractFailedTests_Cargo(t *testing.T) {
+	log := strings.Join([]string{
+		"     Running unittests src/lib.rs (target/debug/deps/mycrate-1a2b3c4d)",
+		"",
+		"running 2 tests",
+		"test tests::it_works ... ok",
+		"test tests::it_fails ... FAILED",
+		"",
+		"failures:",
+		"",
+		"---- tests::it_fails stdout ----",
+		"thread 'tests::it_fails' panicked at src/lib.rs:10:9:",
+		"assertion `left == right` failed",
+		"  left: 4",
+		" right: 5",
+		"",
+		"failures:",
+		"    tests::it_fails",
+		"",
+		"test result: FAILED. 1 passed; 1 failed; 0 ignored",
+	}, "\n")
+
+	got := ExtractFailedTests(log)
+	want := []domain.FailedTest{
+		{Name: "tests::it_fails", Package: "mycrate", File: "src/lib.rs", Line: 10, Message: "assertion `left == right` failed", Framework: "cargo"},
+	}
+	if diff := cmp.Diff(want, got, ignoreOutput); diff != "" {
+		t.Errorf("failed tests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractFailedTests_JUnit(t *testing.T) {
+	log := strings.Join([]string{
+		"[INFO] Running com.example.MathTest",
+		"[ERROR] Tests run: 2, Failures: 1, Errors: 0, Skipped: 0, Time elapsed: 0.05 s <<< FAILURE! -- in com.example.MathTest",
+		"[ERROR] com.example.MathTest.testDivide -- Time elapsed: 0.01 s <<< FAILURE!",
+		"org.opentest4j.AssertionFailedError: expected: <1> but was: <2>",
+		"\tat org.junit.jupiter.api.AssertionUtils.fail(AssertionUtils.java:151)",
+		"\tat com.example.MathTest.testDivide(MathTest.java:12)",
+		"",
+		"MathTest > testAdd() FAILED",
+		"    org.opentest4j.AssertionFailedError at MathTest.java:20",
+	}, "\n")
+
+	got := ExtractFailedTests(log)
+	want := []domain.FailedTest{
+		{Name: "testDivide", Package: "com.example.MathTest", File: "MathTest.java", Line: 12, Message: "org.opentest4j.AssertionFailedError: expected: <1> but was: <2>", Framework: "junit"},
+		{Name: "testAdd()", Package: "MathTest", File: "MathTest.java", Line: 20, Message: "org.opentest4j.AssertionFailedError at MathTest.java:20", Framework: "junit"},
+	}
+	if diff := cmp.Diff(want, got, ignoreOutput); diff != "" {
+		t.Errorf("failed tests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractFailedTests_NoFramework(t *testing.T) {
+	if got := ExtractFailedTests("##[error]Process completed with exit code 1.\nerror: build failed"); got != nil {
+		t.Errorf("ExtractFailedTests = %+v, want nil", got)
+	}
+}
+
+func TestCapOutput(t *testing.T) {
+	lines := make([]string, 0, maxTestOutputLines+5)
+	for range maxTestOutputLines + 5 {
+		lines = append(lines, "    line")
+	}
+	got := strings.Split(capOutput(lines), "\n")
+	if len(got) != maxTestOutputLines+1 || got[0] != "line" || got[len(got)-1] != "..." {
+		t.Errorf("capOutput: %d lines, first %q, last %q", len(got), got[0], got[len(got)-1])
+	}
+}
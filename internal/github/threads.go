@@ -23,10 +23,14 @@ query($owner: String!, $repo: String!, $pr: Int!, $cursor: String) {
           path
           line
           startLine
+          originalLine
+          originalStartLine
+          diffSide
           viewerCanResolve
           viewerCanUnresolve
           viewerCanReply
           comments(first: 50) {
+            totalCount
             nodes {
               id
               databaseId
@@ -37,6 +41,10 @@ query($owner: String!, $repo: String!, $pr: Int!, $cursor: String) {
               createdAt
               url
             }
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
           }
         }
         pageInfo {
@@ -49,6 +57,38 @@ query($owner: String!, $repo: String!, $pr: Int!, $cursor: String) {
 }
 `
 
+// threadCommentsQuery fetches further pages of one thread's comments when the
+// first 50 returned by reviewThreadsQuery are not all of them.
+const threadCommentsQuery = `
+query($id: ID!, $cursor: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: 100, after: $cursor) {
+        totalCount
+        nodes {
+          id
+          databaseId
+          body
+          author { __typename login }
+          path
+          diffHunk
+          createdAt
+          url
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}
+`
+
+// maxThreadComments caps how many comments are loaded per thread. Threads
+// beyond it are marked truncated instead of being silently cut short.
+const maxThreadComments = 1000
+
 type threadsResponse struct {
 	Repository struct {
 		PullRequest *struct {
@@ -65,18 +105,37 @@ type threadsResponse struct {
 }
 
 type threadNode struct {
-	ID                 string `json:"id"`
-	IsResolved         bool   `json:"isResolved"`
-	IsOutdated         bool   `json:"isOutdated"`
-	Path               string `json:"path"`
-	Line               int    `json:"line"`
-	StartLine          int    `json:"startLine"`
-	ViewerCanResolve   bool   `json:"viewerCanResolve"`
-	ViewerCanUnresolve bool   `json:"viewerCanUnresolve"`
-	ViewerCanReply     bool   `json:"viewerCanReply"`
-	Comments           struct {
-		Nodes []commentNode `json:"nodes"`
-	} `json:"comments"`
+	ID                 string            `json:"id"`
+	IsResolved         bool              `json:"isResolved"`
+	IsOutdated         bool              `json:"isOutdated"`
+	Path               string            `json:"path"`
+	Line               int               `json:"line"`
+	StartLine          int               `json:"startLine"`
+	OriginalLine       int               `json:"originalLine"`
+	OriginalStartLine  int               `json:"originalStartLine"`
+	DiffSide           string            `json:"diffSide"`
+	ViewerCanResolve   bool              `json:"viewerCanResolve"`
+	ViewerCanUnresolve bool              `json:"viewerCanUnresolve"`
+	ViewerCanReply     bool              `json:"viewerCanReply"`
+	Comments           commentConnection `json:"comments"`
+
+	// truncated is set when comment pagination stopped at a cap.
+	truncated bool
+}
+
+type commentConnection struct {
+	TotalCount int           `json:"totalCount"`
+	Nodes      []commentNode `json:"nodes"`
+	PageInfo   struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+type threadCommentsResponse struct {
+	Node *struct {
+		Comments commentConnection `json:"comments"`
+	} `json:"node"`
 }
 
 type commentNode struct {
@@ -102,46 +161,9 @@ func (c *Client) FetchThreads(ctx context.Context, owner, repo string, pr int) (
 	start := time.Now()
 	slog.Debug("fetching review threads", "owner", owner, "repo", repo, "pr", pr)
 
-	var allNodes []threadNode
-	var totalCount int
-	var cursor *string
-	page := 1
-
-	for {
-		vars := map[string]interface{}{
-			"owner": owner,
-			"repo":  repo,
-			"pr":    pr,
-		}
-		if cursor != nil {
-			vars["cursor"] = *cursor
-		}
-
-		slog.Debug("fetching threads page", "page", page)
-
-		var resp threadsResponse
-		if err := doWithRetry(func() error {
-			return c.gql.DoWithContext(ctx, reviewThreadsQuery, vars, &resp)
-		}); err != nil {
-			return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
-		}
-
-		if resp.Repository.PullRequest == nil {
-			return nil, &NotFoundError{
-				Resource: "pull request",
-				Detail:   fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo),
-			}
-		}
-
-		rt := resp.Repository.PullRequest.ReviewThreads
-		totalCount = rt.TotalCount
-		allNodes = append(allNodes, rt.Nodes...)
-
-		if !rt.PageInfo.HasNextPage {
-			break
-		}
-		cursor = &rt.PageInfo.EndCursor
-		page++
+	allNodes, totalCount, err := c.fetchThreadNodes(ctx, owner, repo, pr, func(n threadNode) bool { return !n.IsResolved })
+	if err != nil {
+		return nil, err
 	}
 
 	slog.Debug("fetched review threads", "owner", owner, "repo", repo, "pr", pr,
@@ -159,9 +181,44 @@ func (c *Client) FetchResolvedThreads(ctx context.Context, owner, repo string, p
 	start := time.Now()
 	slog.Debug("fetching resolved threads", "owner", owner, "repo", repo, "pr", pr)
 
+	allNodes, totalCount, err := c.fetchThreadNodes(ctx, owner, repo, pr, func(n threadNode) bool { return n.IsResolved })
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("fetched resolved threads", "owner", owner, "repo", repo, "pr", pr,
+		"total", totalCount, "fetched", len(allNodes), "duration", time.Since(start))
+
+	return mapThreadsWithFilter(pr, totalCount, allNodes, true)
+}
+
+// FetchAllThreads retrieves every review thread for a PR, resolved or not.
+// Used by apply to validate a whole plan against a single fetch.
+func (c *Client) FetchAllThreads(ctx context.Context, owner, repo string, pr int) (*domain.CommentsResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	slog.Debug("fetching all threads", "owner", owner, "repo", repo, "pr", pr)
+
+	allNodes, totalCount, err := c.fetchThreadNodes(ctx, owner, repo, pr, func(threadNode) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("fetched all threads", "owner", owner, "repo", repo, "pr", pr,
+		"total", totalCount, "fetched", len(allNodes), "duration", time.Since(start))
+
+	return mapThreads(pr, totalCount, allNodes, func(threadNode) bool { return true })
+}
+
+// fetchThreadNodes pages through every review thread of a PR and loads the
+// remaining comments of the threads selected by full.
+func (c *Client) fetchThreadNodes(ctx context.Context, owner, repo string, pr int, full func(threadNode) bool) ([]threadNode, int, error) {
 	var allNodes []threadNode
 	var totalCount int
 	var cursor *string
+	page := 1
 
 	for {
 		vars := map[string]interface{}{
@@ -173,15 +230,17 @@ func (c *Client) FetchResolvedThreads(ctx context.Context, owner, repo string, p
 			vars["cursor"] = *cursor
 		}
 
+		slog.Debug("fetching threads page", "page", page)
+
 		var resp threadsResponse
 		if err := doWithRetry(func() error {
 			return c.gql.DoWithContext(ctx, reviewThreadsQuery, vars, &resp)
 		}); err != nil {
-			return nil, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
+			return nil, 0, classifyWithContext(err, "pull request", fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo))
 		}
 
 		if resp.Repository.PullRequest == nil {
-			return nil, &NotFoundError{
+			return nil, 0, &NotFoundError{
 				Resource: "pull request",
 				Detail:   fmt.Sprintf("PR #%d in %s/%s", pr, owner, repo),
 			}
@@ -195,12 +254,61 @@ func (c *Client) FetchResolvedThreads(ctx context.Context, owner, repo string, p
 			break
 		}
 		cursor = &rt.PageInfo.EndCursor
+		page++
 	}
 
-	slog.Debug("fetched resolved threads", "owner", owner, "repo", repo, "pr", pr,
-		"total", totalCount, "fetched", len(allNodes), "duration", time.Since(start))
+	for i := range allNodes {
+		if full(allNodes[i]) {
+			if err := c.fetchRemainingComments(ctx, &allNodes[i], maxThreadComments); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return allNodes, totalCount, nil
+}
 
-	return mapThreadsWithFilter(pr, totalCount, allNodes, true)
+// fetchRemainingComments pages through the rest of a thread's comments when
+// the threads query returned only the first page. A limit of 0 means no cap.
+func (c *Client) fetchRemainingComments(ctx context.Context, n *threadNode, limit int) error {
+	if !n.Comments.PageInfo.HasNextPage {
+		return nil
+	}
+	slog.Debug("fetching remaining thread comments", "thread", n.ID,
+		"fetched", len(n.Comments.Nodes), "total", n.Comments.TotalCount)
+
+	for more := true; more; {
+		vars := map[string]interface{}{
+			"id":     n.ID,
+			"cursor": n.Comments.PageInfo.EndCursor,
+		}
+		var resp threadCommentsResponse
+		if err := doWithRetry(func() error {
+			return c.gql.DoWithContext(ctx, threadCommentsQuery, vars, &resp)
+		}); err != nil {
+			return fmt.Errorf("fetch comments for thread %s: %w", n.ID, classifyError(err))
+		}
+		if resp.Node == nil {
+			return &NotFoundError{Resource: "review thread", Detail: n.ID}
+		}
+		more = appendCommentPage(n, resp.Node.Comments, limit)
+	}
+	return nil
+}
+
+// appendCommentPage adds a page of comments to n and reports whether another
+// page should be fetched. Once limit comments are loaded, the rest are dropped
+// and the thread is marked truncated.
+func appendCommentPage(n *threadNode, page commentConnection, limit int) bool {
+	n.Comments.Nodes = append(n.Comments.Nodes, page.Nodes...)
+	n.Comments.PageInfo = page.PageInfo
+	if limit > 0 && len(n.Comments.Nodes) >= limit {
+		if len(n.Comments.Nodes) > limit || page.PageInfo.HasNextPage {
+			n.truncated = true
+		}
+		n.Comments.Nodes = n.Comments.Nodes[:limit]
+		return false
+	}
+	return page.PageInfo.HasNextPage
 }
 
 // mapThreadsToResult converts GraphQL thread nodes to a domain CommentsResult,
@@ -213,6 +321,12 @@ func mapThreadsToResult(pr, totalCount int, nodes []threadNode) (*domain.Comment
 // When keepResolved is false, only unresolved threads are included (default for comments).
 // When keepResolved is true, only resolved threads are included (for --all --unresolve).
 func mapThreadsWithFilter(pr, totalCount int, nodes []threadNode, keepResolved bool) (*domain.CommentsResult, error) {
+	return mapThreads(pr, totalCount, nodes, func(n threadNode) bool { return n.IsResolved == keepResolved })
+}
+
+// mapThreads converts the GraphQL thread nodes selected by keep to a domain
+// CommentsResult. Resolved and unresolved counts cover every node.
+func mapThreads(pr, totalCount int, nodes []threadNode, keep func(threadNode) bool) (*domain.CommentsResult, error) {
 	var resolved, unresolved int
 	var threads []domain.ReviewThread
 
@@ -223,7 +337,7 @@ func mapThreadsWithFilter(pr, totalCount int, nodes []threadNode, keepResolved b
 			unresolved++
 		}
 
-		if n.IsResolved != keepResolved {
+		if !keep(n) {
 			continue
 		}
 
@@ -237,12 +351,16 @@ func mapThreadsWithFilter(pr, totalCount int, nodes []threadNode, keepResolved b
 			Path:               n.Path,
 			Line:               n.Line,
 			StartLine:          n.StartLine,
+			OriginalLine:       n.OriginalLine,
+			OriginalStartLine:  n.OriginalStartLine,
+			DiffSide:           n.DiffSide,
 			IsResolved:         n.IsResolved,
 			IsOutdated:         n.IsOutdated,
 			ViewerCanResolve:   n.ViewerCanResolve,
 			ViewerCanUnresolve: n.ViewerCanUnresolve,
 			ViewerCanReply:     n.ViewerCanReply,
 			Comments:           comments,
+			Truncated:          n.truncated,
 		})
 	}
 
@@ -190,3 +190,70 @@ func TestEmptyThreads(t *testing.T) {
 		t.Errorf("len(Threads) = %d, want 0", len(result.Threads))
 	}
 }
+
+func TestAppendCommentPage(t *testing.T) {
+	page := func(ids []string, hasNext bool) commentConnection {
+		var c commentConnection
+		for _, id := range ids {
+			c.Nodes = append(c.Nodes, commentNode{ID: id, CreatedAt: "2026-02-20T10:00:00Z"})
+		}
+		c.PageInfo.HasNextPage = hasNext
+		c.PageInfo.EndCursor = "cursor-" + ids[len(ids)-1]
+		return c
+	}
+
+	tests := []struct {
+		name          string
+		pages         []commentConnection
+		limit         int
+		wantIDs       []string
+		wantTruncated bool
+	}{
+		{
+			name:    "all pages fetched",
+			pages:   []commentConnection{page([]string{"c3", "c4"}, true), page([]string{"c5"}, false)},
+			wantIDs: []string{"c1", "c2", "c3", "c4", "c5"},
+		},
+		{
+			name:          "cap reached mid-page",
+			pages:         []commentConnection{page([]string{"c3", "c4"}, true)},
+			limit:         3,
+			wantIDs:       []string{"c1", "c2", "c3"},
+			wantTruncated: true,
+		},
+		{
+			name:    "cap exactly at the end is not truncation",
+			pages:   []commentConnection{page([]string{"c3"}, false)},
+			limit:   3,
+			wantIDs: []string{"c1", "c2", "c3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := threadNode{ID: "PRRT_1", Comments: page([]string{"c1", "c2"}, true)}
+			for i, p := range tt.pages {
+				more := appendCommentPage(&n, p, tt.limit)
+				if last := i == len(tt.pages)-1; more == last {
+					t.Fatalf("page %d: more = %v, want %v", i, more, !last)
+				}
+			}
+
+			var gotIDs []string
+			for _, c := range n.Comments.Nodes {
+				gotIDs = append(gotIDs, c.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("comment IDs mismatch (-want +got):\n%s", diff)
+			}
+
+			result, err := mapThreadsToResult(42, 1, []threadNode{n})
+			if err != nil {
+				t.Fatalf("mapThreadsToResult: %v", err)
+			}
+			if got := result.Threads[0].Truncated; got != tt.wantTruncated {
+				t.Errorf("Truncated = %v, want %v", got, tt.wantTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// maxMessageLen caps a diagnostic message; editors show it inline, and the
+// full thread is one "open on GitHub" away.
+const maxMessageLen = 600
+
+// item is one published diagnostic plus what its code actions need.
+type item struct {
+	diag Diagnostic
+
+	threadID string // empty for annotations
+	canReply bool
+	canSolve bool
+	label    string // names the item in code action titles
+	url      string // thread comment or check run on GitHub
+}
+
+// snapshot is the PR feedback published at one refresh, keyed by file URI.
+type snapshot map[string][]item
+
+// buildSnapshot maps unresolved threads and failing-check annotations onto
+// files under root. Threads and annotations without a path have no place
+// in an editor and are skipped.
+func buildSnapshot(root string, threads *domain.CommentsResult, checks *domain.ChecksResult) snapshot {
+	snap := make(snapshot)
+	if threads != nil {
+		for _, t := range threads.Threads {
+			if t.IsResolved || t.Path == "" || len(t.Comments) == 0 {
+				continue
+			}
+			uri := fileURI(root, t.Path)
+			snap[uri] = append(snap[uri], threadItem(t))
+		}
+	}
+	if checks != nil {
+		// Annotations are only fetched for failing checks.
+		for _, ch := range checks.Checks {
+			for _, a := range ch.Annotations {
+				if a.Path == "" {
+					continue
+				}
+				uri := fileURI(root, a.Path)
+				snap[uri] = append(snap[uri], annotationItem(ch, a))
+			}
+		}
+	}
+	for uri := range snap {
+		items := snap[uri]
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].diag.Range.Start.Line < items[j].diag.Range.Start.Line
+		})
+	}
+	return snap
+}
+
+func threadItem(t domain.ReviewThread) item {
+	first := t.Comments[0]
+	last := t.Comments[len(t.Comments)-1]
+
+	msg := fmt.Sprintf("@%s: %s", first.Author, strings.TrimSpace(first.Body))
+	if replies := len(t.Comments) - 1; replies > 0 {
+		msg = fmt.Sprintf("%s\n(%s, last from @%s)", truncate(msg), domain.Plural(replies, "reply", "replies"), last.Author)
+	} else {
+		msg = truncate(msg)
+	}
+	if t.IsOutdated {
+		msg += "\n(outdated: the code has changed since this comment)"
+	}
+
+	severity := SeverityWarning
+	if first.IsBot {
+		severity = SeverityInformation
+	}
+
+	d := Diagnostic{
+		Range:    lineRange(t.StartLine, t.Line),
+		Severity: severity,
+		Code:     t.ID,
+		Source:   "ghent review",
+		Message:  msg,
+	}
+	if last.URL != "" {
+		d.CodeDescription = &CodeDescription{Href: last.URL}
+	}
+	return item{
+		diag:     d,
+		threadID: t.ID,
+		canReply: t.ViewerCanReply,
+		canSolve: t.ViewerCanResolve,
+		label:    "review thread from @" + first.Author,
+		url:      last.URL,
+	}
+}
+
+func annotationItem(ch domain.CheckRun, a domain.Annotation) item {
+	msg := strings.TrimSpace(a.Message)
+	if a.Title != "" && a.Title != msg {
+		msg = a.Title + ": " + msg
+	}
+
+	severity := SeverityError
+	switch a.AnnotationLevel {
+	case "warning":
+		severity = SeverityWarning
+	case "notice":
+		severity = SeverityInformation
+	}
+
+	d := Diagnostic{
+		Range:    lineRange(a.StartLine, a.EndLine),
+		Severity: severity,
+		Source:   "ghent " + ch.Name,
+		Message:  truncate(msg),
+	}
+	if ch.HTMLURL != "" {
+		d.CodeDescription = &CodeDescription{Href: ch.HTMLURL}
+	}
+	return item{diag: d, label: "check " + ch.Name, url: ch.HTMLURL}
+}
+
+// lineRange converts GitHub's 1-based inclusive line span to an LSP range
+// covering those lines in full. A missing start means a single line; a
+// missing line (file-level comment) means the top of the file.
+func lineRange(start, end int) Range {
+	if end <= 0 {
+		end = start
+	}
+	if start <= 0 || start > end {
+		start = end
+	}
+	if end <= 0 {
+		return Range{}
+	}
+	return Range{
+		Start: Position{Line: start - 1},
+		End:   Position{Line: end},
+	}
+}
+
+// fileURI returns the file:// URI of a repository-relative path.
+func fileURI(root, path string) string {
+	abs := filepath.Join(root, filepath.FromSlash(path))
+	p := filepath.ToSlash(abs)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p // Windows drive letters
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+// uriPath returns the filesystem path of a file:// URI, or "" if it is not
+// one. Comparing paths rather than URI strings tolerates clients that
+// escape characters differently.
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+func truncate(s string) string {
+	r := []rune(s)
+	if len(r) <= maxMessageLen {
+		return s
+	}
+	return strings.TrimSpace(string(r[:maxMessageLen])) + "…"
+}
@@ -0,0 +1,176 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidParams  = -32602
+	codeMethodNotFound = -32601
+	codeInternalError  = -32603
+)
+
+// message is a JSON-RPC 2.0 request, notification, or response. Requests
+// carry ID and Method, notifications only Method, responses ID and
+// Result or Error.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// conn frames messages with LSP base-protocol headers (Content-Length)
+// and matches responses to the server's own outgoing requests.
+type conn struct {
+	r *textproto.Reader
+
+	mu sync.Mutex // guards w
+	w  io.Writer
+
+	pendingMu sync.Mutex
+	nextID    int64
+	pending   map[string]chan *message
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		r:       textproto.NewReader(bufio.NewReader(r)),
+		w:       w,
+		pending: make(map[string]chan *message),
+	}
+}
+
+// read returns the next message from the client.
+func (c *conn) read() (*message, error) {
+	header, err := c.r.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r.R, body); err != nil {
+		return nil, err
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, &rpcError{Code: codeParseError, Message: err.Error()}
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+// notify sends a notification to the client.
+func (c *conn) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: raw})
+}
+
+// reply answers request id with result, or with err when it is non-nil.
+func (c *conn) reply(id *json.RawMessage, result any, err error) error {
+	if err != nil {
+		var re *rpcError
+		if !errors.As(err, &re) {
+			re = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		return c.write(&message{ID: id, Error: re})
+	}
+	raw, mErr := json.Marshal(result)
+	if mErr != nil {
+		return mErr
+	}
+	return c.write(&message{ID: id, Result: raw})
+}
+
+// call sends a request to the client and waits for its response, which the
+// read loop delivers through deliver. It must not be called from the read
+// loop itself.
+func (c *conn) call(ctx context.Context, method string, params, result any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	c.pendingMu.Lock()
+	c.nextID++
+	id := json.RawMessage(strconv.Quote("ghent-" + strconv.FormatInt(c.nextID, 10)))
+	ch := make(chan *message, 1)
+	c.pending[string(id)] = ch
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, string(id))
+		c.pendingMu.Unlock()
+	}()
+
+	if err := c.write(&message{ID: &id, Method: method, Params: raw}); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil || len(resp.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+	}
+}
+
+// deliver routes a response to the pending call with the same ID and
+// reports whether one was waiting.
+func (c *conn) deliver(msg *message) bool {
+	if msg.ID == nil {
+		return false
+	}
+	c.pendingMu.Lock()
+	ch, ok := c.pending[string(*msg.ID)]
+	c.pendingMu.Unlock()
+	if ok {
+		ch <- msg
+	}
+	return ok
+}
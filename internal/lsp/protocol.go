@@ -0,0 +1,150 @@
+package lsp
+
+import "encoding/json"
+
+// The subset of LSP 3.17 structures the server speaks. Field names follow
+// the specification so the JSON matches what editors send and expect.
+
+// DiagnosticSeverity values.
+const (
+	SeverityError       = 1
+	SeverityWarning     = 2
+	SeverityInformation = 3
+	SeverityHint        = 4
+)
+
+// Commands the server executes via workspace/executeCommand.
+const (
+	CommandResolve = "ghent.resolve" // args: [threadID]
+	CommandReply   = "ghent.reply"   // args: [threadID] or [threadID, body]
+	CommandOpen    = "ghent.open"    // args: [url]
+)
+
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// overlaps reports whether r and o share at least one line.
+func (r Range) overlaps(o Range) bool {
+	return r.Start.Line <= o.lastLine() && o.Start.Line <= r.lastLine()
+}
+
+// lastLine is the last line a range touches; a range ending at character 0
+// of a later line stops on the line before.
+func (r Range) lastLine() int {
+	if r.End.Character == 0 && r.End.Line > r.Start.Line {
+		return r.End.Line - 1
+	}
+	return r.End.Line
+}
+
+type CodeDescription struct {
+	Href string `json:"href"`
+}
+
+type Diagnostic struct {
+	Range           Range            `json:"range"`
+	Severity        int              `json:"severity"`
+	Code            string           `json:"code,omitempty"`
+	CodeDescription *CodeDescription `json:"codeDescription,omitempty"`
+	Source          string           `json:"source"`
+	Message         string           `json:"message"`
+}
+
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+type Command struct {
+	Title     string `json:"title"`
+	Command   string `json:"command"`
+	Arguments []any  `json:"arguments,omitempty"`
+}
+
+type CodeAction struct {
+	Title       string       `json:"title"`
+	Kind        string       `json:"kind,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	Command     *Command     `json:"command,omitempty"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type codeActionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Range        Range                  `json:"range"`
+}
+
+type executeCommandParams struct {
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments"`
+}
+
+type workspaceFolder struct {
+	URI string `json:"uri"`
+}
+
+type initializeParams struct {
+	RootURI          string            `json:"rootUri"`
+	RootPath         string            `json:"rootPath"`
+	WorkspaceFolders []workspaceFolder `json:"workspaceFolders"`
+}
+
+type serverInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type executeCommandOptions struct {
+	Commands []string `json:"commands"`
+}
+
+type serverCapabilities struct {
+	TextDocumentSync       int                   `json:"textDocumentSync"`
+	CodeActionProvider     bool                  `json:"codeActionProvider"`
+	ExecuteCommandProvider executeCommandOptions `json:"executeCommandProvider"`
+}
+
+type initializeResult struct {
+	Capabilities serverCapabilities `json:"capabilities"`
+	ServerInfo   serverInfo         `json:"serverInfo"`
+}
+
+// MessageType values for window/showMessage and window/logMessage.
+const (
+	messageWarning = 2
+	messageInfo    = 3
+)
+
+type showMessageParams struct {
+	Type    int    `json:"type"`
+	Message string `json:"message"`
+}
+
+type messageActionItem struct {
+	Title string `json:"title"`
+}
+
+type showMessageRequestParams struct {
+	Type    int                 `json:"type"`
+	Message string              `json:"message"`
+	Actions []messageActionItem `json:"actions"`
+}
+
+type showDocumentParams struct {
+	URI      string `json:"uri"`
+	External bool   `json:"external"`
+}
+
+type showDocumentResult struct {
+	Success bool `json:"success"`
+}
@@ -0,0 +1,409 @@
+// Package lsp implements ghent's Language Server: it publishes a pull
+// request's unresolved review threads and failing-check annotations as
+// diagnostics on the files they point at, and offers code actions to reply
+// to, resolve, or open them on GitHub.
+//
+// The server speaks JSON-RPC over the LSP base protocol on a reader/writer
+// pair (stdio in practice). GitHub access is injected through Backend so the
+// package stays independent of the API client.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// Backend binds the server to one pull request.
+type Backend struct {
+	Repo string // OWNER/REPO, for messages
+	PR   int
+	Root string // checkout root, used when the client sends no workspace root
+
+	FetchThreads func(ctx context.Context) (*domain.CommentsResult, error)
+	FetchChecks  func(ctx context.Context) (*domain.ChecksResult, error)
+
+	// WatchChecks polls CI until every check has completed, calling onPoll
+	// with each poll's status.
+	WatchChecks func(ctx context.Context, onPoll func(*domain.WatchStatus)) error
+
+	// Probe fingerprints review activity; a new fingerprint means threads
+	// or the head commit changed since the last refresh.
+	Probe func(ctx context.Context) (string, error)
+
+	Resolve func(ctx context.Context, threadID string) error
+	Reply   func(ctx context.Context, threadID, body string) error
+
+	// PollInterval spaces activity probes once CI has settled.
+	PollInterval time.Duration
+}
+
+// cannedReplies are offered when a reply is requested without a body;
+// editors cannot prompt for free text through LSP alone.
+var cannedReplies = []string{"Fixed.", "Acknowledged.", "Won't fix."}
+
+// Server is a Language Server for one pull request.
+type Server struct {
+	backend Backend
+	version string
+	conn    *conn
+	root    string
+	wg      sync.WaitGroup
+
+	publishMu sync.Mutex // serializes refreshes so snapshots publish in order
+
+	mu   sync.Mutex // guards snap
+	snap snapshot
+}
+
+// NewServer returns a server for backend; version is reported to clients.
+func NewServer(backend Backend, version string) *Server {
+	return &Server{backend: backend, version: version, root: backend.Root}
+}
+
+// Run serves LSP on r/w until the client sends exit, closes the stream, or
+// ctx is cancelled. Diagnostics refresh in the background from the
+// initialized notification on.
+func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer s.wg.Wait()
+	defer cancel()
+
+	s.conn = newConn(r, w)
+	for {
+		msg, err := s.conn.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			var rpcErr *rpcError
+			if errors.As(err, &rpcErr) {
+				slog.Debug("lsp: dropping malformed message", "error", err)
+				continue
+			}
+			return fmt.Errorf("lsp read: %w", err)
+		}
+
+		switch {
+		case msg.Method == "":
+			if !s.conn.deliver(msg) {
+				slog.Debug("lsp: response to unknown request", "id", msg.ID)
+			}
+		case msg.Method == "exit":
+			return nil
+		case msg.Method == "workspace/executeCommand":
+			// Commands may wait on requests to the client (message prompts),
+			// whose responses arrive through this loop.
+			s.wg.Go(func() { s.handle(ctx, msg) })
+		default:
+			s.handle(ctx, msg)
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) {
+	result, err := s.dispatch(ctx, msg)
+	if msg.ID == nil {
+		if err != nil {
+			slog.Debug("lsp: notification failed", "method", msg.Method, "error", err)
+		}
+		return
+	}
+	if err := s.conn.reply(msg.ID, result, err); err != nil {
+		slog.Debug("lsp: reply failed", "method", msg.Method, "error", err)
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var p initializeParams
+		if err := unmarshalParams(msg.Params, &p); err != nil {
+			return nil, err
+		}
+		s.setRoot(p)
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				CodeActionProvider: true,
+				ExecuteCommandProvider: executeCommandOptions{
+					Commands: []string{CommandReply, CommandResolve, CommandOpen},
+				},
+			},
+			ServerInfo: serverInfo{Name: "ghent", Version: s.version},
+		}, nil
+	case "initialized":
+		s.wg.Go(func() { s.refreshLoop(ctx) })
+		return nil, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if err := unmarshalParams(msg.Params, &p); err != nil {
+			return nil, err
+		}
+		return s.codeActions(p), nil
+	case "workspace/executeCommand":
+		var p executeCommandParams
+		if err := unmarshalParams(msg.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.executeCommand(ctx, p)
+	default:
+		// Document sync and other notifications carry nothing the server
+		// needs: diagnostics come from GitHub, not from buffer contents.
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not supported: " + msg.Method}
+	}
+}
+
+// setRoot picks the directory that thread and annotation paths are
+// relative to: the client's workspace, else the checkout, else the cwd.
+func (s *Server) setRoot(p initializeParams) {
+	switch {
+	case p.RootURI != "" && uriPath(p.RootURI) != "":
+		s.root = uriPath(p.RootURI)
+	case len(p.WorkspaceFolders) > 0 && uriPath(p.WorkspaceFolders[0].URI) != "":
+		s.root = uriPath(p.WorkspaceFolders[0].URI)
+	case p.RootPath != "":
+		s.root = p.RootPath
+	case s.root == "":
+		s.root, _ = os.Getwd()
+	}
+}
+
+// refreshLoop republishes diagnostics as CI progresses, then waits for new
+// review activity or a push and starts over.
+func (s *Server) refreshLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		baseline, _ := s.backend.Probe(ctx)
+
+		published := false
+		err := s.backend.WatchChecks(ctx, func(status *domain.WatchStatus) {
+			// Annotations only change when a check completes.
+			if !published || len(status.Events) > 0 {
+				published = true
+				s.publish(ctx)
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			s.logf("watching checks: %v", err)
+		}
+		if !published {
+			s.publish(ctx)
+		}
+
+		s.waitForActivity(ctx, baseline)
+	}
+}
+
+// waitForActivity returns once the activity fingerprint differs from
+// baseline or ctx is done.
+func (s *Server) waitForActivity(ctx context.Context, baseline string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.backend.PollInterval):
+		}
+		fp, err := s.backend.Probe(ctx)
+		if err != nil {
+			slog.Debug("lsp: activity probe failed", "error", err)
+			continue
+		}
+		if fp != baseline {
+			return
+		}
+	}
+}
+
+// publish fetches threads and checks and sends diagnostics for every file
+// that has feedback, clearing files whose feedback went away.
+func (s *Server) publish(ctx context.Context) {
+	s.publishMu.Lock()
+	defer s.publishMu.Unlock()
+
+	start := time.Now()
+	threads, err := s.backend.FetchThreads(ctx)
+	if err != nil {
+		s.logf("fetching review threads: %v", err)
+		return
+	}
+	checks, err := s.backend.FetchChecks(ctx)
+	if err != nil {
+		s.logf("fetching checks: %v", err)
+		return
+	}
+	snap := buildSnapshot(s.root, threads, checks)
+
+	s.mu.Lock()
+	old := s.snap
+	s.snap = snap
+	s.mu.Unlock()
+
+	for uri, items := range snap {
+		diags := make([]Diagnostic, len(items))
+		for i, it := range items {
+			diags[i] = it.diag
+		}
+		s.sendDiagnostics(uri, diags)
+	}
+	for uri := range old {
+		if _, ok := snap[uri]; !ok {
+			s.sendDiagnostics(uri, []Diagnostic{})
+		}
+	}
+	slog.Debug("lsp: published diagnostics", "files", len(snap), "duration", time.Since(start))
+}
+
+func (s *Server) sendDiagnostics(uri string, diags []Diagnostic) {
+	err := s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+	if err != nil {
+		slog.Debug("lsp: publish failed", "uri", uri, "error", err)
+	}
+}
+
+// codeActions offers reply, resolve, and open actions for the feedback
+// overlapping the requested range.
+func (s *Server) codeActions(p codeActionParams) []CodeAction {
+	path := uriPath(p.TextDocument.URI)
+	actions := []CodeAction{}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for uri, items := range s.snap {
+		if uriPath(uri) != path {
+			continue
+		}
+		for _, it := range items {
+			if it.diag.Range.overlaps(p.Range) {
+				actions = append(actions, itemActions(it)...)
+			}
+		}
+	}
+	return actions
+}
+
+func itemActions(it item) []CodeAction {
+	var actions []CodeAction
+	add := func(title, command string, args ...any) {
+		actions = append(actions, CodeAction{
+			Title:       title,
+			Kind:        "quickfix",
+			Diagnostics: []Diagnostic{it.diag},
+			Command:     &Command{Title: title, Command: command, Arguments: args},
+		})
+	}
+	if it.threadID != "" && it.canReply {
+		add("Reply to "+it.label, CommandReply, it.threadID)
+	}
+	if it.threadID != "" && it.canSolve {
+		add("Resolve "+it.label, CommandResolve, it.threadID)
+	}
+	if it.url != "" {
+		add("Open "+it.label+" on GitHub", CommandOpen, it.url)
+	}
+	return actions
+}
+
+func (s *Server) executeCommand(ctx context.Context, p executeCommandParams) error {
+	args := make([]string, len(p.Arguments))
+	for i, raw := range p.Arguments {
+		if err := json.Unmarshal(raw, &args[i]); err != nil {
+			return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("%s: argument %d must be a string", p.Command, i+1)}
+		}
+	}
+	if len(args) == 0 || args[0] == "" {
+		return &rpcError{Code: codeInvalidParams, Message: p.Command + ": missing argument"}
+	}
+
+	switch p.Command {
+	case CommandResolve:
+		if err := s.backend.Resolve(ctx, args[0]); err != nil {
+			return err
+		}
+		s.showMessage(messageInfo, "Resolved review thread")
+		s.publish(ctx)
+		return nil
+
+	case CommandReply:
+		body := ""
+		if len(args) > 1 {
+			body = args[1]
+		}
+		if body == "" {
+			var err error
+			if body, err = s.promptReply(ctx); err != nil || body == "" {
+				return err
+			}
+		}
+		if err := s.backend.Reply(ctx, args[0], body); err != nil {
+			return err
+		}
+		s.showMessage(messageInfo, "Replied: "+body)
+		s.publish(ctx)
+		return nil
+
+	case CommandOpen:
+		var res showDocumentResult
+		err := s.conn.call(ctx, "window/showDocument", showDocumentParams{URI: args[0], External: true}, &res)
+		if err != nil || !res.Success {
+			// Clients without showDocument still get a link to follow.
+			s.showMessage(messageInfo, args[0])
+		}
+		return nil
+
+	default:
+		return &rpcError{Code: codeInvalidParams, Message: "unknown command: " + p.Command}
+	}
+}
+
+// promptReply asks the user to pick a canned reply. It returns "" if the
+// prompt was dismissed.
+func (s *Server) promptReply(ctx context.Context) (string, error) {
+	actions := make([]messageActionItem, len(cannedReplies))
+	for i, r := range cannedReplies {
+		actions[i] = messageActionItem{Title: r}
+	}
+	var choice *messageActionItem
+	err := s.conn.call(ctx, "window/showMessageRequest", showMessageRequestParams{
+		Type:    messageInfo,
+		Message: fmt.Sprintf("Reply on %s#%d:", s.backend.Repo, s.backend.PR),
+		Actions: actions,
+	}, &choice)
+	if err != nil || choice == nil {
+		return "", err
+	}
+	return choice.Title, nil
+}
+
+func (s *Server) showMessage(typ int, text string) {
+	if err := s.conn.notify("window/showMessage", showMessageParams{Type: typ, Message: text}); err != nil {
+		slog.Debug("lsp: showMessage failed", "error", err)
+	}
+}
+
+// logf reports a background failure to the client's log.
+func (s *Server) logf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	slog.Debug("lsp: " + msg)
+	if err := s.conn.notify("window/logMessage", showMessageParams{Type: messageWarning, Message: "ghent: " + msg}); err != nil {
+		slog.Debug("lsp: logMessage failed", "error", err)
+	}
+}
+
+func unmarshalParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
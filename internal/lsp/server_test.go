@@ -0,0 +1,305 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/indrasvat/gh-ghent/internal/domain"
+)
+
+// fakePR is a backend whose thread resolves when asked to.
+type fakePR struct {
+	mu       sync.Mutex
+	resolved bool
+	replies  []string
+}
+
+func (f *fakePR) backend() Backend {
+	return Backend{
+		Repo:         "octo/repo",
+		PR:           42,
+		PollInterval: time.Hour,
+		FetchThreads: func(context.Context) (*domain.CommentsResult, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return &domain.CommentsResult{Threads: []domain.ReviewThread{{
+				ID: "PRRT_1", Path: "a.go", StartLine: 3, Line: 5, IsResolved: f.resolved,
+				ViewerCanReply: true, ViewerCanResolve: true,
+				Comments: []domain.Comment{{Author: "alice", Body: "rename this", URL: "https://github.com/octo/repo/pull/42#discussion_r1"}},
+			}}}, nil
+		},
+		FetchChecks: func(context.Context) (*domain.ChecksResult, error) {
+			return &domain.ChecksResult{Checks: []domain.CheckRun{{
+				Name: "lint", Conclusion: "failure", HTMLURL: "https://github.com/octo/repo/runs/9",
+				Annotations: []domain.Annotation{{Path: "b.go", StartLine: 7, EndLine: 7, AnnotationLevel: "failure", Message: "unused variable"}},
+			}}}, nil
+		},
+		WatchChecks: func(_ context.Context, onPoll func(*domain.WatchStatus)) error {
+			onPoll(&domain.WatchStatus{Final: true})
+			return nil
+		},
+		Probe: func(context.Context) (string, error) { return "fp", nil },
+		Resolve: func(_ context.Context, threadID string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.resolved = threadID == "PRRT_1"
+			return nil
+		},
+		Reply: func(_ context.Context, _, body string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.replies = append(f.replies, body)
+			return nil
+		},
+	}
+}
+
+// testClient drives a Server through in-memory pipes.
+type testClient struct {
+	t    *testing.T
+	conn *conn
+	done chan error
+}
+
+func startServer(t *testing.T, backend Backend) *testClient {
+	t.Helper()
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	c := &testClient{t: t, conn: newConn(clientR, clientW), done: make(chan error, 1)}
+	go func() {
+		c.done <- NewServer(backend, "test").Run(context.Background(), serverR, serverW)
+		_ = serverW.Close()
+	}()
+	return c
+}
+
+// request sends a request and returns its response, collecting the
+// notifications that arrive first. handle answers server-to-client
+// requests; it may be nil.
+func (c *testClient) request(method string, params any, handle func(*message) any) (*message, []*message) {
+	c.t.Helper()
+	id := json.RawMessage(`"` + method + `"`)
+	raw, _ := json.Marshal(params)
+	if err := c.conn.write(&message{ID: &id, Method: method, Params: raw}); err != nil {
+		c.t.Fatalf("write %s: %v", method, err)
+	}
+	var notes []*message
+	for {
+		msg := c.read()
+		switch {
+		case msg.Method == "":
+			return msg, notes
+		case msg.ID != nil:
+			if handle == nil {
+				c.t.Fatalf("unexpected server request %s", msg.Method)
+			}
+			if err := c.conn.reply(msg.ID, handle(msg), nil); err != nil {
+				c.t.Fatal(err)
+			}
+		default:
+			notes = append(notes, msg)
+		}
+	}
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	if err := c.conn.notify(method, params); err != nil {
+		c.t.Fatalf("notify %s: %v", method, err)
+	}
+}
+
+func (c *testClient) read() *message {
+	c.t.Helper()
+	type result struct {
+		msg *message
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		msg, err := c.conn.read()
+		ch <- result{msg, err}
+	}()
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			c.t.Fatalf("read: %v", r.err)
+		}
+		return r.msg
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timed out waiting for the server")
+		return nil
+	}
+}
+
+// published decodes publishDiagnostics notifications into messages per file.
+func published(t *testing.T, notes []*message) map[string][]string {
+	t.Helper()
+	got := make(map[string][]string)
+	for _, n := range notes {
+		if n.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var p PublishDiagnosticsParams
+		if err := json.Unmarshal(n.Params, &p); err != nil {
+			t.Fatal(err)
+		}
+		msgs := []string{}
+		for _, d := range p.Diagnostics {
+			msgs = append(msgs, d.Message)
+		}
+		got[p.URI] = msgs
+	}
+	return got
+}
+
+func TestServerPublishesAndActsOnFeedback(t *testing.T) {
+	pr := &fakePR{}
+	c := startServer(t, pr.backend())
+
+	resp, _ := c.request("initialize", map[string]any{"rootUri": "file:///work/repo"}, nil)
+	var init initializeResult
+	if err := json.Unmarshal(resp.Result, &init); err != nil || !init.Capabilities.CodeActionProvider {
+		t.Fatalf("initialize = %s, %v", resp.Result, err)
+	}
+
+	c.notify("initialized", struct{}{})
+	var notes []*message
+	for len(notes) < 2 {
+		notes = append(notes, c.read())
+	}
+	want := map[string][]string{
+		"file:///work/repo/a.go": {"@alice: rename this"},
+		"file:///work/repo/b.go": {"unused variable"},
+	}
+	if diff := cmp.Diff(want, published(t, notes)); diff != "" {
+		t.Errorf("initial diagnostics mismatch (-want +got):\n%s", diff)
+	}
+
+	// Line 4 (0-based 3) is inside the thread's 3-5 span.
+	resp, _ = c.request("textDocument/codeAction", codeActionParams{
+		TextDocument: textDocumentIdentifier{URI: "file:///work/repo/a.go"},
+		Range:        Range{Start: Position{Line: 3}, End: Position{Line: 3}},
+	}, nil)
+	var actions []CodeAction
+	if err := json.Unmarshal(resp.Result, &actions); err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, a := range actions {
+		titles = append(titles, a.Title)
+	}
+	wantTitles := []string{
+		"Reply to review thread from @alice",
+		"Resolve review thread from @alice",
+		"Open review thread from @alice on GitHub",
+	}
+	if diff := cmp.Diff(wantTitles, titles); diff != "" {
+		t.Errorf("code actions mismatch (-want +got):\n%s", diff)
+	}
+
+	// Reply without a body prompts for a canned reply.
+	resp, _ = c.request("workspace/executeCommand", map[string]any{
+		"command": CommandReply, "arguments": []string{"PRRT_1"},
+	}, func(req *message) any {
+		if req.Method != "window/showMessageRequest" {
+			t.Errorf("server request = %s, want window/showMessageRequest", req.Method)
+		}
+		return messageActionItem{Title: "Fixed."}
+	})
+	if resp.Error != nil {
+		t.Fatalf("reply command: %v", resp.Error)
+	}
+	if diff := cmp.Diff([]string{"Fixed."}, pr.replies); diff != "" {
+		t.Errorf("replies mismatch (-want +got):\n%s", diff)
+	}
+
+	// Resolving clears the thread's file.
+	resp, notes = c.request("workspace/executeCommand", map[string]any{
+		"command": CommandResolve, "arguments": []string{"PRRT_1"},
+	}, nil)
+	if resp.Error != nil {
+		t.Fatalf("resolve command: %v", resp.Error)
+	}
+	got := published(t, notes)
+	if diff := cmp.Diff([]string{}, got["file:///work/repo/a.go"]); diff != "" {
+		t.Errorf("a.go after resolve mismatch (-want +got):\n%s", diff)
+	}
+
+	if resp, _ := c.request("shutdown", nil, nil); resp.Error != nil {
+		t.Fatalf("shutdown: %v", resp.Error)
+	}
+	c.notify("exit", nil)
+	if err := <-c.done; err != nil {
+		t.Errorf("Run() = %v", err)
+	}
+}
+
+func TestServerRejectsUnknownRequests(t *testing.T) {
+	c := startServer(t, (&fakePR{}).backend())
+	resp, _ := c.request("textDocument/hover", map[string]any{}, nil)
+	if resp.Error == nil || resp.Error.Code != codeMethodNotFound {
+		t.Errorf("hover error = %+v, want method not found", resp.Error)
+	}
+	c.notify("exit", nil)
+	<-c.done
+}
+
+func TestBuildSnapshot(t *testing.T) {
+	threads := &domain.CommentsResult{Threads: []domain.ReviewThread{
+		{ID: "T1", Path: "x.go", Line: 10, Comments: []domain.Comment{{Author: "bot[bot]", IsBot: true, Body: "nit"}}},
+		{ID: "T2", Path: "x.go", Line: 2, Comments: []domain.Comment{
+			{Author: "alice", Body: "why?"}, {Author: "bob", Body: "because"},
+		}},
+		{ID: "T3", Path: "x.go", Line: 4, IsResolved: true, Comments: []domain.Comment{{Author: "alice"}}},
+		{ID: "T4", Path: "", Line: 0, Comments: []domain.Comment{{Author: "alice"}}},
+	}}
+	checks := &domain.ChecksResult{Checks: []domain.CheckRun{{
+		Name: "test",
+		Annotations: []domain.Annotation{
+			{Path: "y.go", StartLine: 3, EndLine: 4, AnnotationLevel: "warning", Title: "vet", Message: "shadowed"},
+		},
+	}}}
+
+	snap := buildSnapshot("/r", threads, checks)
+
+	type diag struct {
+		Start, End, Severity int
+		Message              string
+	}
+	got := make(map[string][]diag)
+	for uri, items := range snap {
+		for _, it := range items {
+			d := it.diag
+			got[uri] = append(got[uri], diag{d.Range.Start.Line, d.Range.End.Line, d.Severity, d.Message})
+		}
+	}
+	want := map[string][]diag{
+		"file:///r/x.go": {
+			{1, 2, SeverityWarning, "@alice: why?\n(1 reply, last from @bob)"},
+			{9, 10, SeverityInformation, "@bot[bot]: nit"},
+		},
+		"file:///r/y.go": {
+			{2, 4, SeverityWarning, "vet: shadowed"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("snapshot mismatch (-want +got):\n%s", diff)
+	}
+
+	var uris []string
+	for uri := range snap {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	if uriPath(uris[0]) != "/r/x.go" {
+		t.Errorf("uriPath(%q) = %q, want /r/x.go", uris[0], uriPath(uris[0]))
+	}
+}
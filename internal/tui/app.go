@@ -91,6 +91,24 @@ type reviewsLoadedMsg struct {
 	err     error
 }
 
+// mergeContextLoadedMsg is sent when PR metadata and branch rules are fetched.
+type mergeContextLoadedMsg struct {
+	pr           *domain.PullRequestInfo
+	requirements *domain.MergeRequirements
+	err          error
+}
+
+// statusLoadedMsg is sent when the combined status fetch completes.
+type statusLoadedMsg struct {
+	result *domain.StatusResult
+	pr     *domain.PullRequestInfo
+	err    error
+}
+
+// FetchStatusFunc fetches a PR's full status with merge readiness already
+// evaluated on the unfiltered data, as pipe-mode status reports it.
+type FetchStatusFunc func() (*domain.StatusResult, *domain.PullRequestInfo, error)
+
 // FetchCommentsFunc fetches review threads for a PR.
 type FetchCommentsFunc func() (*domain.CommentsResult, error)
 
@@ -100,6 +118,9 @@ type FetchChecksFunc func() (*domain.ChecksResult, error)
 // FetchReviewsFunc fetches reviews for a PR.
 type FetchReviewsFunc func() ([]domain.Review, error)
 
+// FetchMergeContextFunc fetches PR metadata and the base branch's merge rules.
+type FetchMergeContextFunc func() (*domain.PullRequestInfo, *domain.MergeRequirements, error)
+
 // App is the root Bubble Tea model for the ghent TUI.
 type App struct {
 	// View state
@@ -121,12 +142,16 @@ type App struct {
 	commentsLoading bool
 	checksLoading   bool
 	reviewsLoading  bool
+	mergeCtxLoading bool
+	statusLoading   bool
 	loadErrors      []string
 
 	// Async fetch functions (set by CLI, fired in Init).
 	fetchCommentsFn FetchCommentsFunc
 	fetchChecksFn   FetchChecksFunc
 	fetchReviewsFn  FetchReviewsFunc
+	fetchMergeCtxFn FetchMergeContextFunc
+	fetchStatusFn   FetchStatusFunc
 
 	// Resolver callback for resolve view mutations.
 	resolveFunc func(threadID string) error
@@ -184,6 +209,12 @@ func (a App) Init() tea.Cmd {
 			return reviewsLoadedMsg{reviews: reviews, err: err}
 		})
 	}
+	if a.fetchMergeCtxFn != nil {
+		cmds = append(cmds, a.mergeContextCmd())
+	}
+	if a.fetchStatusFn != nil {
+		cmds = append(cmds, a.statusCmd())
+	}
 	if len(cmds) > 0 {
 		return tea.Batch(cmds...)
 	}
@@ -256,6 +287,40 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.status.recomputeMaxScroll()
 		return a, nil
 
+	case mergeContextLoadedMsg:
+		a.mergeCtxLoading = false
+		a.status.loading = a.isLoading()
+		// Not fatal: without branch rules, readiness uses the default heuristic.
+		if typedMsg.err == nil {
+			a.status.pr = typedMsg.pr
+			a.status.requirements = typedMsg.requirements
+		}
+		a.status.recomputeMaxScroll()
+		return a, nil
+
+	case statusLoadedMsg:
+		a.statusLoading = false
+		a.status.loading = a.isLoading()
+		if typedMsg.err != nil {
+			a.loadErrors = append(a.loadErrors, fmt.Sprintf("status: %v", typedMsg.err))
+			a.status.hasErrors = true
+		} else {
+			r := typedMsg.result
+			a.SetComments(&r.Comments)
+			a.SetChecks(&r.Checks)
+			a.SetReviews(r.Reviews)
+			a.status.pr = typedMsg.pr
+			a.status.requirements = r.MergeRequirements
+			a.status.evaluated = true
+			a.status.verdict = r.Blockers
+			contentHeight := max(a.height-2, 1)
+			a.commentsList.setSize(a.width, contentHeight)
+			a.resolve.setSize(a.width, contentHeight)
+			a.checksList.setSize(a.width, contentHeight)
+		}
+		a.status.recomputeMaxScroll()
+		return a, nil
+
 	// Messages from sub-models
 	case selectThreadMsg:
 		a.activeView = ViewCommentsExpand
@@ -331,6 +396,14 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return reviewsLoadedMsg{reviews: reviews, err: err}
 			})
 		}
+		if a.fetchMergeCtxFn != nil {
+			a.mergeCtxLoading = true
+			cmds = append(cmds, a.mergeContextCmd())
+		}
+		if a.fetchStatusFn != nil {
+			a.statusLoading = true
+			cmds = append(cmds, a.statusCmd())
+		}
 		a.status.loading = a.isLoading()
 		if len(cmds) > 0 {
 			return a, tea.Batch(cmds...)
@@ -660,6 +733,7 @@ func (a *App) SetComments(c *domain.CommentsResult) {
 	a.comments = c
 	if c != nil {
 		a.commentsList = newCommentsListModel(c.Threads)
+		a.commentsList.setConversation(c.Conversation)
 		a.resolve = newResolveModel(c.Threads)
 	}
 	a.status.comments = c
@@ -712,6 +786,11 @@ func (a *App) SetSolo(solo bool) {
 	a.status.solo = solo
 }
 
+// SetMergePolicy applies a team merge policy to readiness evaluation.
+func (a *App) SetMergePolicy(p *domain.MergePolicy) {
+	a.status.policy = p
+}
+
 // SetAsyncFetch configures async data fetching — TUI launches immediately,
 // data loads progressively via Init() commands.
 func (a *App) SetAsyncFetch(comments FetchCommentsFunc, checks FetchChecksFunc, reviews FetchReviewsFunc) {
@@ -730,9 +809,42 @@ func (a *App) SetAsyncFetch(comments FetchCommentsFunc, checks FetchChecksFunc,
 	a.status.loading = a.isLoading()
 }
 
+// SetMergeContextFetch configures the async fetch of PR metadata and branch
+// rules used to evaluate merge readiness in the status view.
+func (a *App) SetMergeContextFetch(fn FetchMergeContextFunc) {
+	a.fetchMergeCtxFn = fn
+	a.mergeCtxLoading = fn != nil
+	a.status.loading = a.isLoading()
+}
+
+func (a App) mergeContextCmd() tea.Cmd {
+	fn := a.fetchMergeCtxFn
+	return func() tea.Msg {
+		pr, req, err := fn()
+		return mergeContextLoadedMsg{pr: pr, requirements: req, err: err}
+	}
+}
+
+// SetStatusFetch configures one async fetch of the whole status view. The
+// status view then shows the readiness it returns instead of evaluating the
+// loaded data, which may be narrowed for display (e.g. by --since).
+func (a *App) SetStatusFetch(fn FetchStatusFunc) {
+	a.fetchStatusFn = fn
+	a.statusLoading = fn != nil
+	a.status.loading = a.isLoading()
+}
+
+func (a App) statusCmd() tea.Cmd {
+	fn := a.fetchStatusFn
+	return func() tea.Msg {
+		result, pr, err := fn()
+		return statusLoadedMsg{result: result, pr: pr, err: err}
+	}
+}
+
 // isLoading returns true if any data is still being fetched.
 func (a App) isLoading() bool {
-	return a.commentsLoading || a.checksLoading || a.reviewsLoading
+	return a.commentsLoading || a.checksLoading || a.reviewsLoading || a.mergeCtxLoading || a.statusLoading
 }
 
 // ActiveView returns the current active view.
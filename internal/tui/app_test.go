@@ -579,6 +579,40 @@ func TestSetAsyncFetchMarksLoading(t *testing.T) {
 	}
 }
 
+func TestStatusLoadedMsgKeepsFetchedReadiness(t *testing.T) {
+	app := NewApp("owner/repo", 42, ViewStatus)
+	app.SetStatusFetch(func() (*domain.StatusResult, *domain.PullRequestInfo, error) { return nil, nil, nil })
+	if !app.statusLoading || !app.status.loading {
+		t.Fatal("expected loading after SetStatusFetch")
+	}
+	app = sendWindowSize(app, 80, 24)
+
+	// --since hid the old unresolved thread from display, but the fetch
+	// evaluated readiness before filtering, so it still blocks.
+	blocker := domain.MergeBlocker{Rule: "unresolved_threads", Source: domain.BlockerSourceDefault, Message: "1 unresolved review thread"}
+	model, _ := app.Update(statusLoadedMsg{
+		result: &domain.StatusResult{
+			Checks:   domain.ChecksResult{OverallStatus: domain.StatusPass},
+			Reviews:  []domain.Review{{Author: "alice", State: domain.ReviewApproved}},
+			Blockers: []domain.MergeBlocker{blocker},
+		},
+		pr: &domain.PullRequestInfo{Number: 42, MergeStateStatus: "CLEAN"},
+	})
+	app = model.(App)
+	if app.statusLoading || app.status.loading {
+		t.Error("expected loading to end after statusLoadedMsg")
+	}
+	if app.comments == nil || app.comments.UnresolvedCount != 0 {
+		t.Error("comments not set from the status result")
+	}
+	if app.status.isMergeReady() {
+		t.Error("isMergeReady() = true; want the fetched blocker to hold")
+	}
+	if got := app.status.blockers(); len(got) != 1 || got[0] != blocker {
+		t.Errorf("blockers() = %+v, want [%+v]", got, blocker)
+	}
+}
+
 func TestAsyncInitReturnsCommands(t *testing.T) {
 	app := NewApp("owner/repo", 42, ViewStatus)
 	app.SetAsyncFetch(
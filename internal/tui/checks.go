@@ -182,6 +182,15 @@ func (m checksListModel) renderCheckRow(ch domain.CheckRun, isCursor bool) strin
 		nameStyle = nameStyle.Foreground(lipgloss.Color(string(styles.Red))).Bold(true)
 	}
 	nameStr := nameStyle.Render(name)
+	if ch.IsCommitStatus() {
+		nameStr += " " + styles.StatusBarDim.Render("(status)")
+	}
+	if ch.Flaky != nil {
+		nameStr += " " + lipgloss.NewStyle().Foreground(lipgloss.Color(string(styles.Yellow))).Render("(flaky)")
+	}
+	if badge := baseComparisonBadge(ch.BaseComparison); badge != "" {
+		nameStr += " " + badge
+	}
 
 	// Duration.
 	durStr := styles.StatusBarDim.Render(formatCheckDuration(ch))
@@ -215,6 +224,20 @@ func (m checksListModel) renderCheckRow(ch domain.CheckRun, isCursor bool) strin
 	return styles.ListItemNormal.Render(fullRow) + styles.ANSIReset
 }
 
+// baseComparisonBadge renders how a check compares with the base branch:
+// new failures stand out, pre-existing ones are dimmed.
+func baseComparisonBadge(comparison string) string {
+	switch comparison {
+	case domain.BaseNewFailure:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(string(styles.Red))).Render("(new)")
+	case domain.BasePreExisting:
+		return styles.StatusBarDim.Render("(pre-existing)")
+	case domain.BaseFixedByPR:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(string(styles.Green))).Render("(fixed by PR)")
+	}
+	return ""
+}
+
 // renderAnnotationHeader renders the "N errors" header below a failed check.
 func (m checksListModel) renderAnnotationHeader(ch domain.CheckRun) string {
 	count := len(ch.Annotations)
@@ -297,6 +320,12 @@ func (m *checksLogModel) buildContent() {
 	lines = append(lines, " "+styles.StatusBarDim.Render("Duration: "+dur+"  Status: "+conclusion)+styles.ANSIReset)
 	lines = append(lines, "")
 
+	// ── Commit status description ──
+	if ch.Description != "" {
+		lines = append(lines, " "+ch.Description+styles.ANSIReset)
+		lines = append(lines, "")
+	}
+
 	// ── Annotations ──
 	if len(ch.Annotations) > 0 {
 		count := len(ch.Annotations)
@@ -318,6 +347,16 @@ func (m *checksLogModel) buildContent() {
 		}
 	}
 
+	// ── Failed steps ──
+	for _, st := range ch.FailedSteps {
+		lines = append(lines, " "+styles.StatusBarDim.Render("Failed step:")+" "+
+			styles.CheckFail.Render(fmt.Sprintf("%d. %s", st.Number, st.Name))+
+			styles.StatusBarDim.Render(fmt.Sprintf(" (%s, %ds)", st.Conclusion, st.DurationSeconds))+styles.ANSIReset)
+	}
+	if len(ch.FailedSteps) > 0 {
+		lines = append(lines, "")
+	}
+
 	// ── Log excerpt ──
 	if ch.LogExcerpt != "" {
 		lines = append(lines, " "+styles.StatusBarDim.Render("Log excerpt:")+styles.ANSIReset)
@@ -342,7 +381,7 @@ func (m *checksLogModel) buildContent() {
 			}
 			lines = append(lines, "  "+lineNum+" "+lineContent+styles.ANSIReset)
 		}
-	} else if checkIsFailed(*ch) {
+	} else if checkIsFailed(*ch) && !ch.IsCommitStatus() {
 		lines = append(lines, " "+styles.StatusBarDim.Render("No log excerpt available.")+styles.ANSIReset)
 	}
 
@@ -407,7 +446,7 @@ func checkIsFailed(ch domain.CheckRun) bool {
 		return false
 	}
 	switch ch.Conclusion {
-	case "failure", "timed_out", "action_required", "startup_failure", "stale", "cancelled":
+	case "failure", "timed_out", "action_required", "startup_failure", "stale", "cancelled", "error":
 		return true
 	}
 	return false
@@ -511,7 +550,7 @@ func rerunFailedChecks(repo string, checks []domain.CheckRun) tea.Cmd {
 		// Collect unique run IDs from failed checks.
 		seen := make(map[string]bool)
 		for _, ch := range checks {
-			if !checkIsFailed(ch) {
+			if !checkIsFailed(ch) || ch.IsCommitStatus() {
 				continue
 			}
 			runID := extractRunID(ch.HTMLURL)
@@ -68,6 +68,27 @@ func TestChecksListRenders(t *testing.T) {
 	}
 }
 
+func TestChecksListBaseComparisonBadges(t *testing.T) {
+	lint := makeCheck("lint", "completed", "failure", nil)
+	lint.BaseComparison = domain.BasePreExisting
+	test := makeCheck("test", "completed", "failure", nil)
+	test.BaseComparison = domain.BaseNewFailure
+	build := makeCheck("build", "completed", "success", nil)
+	build.BaseComparison = domain.BaseFixedByPR
+	e2e := makeCheck("e2e", "completed", "failure", nil)
+	e2e.Flaky = &domain.FlakyCheck{Reason: domain.FlakyReasonSameCode}
+
+	m := newChecksListModel([]domain.CheckRun{lint, test, build, e2e})
+	m.setSize(120, 20)
+
+	view := m.View()
+	for _, want := range []string{"(pre-existing)", "(new)", "(fixed by PR)", "(flaky)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("missing badge %q", want)
+		}
+	}
+}
+
 func TestChecksListAnnotationsAutoExpand(t *testing.T) {
 	checks := []domain.CheckRun{
 		makeCheck("lint", "completed", "failure", []domain.Annotation{
@@ -30,25 +30,36 @@ type listItemKind int
 const (
 	listItemFileHeader listItemKind = iota
 	listItemThread
+	listItemConversation
 )
 
+// conversationHeader labels the group of top-level PR comments and review bodies.
+const conversationHeader = "PR conversation"
+
 type listItem struct {
 	kind     listItemKind
-	filePath string               // file header text
-	thread   *domain.ReviewThread // nil for headers
-	idx      int                  // index into original threads slice
+	filePath string                      // file header text
+	thread   *domain.ReviewThread        // set for thread rows
+	entry    *domain.ConversationComment // set for conversation rows
+	idx      int                         // index into original threads slice
+}
+
+// selectable reports whether the cursor can land on the item.
+func (i listItem) selectable() bool {
+	return i.kind == listItemThread || i.kind == listItemConversation
 }
 
 // ── Comments list model ─────────────────────────────────────────
 
 // commentsListModel renders a scrollable list of review threads grouped by file.
 type commentsListModel struct {
-	threads []domain.ReviewThread
-	items   []listItem // flattened: file headers + thread rows
-	cursor  int        // index into items (only lands on thread items)
-	offset  int        // scroll offset for viewport
-	width   int
-	height  int
+	threads      []domain.ReviewThread
+	conversation []domain.ConversationComment
+	items        []listItem // flattened: file headers + thread rows + conversation rows
+	cursor       int        // index into items (only lands on thread items)
+	offset       int        // scroll offset for viewport
+	width        int
+	height       int
 
 	// File filter state (cycled by 'f' key).
 	filterFile  string   // empty = show all
@@ -63,14 +74,28 @@ func newCommentsListModel(threads []domain.ReviewThread) commentsListModel {
 	}
 	m.computeUniquePaths()
 	m.buildItems()
-	// Set cursor to the first thread item.
+	m.cursorToFirst()
+	return m
+}
+
+// setConversation adds top-level PR comments and review bodies below the
+// threads, under their own header.
+func (m *commentsListModel) setConversation(entries []domain.ConversationComment) {
+	m.conversation = entries
+	m.buildItems()
+	m.offset = 0
+	m.cursorToFirst()
+}
+
+// cursorToFirst moves the cursor to the first selectable item.
+func (m *commentsListModel) cursorToFirst() {
+	m.cursor = 0
 	for i, item := range m.items {
-		if item.kind == listItemThread {
+		if item.selectable() {
 			m.cursor = i
-			break
+			return
 		}
 	}
-	return m
 }
 
 // computeUniquePaths extracts sorted unique file paths from all threads.
@@ -85,13 +110,10 @@ func (m *commentsListModel) computeUniquePaths() {
 	sort.Strings(m.uniquePaths)
 }
 
-// buildItems creates the flattened item list from threads, grouped by file path.
-// When filterFile is set, only threads matching that path are included.
+// buildItems creates the flattened item list from threads, grouped by file path,
+// followed by the PR conversation. When filterFile is set, only threads matching
+// that path are included and the conversation is hidden.
 func (m *commentsListModel) buildItems() {
-	if len(m.threads) == 0 {
-		m.items = nil
-		return
-	}
 
 	// Group threads by file path.
 	groups := make(map[string][]int) // path → thread indices
@@ -120,6 +142,12 @@ func (m *commentsListModel) buildItems() {
 			})
 		}
 	}
+	if m.filterFile == "" && len(m.conversation) > 0 {
+		items = append(items, listItem{kind: listItemFileHeader, filePath: conversationHeader})
+		for i := range m.conversation {
+			items = append(items, listItem{kind: listItemConversation, entry: &m.conversation[i], idx: -1})
+		}
+	}
 	m.items = items
 }
 
@@ -139,15 +167,8 @@ func (m *commentsListModel) cycleFilter() {
 		m.filterFile = m.uniquePaths[m.filterIdx]
 	}
 	m.buildItems()
-	m.cursor = 0
 	m.offset = 0
-	// Move cursor to first thread item.
-	for i, item := range m.items {
-		if item.kind == listItemThread {
-			m.cursor = i
-			break
-		}
-	}
+	m.cursorToFirst()
 }
 
 // setSize sets the viewport dimensions.
@@ -164,6 +185,15 @@ func (m commentsListModel) selectedThreadIdx() int {
 	return -1
 }
 
+// selectedEntry returns the focused conversation entry, or nil if the cursor
+// is on a thread.
+func (m commentsListModel) selectedEntry() *domain.ConversationComment {
+	if m.cursor >= 0 && m.cursor < len(m.items) && m.items[m.cursor].kind == listItemConversation {
+		return m.items[m.cursor].entry
+	}
+	return nil
+}
+
 // Update handles key events for the comments list.
 func (m commentsListModel) Update(msg tea.Msg) (commentsListModel, tea.Cmd) {
 	if typedMsg, ok := msg.(tea.KeyMsg); ok {
@@ -178,11 +208,17 @@ func (m commentsListModel) Update(msg tea.Msg) (commentsListModel, tea.Cmd) {
 				return m, func() tea.Msg { return selectThreadMsg{threadIdx: idx} }
 			}
 		case key.Matches(typedMsg, commentsKeys.Copy):
+			if e := m.selectedEntry(); e != nil {
+				return m, copyToClipboard(e.ID)
+			}
 			idx := m.selectedThreadIdx()
 			if idx >= 0 && idx < len(m.threads) {
 				return m, copyToClipboard(m.threads[idx].ID)
 			}
 		case key.Matches(typedMsg, commentsKeys.Open):
+			if e := m.selectedEntry(); e != nil && e.URL != "" {
+				return m, openInBrowser(e.URL)
+			}
 			idx := m.selectedThreadIdx()
 			if idx >= 0 && idx < len(m.threads) {
 				t := m.threads[idx]
@@ -210,7 +246,7 @@ func (m *commentsListModel) moveCursor(delta int) {
 		if pos < 0 || pos >= len(m.items) {
 			return // don't wrap
 		}
-		if m.items[pos].kind == listItemThread {
+		if m.items[pos].selectable() {
 			m.cursor = pos
 			m.ensureVisible()
 			return
@@ -219,9 +255,9 @@ func (m *commentsListModel) moveCursor(delta int) {
 }
 
 // itemScreenLines returns the number of screen lines an item occupies.
-// Thread items render 3 lines (marker + body + thread ID); headers render 1.
+// Thread and conversation items render 3 lines (marker + body + ID); headers render 1.
 func (m *commentsListModel) itemScreenLines(i int) int {
-	if i >= 0 && i < len(m.items) && m.items[i].kind == listItemThread {
+	if i >= 0 && i < len(m.items) && m.items[i].selectable() {
 		return 3
 	}
 	return 1
@@ -272,6 +308,9 @@ func (m commentsListModel) View() string {
 		case listItemThread:
 			lines = append(lines, m.renderThreadRow(item, i == m.cursor))
 			screenLines += 3 // marker + body + thread ID
+		case listItemConversation:
+			lines = append(lines, m.renderConversationRow(*item.entry, i == m.cursor))
+			screenLines += 3
 		}
 	}
 
@@ -374,12 +413,7 @@ func (m commentsListModel) renderThreadRow(item listItem, isCursor bool) string
 		metaParts = append(metaParts, styles.ThreadID.Render(idDisplay))
 	}
 	if len(t.Comments) > 1 {
-		n := len(t.Comments) - 1
-		if n == 1 {
-			metaParts = append(metaParts, "1 reply")
-		} else {
-			metaParts = append(metaParts, fmt.Sprintf("%d replies", n))
-		}
+		metaParts = append(metaParts, domain.Plural(len(t.Comments)-1, "reply", "replies"))
 	}
 	line3 := "     " + styles.StatusBarDim.Render(strings.Join(metaParts, " · "))
 
@@ -393,8 +427,72 @@ func (m commentsListModel) renderThreadRow(item listItem, isCursor bool) string
 	return content
 }
 
+// renderConversationRow renders a top-level comment or review body:
+//
+//	Line 1:  ▶  review CHANGES_REQUESTED — @coderabbitai [bot]  2h ago
+//	Line 2:      Actionable comments posted: 3 ...
+//	Line 3:      PRR_kwDON1... · unanswered
+func (m commentsListModel) renderConversationRow(c domain.ConversationComment, isCursor bool) string {
+	marker := " "
+	if isCursor {
+		marker = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(string(styles.Blue))).
+			Render("▶")
+	}
+
+	authorLabel := "@" + c.Author
+	if c.IsBot {
+		authorLabel += " [bot]"
+	}
+	line1Parts := []string{
+		" " + marker,
+		styles.LineNumber.Render(conversationLabel(c)),
+		styles.StatusBarDim.Render("—"),
+		styles.Author.Render(authorLabel),
+	}
+	if !c.CreatedAt.IsZero() {
+		line1Parts = append(line1Parts, styles.StatusBarDim.Render(formatTimeAgo(c.CreatedAt)))
+	}
+	line1 := strings.Join(line1Parts, " ")
+
+	body := styles.Truncate(stripMarkdown(c.Body), max(m.width-10, 20))
+	if c.IsMinimized {
+		body = styles.StatusBarDim.Render(body)
+	}
+	line2 := "     " + body
+
+	idDisplay := c.ID
+	if len(idDisplay) > 14 {
+		idDisplay = idDisplay[:14] + "..."
+	}
+	metaParts := []string{styles.ThreadID.Render(idDisplay)}
+	switch {
+	case c.IsMinimized:
+		metaParts = append(metaParts, "hidden ("+strings.ToLower(c.MinimizedReason)+")")
+	case c.HasReply:
+		metaParts = append(metaParts, "answered")
+	default:
+		metaParts = append(metaParts, "unanswered")
+	}
+	line3 := "     " + styles.StatusBarDim.Render(strings.Join(metaParts, " · "))
+
+	if isCursor {
+		return styles.ListItemSelected.Render(line1+"\n"+line2+"\n"+line3) + styles.ANSIReset
+	}
+	return styles.ListItemNormal.Render(line1+"\n"+line2+"\n"+line3) + styles.ANSIReset
+}
+
 // ── Helpers ─────────────────────────────────────────────────────
 
+// conversationLabel names a conversation entry's kind, e.g. "comment" or
+// "review APPROVED".
+func conversationLabel(c domain.ConversationComment) string {
+	if c.Kind == domain.ConversationKindReview && c.ReviewState != "" {
+		return c.Kind + " " + string(c.ReviewState)
+	}
+	return c.Kind
+}
+
 // formatTimeAgo returns a human-readable relative time string.
 func formatTimeAgo(t time.Time) string {
 	d := time.Since(t)
@@ -880,3 +880,42 @@ func TestExpandedOKeyNoURLNoOp(t *testing.T) {
 		t.Error("expected nil command for 'o' when URL is empty")
 	}
 }
+
+func TestCommentsListConversationRows(t *testing.T) {
+	m := newCommentsListModel(testThreads()[:1])
+	m.setConversation([]domain.ConversationComment{
+		{ID: "IC_1", Kind: domain.ConversationKindComment, Author: "coderabbitai", IsBot: true, Body: "## Walkthrough", URL: "https://example.com/1"},
+		{ID: "PRR_1", Kind: domain.ConversationKindReview, Author: "bob", ReviewState: domain.ReviewChangesRequested, Body: "Please split this PR."},
+	})
+	m.setSize(120, 30)
+
+	// 1 file header + 1 thread + conversation header + 2 entries.
+	if len(m.items) != 5 {
+		t.Fatalf("items = %d, want 5", len(m.items))
+	}
+
+	out := m.View()
+	for _, want := range []string{"PR conversation", "@coderabbitai [bot]", "review CHANGES_REQUESTED", "Please split this PR.", "unanswered"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	// Cursor moves from the thread onto conversation entries, skipping the header.
+	m.moveCursor(1)
+	if e := m.selectedEntry(); e == nil || e.ID != "IC_1" {
+		t.Fatalf("selectedEntry() = %v, want IC_1", e)
+	}
+	if idx := m.selectedThreadIdx(); idx != -1 {
+		t.Errorf("selectedThreadIdx() = %d on a conversation row, want -1", idx)
+	}
+}
+
+func TestCommentsListConversationOnly(t *testing.T) {
+	m := newCommentsListModel(nil)
+	m.setConversation([]domain.ConversationComment{{ID: "IC_1", Kind: domain.ConversationKindComment, Author: "alice"}})
+
+	if e := m.selectedEntry(); e == nil || e.ID != "IC_1" {
+		t.Errorf("cursor should start on the first conversation entry, got %v", e)
+	}
+}
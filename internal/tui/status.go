@@ -19,6 +19,9 @@ type statusModel struct {
 	checks        *domain.ChecksResult
 	reviews       []domain.Review
 	reviewMonitor *domain.ReviewMonitor
+	pr            *domain.PullRequestInfo
+	requirements  *domain.MergeRequirements // nil → default readiness heuristic
+	policy        *domain.MergePolicy       // nil → no policy file
 	width         int
 	height        int
 	scrollOffset  int
@@ -26,6 +29,11 @@ type statusModel struct {
 	loading       bool // true while async data is being fetched
 	hasErrors     bool // true if any core fetch failed — blocks merge readiness
 	solo          bool // skip approval requirement (single-maintainer repos)
+
+	// evaluated marks verdict as the readiness computed by the fetch, on
+	// data that may since have been narrowed for display.
+	evaluated bool
+	verdict   []domain.MergeBlocker
 }
 
 func (m *statusModel) setSize(width, height int) {
@@ -82,39 +90,35 @@ func (m statusModel) renderContent() string {
 	sections = append(sections, m.renderChecksSection())
 	sections = append(sections, "")
 	sections = append(sections, m.renderApprovalsSection())
+	if blockers := m.renderBlockersSection(); blockers != "" {
+		sections = append(sections, "", blockers)
+	}
 	return strings.Join(sections, "\n")
 }
 
-// isMergeReady mirrors the CLI's IsMergeReady logic.
+// isMergeReady applies the same readiness evaluation as the CLI.
 func (m statusModel) isMergeReady() bool {
 	if m.hasErrors {
 		return false
 	}
-	if m.comments != nil && m.comments.UnresolvedCount > 0 {
-		return false
-	}
-	if m.checks != nil && m.checks.OverallStatus != domain.StatusPass {
-		return false
-	}
-	if m.reviews != nil {
-		for _, r := range m.reviews {
-			if r.State == domain.ReviewChangesRequested {
-				return false
-			}
-		}
-		if !m.solo {
-			hasApproval := false
-			for _, r := range m.reviews {
-				if r.State == domain.ReviewApproved {
-					hasApproval = true
-				}
-			}
-			if !hasApproval {
-				return false
-			}
-		}
-	}
-	return true
+	return len(m.blockers()) == 0
+}
+
+// blockers returns the unmet merge conditions for the loaded data, or the
+// ones the status fetch evaluated.
+func (m statusModel) blockers() []domain.MergeBlocker {
+	if m.evaluated {
+		return m.verdict
+	}
+	return domain.EvaluateReadiness(domain.ReadinessInput{
+		Threads:      m.comments,
+		Checks:       m.checks,
+		Reviews:      m.reviews,
+		PR:           m.pr,
+		Requirements: m.requirements,
+		Policy:       m.policy,
+		Solo:         m.solo,
+	})
 }
 
 // View renders the status dashboard.
@@ -149,6 +153,11 @@ func (m statusModel) View() string {
 	// ── Approvals section ────────────────────────────────
 	sections = append(sections, m.renderApprovalsSection())
 
+	// ── Merge blockers section ───────────────────────────
+	if blockers := m.renderBlockersSection(); blockers != "" {
+		sections = append(sections, "", blockers)
+	}
+
 	content := strings.Join(sections, "\n")
 	allLines := strings.Split(content, "\n")
 	totalLines := len(allLines)
@@ -226,8 +235,14 @@ func (m statusModel) renderKPICards() string {
 		cards = append(cards, m.renderCard(approvalCount, "Approvals", approvalColor))
 	}
 
+	// Merge state card — only once PR metadata has loaded.
+	if m.pr != nil {
+		text, color := mergeStateCard(m.pr)
+		cards = append(cards, m.renderCardText(text, "Merge State", color))
+	}
+
 	// Layout: distribute cards across width.
-	cardWidth := max((m.width-4*3)/4, 10) // 3 gaps between 4 cards
+	cardWidth := max((m.width-3*len(cards))/len(cards), 10) // 3 columns of border/gap per card
 
 	var rendered []string
 	for _, card := range cards {
@@ -320,6 +335,25 @@ func (m statusModel) staleBlockingCount() int {
 	return count
 }
 
+// mergeStateCard summarizes draft/conflict/behind state for the KPI row.
+// Conflicts outrank draft, which outranks being behind.
+func mergeStateCard(pr *domain.PullRequestInfo) (string, lipgloss.Color) {
+	switch {
+	case pr.HasConflicts():
+		return "conflicts", lipgloss.Color(string(styles.Red))
+	case pr.IsDraft:
+		return "draft", lipgloss.Color(string(styles.Yellow))
+	case pr.IsBehind():
+		if pr.BehindBy > 0 {
+			return fmt.Sprintf("%d behind", pr.BehindBy), lipgloss.Color(string(styles.Yellow))
+		}
+		return "behind", lipgloss.Color(string(styles.Yellow))
+	case pr.Mergeable == domain.MergeableUnknown || pr.Mergeable == "":
+		return "checking", lipgloss.Color(string(styles.Dim))
+	}
+	return "clean", lipgloss.Color(string(styles.Green))
+}
+
 // cardColorForCount returns green if count is 0, red otherwise.
 func cardColorForCount(count int, redIfNonZero bool) lipgloss.Color {
 	if redIfNonZero && count > 0 {
@@ -343,6 +377,9 @@ func (m statusModel) renderThreadsSection() string {
 		if m.comments.ResolvedCount > 0 {
 			parts = append(parts, fmt.Sprintf("%d resolved", m.comments.ResolvedCount))
 		}
+		if m.comments.UnansweredConversationCount > 0 {
+			parts = append(parts, fmt.Sprintf("%d unanswered conversation", m.comments.UnansweredConversationCount))
+		}
 		rightInfo = dimStyle.Render(strings.Join(parts, " · "))
 
 		if m.comments.UnresolvedCount == 0 {
@@ -352,7 +389,7 @@ func (m statusModel) renderThreadsSection() string {
 
 	header := m.renderSectionHeader(headerDot, title, rightInfo)
 
-	if m.comments == nil || len(m.comments.Threads) == 0 {
+	if m.comments == nil || (len(m.comments.Threads) == 0 && m.comments.UnansweredConversationCount == 0) {
 		return header + "\n" + dimStyle.Render("   No review threads")
 	}
 
@@ -361,6 +398,9 @@ func (m statusModel) renderThreadsSection() string {
 
 	maxShow := 3
 	threads := m.comments.Threads
+	if len(threads) == 0 {
+		lines = append(lines, dimStyle.Render("   No review threads"))
+	}
 	for i, t := range threads {
 		if i >= maxShow {
 			break
@@ -383,6 +423,24 @@ func (m statusModel) renderThreadsSection() string {
 		lines = append(lines, dimStyle.Render(more))
 	}
 
+	// Top-level conversation feedback nobody has answered yet.
+	shown := 0
+	for _, c := range m.comments.Conversation {
+		if !c.IsUnanswered() {
+			continue
+		}
+		if shown == maxShow {
+			more := fmt.Sprintf("   ... and %d more in conversation", m.comments.UnansweredConversationCount-maxShow)
+			lines = append(lines, dimStyle.Render(more))
+			break
+		}
+		line := "   " + styles.LineNumber.Render(conversationLabel(c)) + " " + dimStyle.Render("—") + " " +
+			styles.Author.Render("@"+c.Author)
+		line = padWithRight(line, dimStyle.Render(formatTimeAgo(c.CreatedAt)), m.width-2)
+		lines = append(lines, line)
+		shown++
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -552,6 +610,35 @@ func (m statusModel) renderApprovalsSection() string {
 	return strings.Join(lines, "\n")
 }
 
+// ── Section: Merge Blockers ──────────────────────────────────────
+
+// renderBlockersSection lists unmet merge conditions. Empty while data is
+// still loading or when the PR is merge-ready.
+func (m statusModel) renderBlockersSection() string {
+	if m.loading {
+		return ""
+	}
+	blockers := m.blockers()
+	if len(blockers) == 0 {
+		return ""
+	}
+
+	rightInfo := "ghent defaults"
+	if m.requirements.HasRules() {
+		rightInfo = "branch rules"
+	}
+	if m.policy != nil {
+		rightInfo += " + policy"
+	}
+	header := m.renderSectionHeader(redStyle.Render("●"), "Merge Blockers", dimStyle.Render(rightInfo))
+
+	lines := []string{header}
+	for _, b := range blockers {
+		lines = append(lines, "   "+redStyle.Render("✗")+" "+b.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // reviewPriority returns a sort key — lower values sort first.
 func reviewPriority(state domain.ReviewState) int {
 	switch state {
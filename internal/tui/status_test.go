@@ -745,3 +745,35 @@ func TestReviewPriority(t *testing.T) {
 		}
 	}
 }
+
+func TestStatusMergeStateCard(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   *domain.PullRequestInfo
+		want string
+	}{
+		{name: "clean", pr: &domain.PullRequestInfo{Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN"}, want: "clean"},
+		{name: "conflicts", pr: &domain.PullRequestInfo{IsDraft: true, Mergeable: "CONFLICTING"}, want: "conflicts"},
+		{name: "draft", pr: &domain.PullRequestInfo{IsDraft: true, Mergeable: "MERGEABLE"}, want: "draft"},
+		{name: "behind", pr: &domain.PullRequestInfo{Mergeable: "MERGEABLE", BehindBy: 4}, want: "4 behind"},
+		{name: "unknown", pr: &domain.PullRequestInfo{Mergeable: "UNKNOWN"}, want: "checking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := statusModel{
+				comments: &domain.CommentsResult{},
+				checks:   &domain.ChecksResult{},
+				pr:       tt.pr,
+			}
+			m.setSize(140, 30)
+			view := m.View()
+			if !strings.Contains(view, "MERGE STATE") {
+				t.Error("missing MERGE STATE label")
+			}
+			if !strings.Contains(view, tt.want) {
+				t.Errorf("merge state card missing %q", tt.want)
+			}
+		})
+	}
+}
@@ -275,7 +275,7 @@ func (m watcherModel) makeEvent(ch domain.CheckRun) watchEvent {
 	icon := greenStyle.Render("✓")
 	detail := ""
 	switch ch.Conclusion {
-	case "failure", "timed_out":
+	case "failure", "timed_out", "error":
 		icon = redStyle.Render("✗")
 	case "skipped", "cancelled":
 		icon = dimStyle.Render("—")
@@ -586,7 +586,7 @@ func (m watcherModel) renderCheckList() string {
 		case ch.Status == "completed" && ch.Conclusion == "success":
 			statusStyle = greenStyle
 			status = "passed"
-		case ch.Status == "completed" && (ch.Conclusion == "failure" || ch.Conclusion == "timed_out"):
+		case ch.Status == "completed" && (ch.Conclusion == "failure" || ch.Conclusion == "timed_out" || ch.Conclusion == "error"):
 			nameStyle = nameStyle.Foreground(lipgloss.Color(string(styles.Red)))
 			statusStyle = redStyle
 			status = ch.Conclusion